@@ -1,13 +1,113 @@
 package main
 
 import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/handlers"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/dictionary-service/worker"
+	gpt "MentorTools/gpt-service/services"
+	"MentorTools/pkg/config"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout - сколько ждать завершения активных запросов при остановке сервиса
+const shutdownTimeout = 15 * time.Second
+
+// runner - фоновый обработчик, который работает до отмены ctx
+type runner interface {
+	Run(ctx context.Context)
+}
+
 func main() {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprintln(w, "Service is running")
+	configPath := flag.String("config", "/app/config/config.yaml", "path to the configuration file")
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Пул соединений с базой словаря; недостающие миграции применяются внутри InitDB, если включён auto_migrate
+	dbpool, err := repository.InitDB(ctx, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	// Привязки учеников к репетиторам словарь копирует из auth_db user-service
+	authdb, err := repository.ConnectAuthDB(ctx, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to auth database: %v", err)
+	}
+	defer authdb.Close()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := frequency.LoadFiles(cfg.FrequencyLists); err != nil {
+		log.Fatalf("Failed to load frequency lists: %v", err)
+	}
+	media, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to create media storage: %v", err)
+	}
+
+	enrichment := worker.NewEnrichmentWorker(dbpool, worker.DefaultEnrichmentConfig)
+	jobs := worker.NewWordJobWorker(dbpool, worker.DefaultWordJobConfig)
+	workers := []runner{
+		enrichment,
+		jobs,
+		worker.NewAudioWorker(dbpool, media, gpt.NewOpenAISpeech(), worker.DefaultAudioConfig),
+		worker.NewLevelWorker(dbpool, worker.DefaultLevelConfig),
+		worker.NewLessonScheduler(dbpool, worker.DefaultLessonSchedulerConfig),
+		worker.NewLinkSyncWorker(dbpool, authdb, worker.DefaultLinkSyncConfig),
+	}
+
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w runner) {
+			defer wg.Done()
+			w.Run(ctx)
+		}(w)
+	}
+
+	mux := http.NewServeMux()
+	handlers.RegisterRoutes(mux, dbpool, enrichment, jobs, media, gpt.NewOpenAIImages())
+
+	// Health check route
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Dictionary-service is running")
 	})
-	http.ListenAndServe(":8080", nil)
+
+	server := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown error: %v", err)
+		}
+	}()
+
+	log.Printf("Starting dictionary-service on %s", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server failed to start: %v", err)
+	}
+
+	// Сервер остановлен по сигналу: ждём, пока фоновые обработчики завершат текущую работу
+	stop()
+	wg.Wait()
+	log.Printf("Dictionary-service stopped")
 }
@@ -0,0 +1,86 @@
+// Команда frequency-list переводит открытый частотный список словоформ в формат частотных списков сервиса
+// ("<ранг> <лемма> <уровень>"). На входе строки "<слово> <частота>" через пробел или табуляцию: так выглядят
+// выгрузка wordfreq и SUBTLEX (лишние колонки и строка заголовка пропускаются). Формы одного слова
+// складываются в лемму нормализатором, уровень CEFR приблизительно выводится из ранга (frequency.LevelForRank).
+//
+//	frequency-list -in subtlex-us.txt -top 20000 -source "SUBTLEX-US" > frequency/en.txt
+package main
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/normalizer"
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	inPath := flag.String("in", "", "word frequency list: \"<word> <count>\" per line")
+	language := flag.String("lang", "en", "language of the list, ISO 639-1")
+	top := flag.Int("top", 20000, "number of lemmas to keep")
+	source := flag.String("source", "", "source name and version for the header comment")
+	flag.Parse()
+
+	if *inPath == "" || *top <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*inPath)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *inPath, err)
+	}
+	defer file.Close()
+
+	counts := make(map[string]float64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		// Строка заголовка и строки без числа во второй колонке не содержат данных
+		count, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		result := normalizer.NormalizeFor(fields[0], *language)
+		if result.Lemma == "" || result.IsPhrase {
+			continue
+		}
+		counts[result.Lemma] += count
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("Failed to read %s: %v", *inPath, err)
+	}
+
+	lemmas := make([]string, 0, len(counts))
+	for lemma := range counts {
+		lemmas = append(lemmas, lemma)
+	}
+	sort.Slice(lemmas, func(i, j int) bool {
+		if counts[lemmas[i]] != counts[lemmas[j]] {
+			return counts[lemmas[i]] > counts[lemmas[j]]
+		}
+		return lemmas[i] < lemmas[j]
+	})
+	if len(lemmas) > *top {
+		lemmas = lemmas[:*top]
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	if *source != "" {
+		fmt.Fprintf(out, "# Источник: %s\n", *source)
+	}
+	fmt.Fprintln(out, "# Уровни выведены из ранга (frequency.LevelForRank) и приблизительны")
+	fmt.Fprintln(out, "# Формат строки: <ранг> <лемма> <уровень A1-C2>")
+	for i, lemma := range lemmas {
+		fmt.Fprintf(out, "%d %s %s\n", i+1, lemma, frequency.LevelForRank(i+1))
+	}
+}
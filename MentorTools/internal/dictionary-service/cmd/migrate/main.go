@@ -0,0 +1,72 @@
+// Команда migrate применяет к базе словаря недостающие миграции схемы.
+// С флагом -status печатает список миграций и время их применения, не меняя базу.
+// База, созданная вручную до появления миграций, один раз отмечается флагом -baseline:
+// миграции до указанной версии записываются как применённые без выполнения.
+//
+//	migrate
+//	migrate -status
+//	migrate -baseline 21
+package main
+
+import (
+	"MentorTools/dictionary-service/migrations"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	configPath := flag.String("config", "/app/config/config.yaml", "path to the configuration file")
+	status := flag.Bool("status", false, "print migrations and exit")
+	baseline := flag.Int("baseline", 0, "mark migrations up to this version as applied without running them")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dbpool, _, err := repository.ConnectDictionaryDB(ctx, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	switch {
+	case *status:
+		statuses, err := migrations.GetStatus(ctx, dbpool)
+		if err != nil {
+			log.Fatalf("Failed to read migration history: %v", err)
+		}
+		for _, migration := range statuses {
+			appliedAt := "pending"
+			if migration.AppliedAt != nil {
+				appliedAt = migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-50s %s\n", migration.Name, appliedAt)
+		}
+
+	case *baseline > 0:
+		marked, err := migrations.Baseline(ctx, dbpool, *baseline)
+		if err != nil {
+			log.Fatalf("Baseline failed: %v", err)
+		}
+		log.Printf("Marked %d migrations as applied", len(marked))
+
+	default:
+		applied, err := migrations.Apply(ctx, dbpool)
+		for _, migration := range applied {
+			log.Printf("Applied migration %s", migration.Name)
+		}
+		if errors.Is(err, migrations.ErrUnversionedSchema) {
+			log.Fatalf("%v: pass the version of the last migration already present in the database", err)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Printf("Database is up to date, applied %d migrations", len(applied))
+	}
+}
@@ -0,0 +1,46 @@
+// Команда regenerate-content перегенерирует контент слов, созданный старой версией промпта GPT.
+//
+//	regenerate-content -prompt-version 2 -limit 500 -rpm 20
+//
+// Новые версии сразу становятся активными; прежние остаются в истории версий слова.
+package main
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/worker"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	configPath := flag.String("config", "/app/config/config.yaml", "path to the configuration file")
+	promptVersion := flag.Int("prompt-version", gpt.PromptVersion, "regenerate content produced by prompt versions older than this")
+	limit := flag.Int("limit", 0, "maximum number of words to regenerate, 0 - all")
+	rpm := flag.Int("rpm", worker.DefaultEnrichmentConfig.RequestsPerMinute, "GPT requests per minute")
+	flag.Parse()
+
+	if *rpm <= 0 {
+		log.Fatalf("rpm must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dbpool, err := repository.InitDB(ctx, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	report, err := services.RegenerateOutdated(ctx, dbpool, *promptVersion, *limit, time.Minute/time.Duration(*rpm))
+	log.Printf("Regenerated %d words, failed %d", report.Regenerated, report.Failed)
+	if err != nil {
+		log.Fatalf("Regeneration stopped: %v", err)
+	}
+}
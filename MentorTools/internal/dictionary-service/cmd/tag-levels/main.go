@@ -0,0 +1,54 @@
+// Команда tag-levels присваивает уровень CEFR и частотный ранг заполненным словам, у которых его ещё нет:
+// слова из частотного списка размечаются сразу, остальные - по оценке GPT. Частотные списки берутся
+// из настройки frequency_lists файла конфигурации, а если она не задана - встроенные.
+//
+//	tag-levels -limit 1000 -rpm 20
+package main
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/worker"
+	"MentorTools/pkg/config"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	configPath := flag.String("config", "/app/config/config.yaml", "path to the configuration file")
+	limit := flag.Int("limit", 0, "maximum number of words to tag, 0 - all")
+	rpm := flag.Int("rpm", worker.DefaultEnrichmentConfig.RequestsPerMinute, "GPT requests per minute")
+	flag.Parse()
+
+	if *rpm <= 0 {
+		log.Fatalf("rpm must be positive")
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := frequency.LoadFiles(cfg.FrequencyLists); err != nil {
+		log.Fatalf("Failed to load frequency lists: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dbpool, err := repository.InitDB(ctx, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	report, err := services.TagUntaggedWords(ctx, dbpool, *limit, time.Minute/time.Duration(*rpm))
+	log.Printf("Tagged %d words, failed %d", report.Tagged, report.Failed)
+	if err != nil {
+		log.Fatalf("Tagging stopped: %v", err)
+	}
+}
@@ -0,0 +1,334 @@
+package exporter
+
+import (
+	"MentorTools/dictionary-service/models"
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Драйвер SQLite: колода Anki - это zip-архив с базой SQLite
+)
+
+// ankiSchema - схема коллекции Anki 2.1 (collection.anki2, версия 11), которую понимают все версии Anki
+const ankiSchema = `
+CREATE TABLE col (
+    id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+    ver integer not null, dty integer not null, usn integer not null, ls integer not null,
+    conf text not null, models text not null, decks text not null, dconf text not null, tags text not null
+);
+CREATE TABLE notes (
+    id integer primary key, guid text not null, mid integer not null, mod integer not null,
+    usn integer not null, tags text not null, flds text not null, sfld integer not null,
+    csum integer not null, flags integer not null, data text not null
+);
+CREATE TABLE cards (
+    id integer primary key, nid integer not null, did integer not null, ord integer not null,
+    mod integer not null, usn integer not null, type integer not null, queue integer not null,
+    due integer not null, ivl integer not null, factor integer not null, reps integer not null,
+    lapses integer not null, left integer not null, odue integer not null, odid integer not null,
+    flags integer not null, data text not null
+);
+CREATE TABLE revlog (
+    id integer primary key, cid integer not null, usn integer not null, ivl integer not null,
+    lastIvl integer not null, factor integer not null, time integer not null, type integer not null
+);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// ankiFields - поля заметки экспортируемой колоды
+var ankiFields = []string{"Word", "Transcription", "Translation", "Description", "Examples", "Image"}
+
+// ankiCSS - оформление карточек
+const ankiCSS = `.card { font-family: arial; font-size: 20px; text-align: center; color: black; background-color: white; }
+.transcription { color: #666; }
+.description { font-size: 16px; }
+.examples { font-size: 16px; text-align: left; }
+.image img { max-width: 100%; max-height: 320px; }`
+
+// Шаблоны карточки: на лицевой стороне слово, на обратной - картинка, транскрипция, перевод, описание и примеры
+const (
+	ankiQuestion = `{{Word}}`
+	ankiAnswer   = `{{FrontSide}}<hr id=answer>
+<div class="image">{{Image}}</div>
+<div class="transcription">{{Transcription}}</div>
+<div>{{Translation}}</div>
+<div class="description">{{Description}}</div>
+<div class="examples">{{Examples}}</div>`
+)
+
+// WriteAnki записывает слова в колоду Anki (.apkg) с именем deckName.
+// GUID заметки вычисляется из слова, поэтому повторный импорт той же колоды в Anki
+// обновляет существующие карточки, а не создаёт дубликаты. Миниатюры картинок слов
+// кладутся в архив медиафайлами колоды.
+func WriteAnki(w io.Writer, words []models.ExportWord, deckName string) error {
+	collection, err := os.CreateTemp("", "anki-export-*.anki2")
+	if err != nil {
+		return err
+	}
+	collection.Close()
+	defer os.Remove(collection.Name())
+
+	if err := buildAnkiCollection(collection.Name(), words, deckName); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(collection.Name())
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("collection.anki2")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+
+	// Медиафайлы лежат в архиве под номерами, а файл media сопоставляет номер с именем файла в коллекции
+	mediaNames := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, word := range words {
+		name := ankiImageName(word)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		number := strconv.Itoa(len(mediaNames))
+		file, err := archive.Create(number)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(word.Thumbnail); err != nil {
+			return err
+		}
+		mediaNames[number] = name
+	}
+
+	mediaJSON, err := json.Marshal(mediaNames)
+	if err != nil {
+		return err
+	}
+	media, err := archive.Create("media")
+	if err != nil {
+		return err
+	}
+	if _, err := media.Write(mediaJSON); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// buildAnkiCollection создаёт базу коллекции Anki по пути path
+func buildAnkiCollection(path string, words []models.ExportWord, deckName string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to create Anki collection: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(ankiSchema); err != nil {
+		return fmt.Errorf("failed to create Anki collection: %w", err)
+	}
+
+	now := time.Now()
+	nowMillis := now.UnixMilli()
+	// ID модели и колоды в Anki - метки времени в миллисекундах
+	modelID := nowMillis
+	deckID := nowMillis + 1
+
+	conf, noteModels, decks, dconf, err := ankiCollectionJSON(now, modelID, deckID, deckName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		now.Unix(), nowMillis, nowMillis, conf, noteModels, decks, dconf)
+	if err != nil {
+		return fmt.Errorf("failed to write Anki collection: %w", err)
+	}
+
+	for i, word := range words {
+		noteID := nowMillis + int64(i)
+		fields := []string{
+			html.EscapeString(word.Word),
+			html.EscapeString(word.Transcription),
+			html.EscapeString(word.Translation),
+			html.EscapeString(word.Description),
+			ankiExamples(word.Examples),
+			ankiImage(word),
+		}
+
+		_, err := tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			noteID, ankiGUID(word.Word), modelID, now.Unix(), ankiTags(word), strings.Join(fields, "\x1f"),
+			word.Word, ankiChecksum(word.Word))
+		if err != nil {
+			return fmt.Errorf("failed to write Anki note: %w", err)
+		}
+
+		// Новая карточка: type = 0, queue = 0, due - порядковый номер в очереди новых
+		_, err = tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+			noteID, noteID, deckID, now.Unix(), i+1)
+		if err != nil {
+			return fmt.Errorf("failed to write Anki card: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ankiCollectionJSON собирает JSON-настройки коллекции: общие настройки, модель заметки, колоды и параметры колод
+func ankiCollectionJSON(now time.Time, modelID, deckID int64, deckName string) (conf, noteModels, decks, dconf string, err error) {
+	fields := make([]map[string]interface{}, len(ankiFields))
+	for i, name := range ankiFields {
+		fields[i] = map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": false,
+			"font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+
+	model := map[string]interface{}{
+		"id": modelID, "name": "MentorTools Word", "type": 0, "mod": now.Unix(), "usn": -1,
+		"sortf": 0, "did": deckID, "flds": fields, "css": ankiCSS, "tags": []string{}, "vers": []int{},
+		"tmpls": []map[string]interface{}{{
+			"name": "Word", "ord": 0, "qfmt": ankiQuestion, "afmt": ankiAnswer,
+			"did": nil, "bqfmt": "", "bafmt": "",
+		}},
+		"req":       []interface{}{[]interface{}{0, "any", []int{0}}},
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+	}
+
+	deck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "mod": now.Unix(), "usn": -1, "desc": "", "dyn": 0, "conf": 1,
+			"collapsed": false, "extendNew": 10, "extendRev": 50,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+
+	options := map[string]interface{}{
+		"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0,
+		"replayq": true, "dyn": false,
+		"new": map[string]interface{}{
+			"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500,
+			"order": 1, "perDay": 20, "bury": true, "separate": true,
+		},
+		"rev": map[string]interface{}{
+			"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "ivlFct": 1, "maxIvl": 36500,
+			"minSpace": 1, "bury": true,
+		},
+		"lapse": map[string]interface{}{
+			"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0,
+		},
+	}
+
+	collectionConf := map[string]interface{}{
+		"nextPos": 1, "estTimes": true, "activeDecks": []int64{deckID}, "sortType": "noteFld",
+		"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": deckID, "newSpread": 0,
+		"dueCounts": true, "curModel": strconv.FormatInt(modelID, 10), "collapseTime": 1200,
+	}
+
+	values := []interface{}{
+		collectionConf,
+		map[string]interface{}{strconv.FormatInt(modelID, 10): model},
+		map[string]interface{}{"1": deck(1, "Default"), strconv.FormatInt(deckID, 10): deck(deckID, deckName)},
+		map[string]interface{}{"1": options},
+	}
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		encoded[i] = string(data)
+	}
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
+
+// ankiExamples оформляет примеры списком HTML
+func ankiExamples(examples []models.Example) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<ul>")
+	for _, example := range examples {
+		b.WriteString("<li>")
+		b.WriteString(html.EscapeString(example.Sentence))
+		if example.Translation != "" {
+			b.WriteString("<br><i>")
+			b.WriteString(html.EscapeString(example.Translation))
+			b.WriteString("</i>")
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
+
+// ankiImageName - имя медиафайла миниатюры в колоде: последний сегмент ключа, который выводится из содержимого
+// и поэтому совпадает у одинаковых картинок. Пусто, если миниатюра не загружена.
+func ankiImageName(word models.ExportWord) string {
+	if len(word.Thumbnail) == 0 || word.ThumbnailKey == "" {
+		return ""
+	}
+	return path.Base(word.ThumbnailKey)
+}
+
+// ankiImage - поле с картинкой слова
+func ankiImage(word models.ExportWord) string {
+	name := ankiImageName(word)
+	if name == "" {
+		return ""
+	}
+	return `<img src="` + html.EscapeString(name) + `">`
+}
+
+// ankiTags - теги заметки: статус слова у ученика; пробелы в тегах Anki недопустимы
+func ankiTags(word models.ExportWord) string {
+	if word.Status == "" {
+		return ""
+	}
+	return " " + strings.ReplaceAll(word.Status, " ", "_") + " "
+}
+
+// ankiGUID - стабильный GUID заметки для слова
+func ankiGUID(word string) string {
+	sum := sha1.Sum([]byte("mentortools:" + word))
+	return hex.EncodeToString(sum[:])[:10]
+}
+
+// ankiChecksum - контрольная сумма первого поля, по которой Anki ищет дубликаты:
+// первые 8 шестнадцатеричных цифр SHA-1
+func ankiChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	checksum, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+	return checksum
+}
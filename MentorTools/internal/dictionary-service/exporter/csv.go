@@ -0,0 +1,46 @@
+package exporter
+
+import (
+	"MentorTools/dictionary-service/models"
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvHeader - колонки CSV-экспорта; файл читается обратно импортом с word_column=word
+var csvHeader = []string{"word", "typed_form", "transcription", "translation", "description", "status", "deck", "examples", "image_url"}
+
+// WriteCSV записывает слова в CSV. Файл начинается с BOM, чтобы Excel правильно открыл кириллицу;
+// примеры собираются в одну колонку в виде "предложение - перевод", разделённые " | ".
+func WriteCSV(w io.Writer, words []models.ExportWord) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, word := range words {
+		examples := make([]string, 0, len(word.Examples))
+		for _, example := range word.Examples {
+			if example.Translation == "" {
+				examples = append(examples, example.Sentence)
+				continue
+			}
+			examples = append(examples, example.Sentence+" - "+example.Translation)
+		}
+
+		err := writer.Write([]string{
+			word.Word, word.TypedForm, word.Transcription, word.Translation,
+			word.Description, word.Status, word.Deck, strings.Join(examples, " | "), word.ImageURL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,142 @@
+package exporter
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/normalizer"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// gap - пропуск на месте слова в упражнении
+const gap = "__________"
+
+// Exercise - упражнение на заполнение пропуска
+type Exercise struct {
+	Sentence string // Предложение с пропуском
+	Answer   string // Слово в той форме, в которой оно стояло в предложении
+	Word     string // Лемма для банка слов
+}
+
+// BuildExercises составляет упражнения на заполнение пропусков из примеров слов:
+// для каждого слова берётся первый пример, в котором слово удалось найти в любой форме.
+// Упражнения упорядочены по тексту предложения, а не по слову: их порядок не совпадает с банком слов,
+// который идёт по алфавиту, и при этом одинаков при каждой выгрузке.
+func BuildExercises(words []models.ExportWord) []Exercise {
+	var exercises []Exercise
+	for _, word := range words {
+		for _, example := range word.Examples {
+			sentence, answer, ok := gapSentence(example.Sentence, word.Word)
+			if ok {
+				exercises = append(exercises, Exercise{Sentence: sentence, Answer: answer, Word: word.Word})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(exercises, func(i, j int) bool {
+		return exercises[i].Sentence < exercises[j].Sentence
+	})
+	return exercises
+}
+
+// gapSentence заменяет в предложении слово или выражение lemma пропуском.
+// Слова предложения сравниваются с леммой после нормализации, поэтому находятся
+// и формы слова ("went" для "go", "gave up" для "give up").
+func gapSentence(sentence, lemma string) (string, string, bool) {
+	tokens := strings.Fields(sentence)
+	size := len(strings.Fields(lemma))
+	if size == 0 {
+		return "", "", false
+	}
+
+	for i := 0; i+size <= len(tokens); i++ {
+		cores := make([]string, size)
+		for j := range cores {
+			cores[j] = trimPunct(tokens[i+j])
+		}
+		candidate := strings.Join(cores, " ")
+		if candidate == "" || !matchesLemma(candidate, lemma) {
+			continue
+		}
+
+		first, last := tokens[i], tokens[i+size-1]
+		prefix := first[:strings.Index(first, cores[0])]
+		suffix := last[strings.LastIndex(last, cores[size-1])+len(cores[size-1]):]
+
+		gapped := append([]string{}, tokens[:i]...)
+		gapped = append(gapped, prefix+gap+suffix)
+		gapped = append(gapped, tokens[i+size:]...)
+		return strings.Join(gapped, " "), candidate, true
+	}
+	return "", "", false
+}
+
+// matchesLemma проверяет, является ли фрагмент предложения формой леммы
+func matchesLemma(fragment, lemma string) bool {
+	normalized := normalizer.Normalize(fragment)
+	if normalized.Typed == lemma {
+		return true
+	}
+	for _, candidate := range normalized.Candidates {
+		if candidate == lemma {
+			return true
+		}
+	}
+	return false
+}
+
+// trimPunct отбрасывает знаки препинания по краям слова
+func trimPunct(token string) string {
+	return strings.TrimFunc(token, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WriteWorksheet записывает PDF-листок для печати: банк слов, предложения с пропусками
+// и ключ с ответами на отдельной странице. Встроенные шрифты PDF не содержат кириллицы,
+// поэтому в листок попадают только английские предложения без переводов.
+func WriteWorksheet(w io.Writer, title string, exercises []Exercise) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.MultiCell(0, 8, tr(title), "", "L", false)
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.MultiCell(0, 6, "Fill in the gaps with the words from the box. You may need to change the form of the word.", "", "L", false)
+	pdf.Ln(4)
+
+	bank := make([]string, 0, len(exercises))
+	for _, exercise := range exercises {
+		bank = append(bank, exercise.Word)
+	}
+	sort.Strings(bank)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.MultiCell(0, 8, tr(strings.Join(bank, "   |   ")), "1", "C", false)
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "", 12)
+	for i, exercise := range exercises {
+		pdf.MultiCell(0, 7, tr(strconv.Itoa(i+1)+". "+exercise.Sentence), "", "L", false)
+		pdf.Ln(3)
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.MultiCell(0, 8, "Answer key", "", "L", false)
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "", 12)
+	for i, exercise := range exercises {
+		pdf.MultiCell(0, 7, tr(strconv.Itoa(i+1)+". "+exercise.Answer), "", "L", false)
+	}
+
+	return pdf.Output(w)
+}
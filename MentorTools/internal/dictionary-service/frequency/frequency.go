@@ -0,0 +1,142 @@
+// Package frequency хранит частотные списки лемм с уровнями CEFR.
+//
+// Встроенный список en.txt - небольшой список частых слов, составленный вручную для разработки и тестов
+// (см. README.md). Для работы с реальными учениками его заменяют лицензированным списком в том же формате
+// через настройку frequency_lists: списки загружаются при запуске функцией LoadFiles.
+package frequency
+
+import (
+	"MentorTools/dictionary-service/models"
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed en.txt
+var englishList string
+
+// lists - частотные списки по языкам: лемма -> запись
+var lists = map[string]map[string]Entry{
+	"en": mustParseList("en.txt", englishList),
+}
+
+// Entry - место слова в частотном списке
+type Entry struct {
+	Rank  int    // Ранг по частоте употребления, 1 - самое частое слово
+	Level string // Уровень CEFR (A1-C2)
+}
+
+// Частотные полосы
+const (
+	BandTop1000  = "top-1000"
+	BandTop3000  = "top-3000"
+	BandTop5000  = "top-5000"
+	BandTop10000 = "top-10000"
+	BandRare     = "rare"
+)
+
+// Lookup ищет лемму в частотном списке языка language. Списки есть не для всех языков,
+// поэтому отсутствие слова значит лишь, что уровень нужно определить другим способом.
+func Lookup(lemma, language string) (Entry, bool) {
+	entry, ok := lists[language][lemma]
+	return entry, ok
+}
+
+// LoadFiles заменяет списки языков списками из файлов в формате "<ранг> <лемма> <уровень>": ключ paths -
+// код языка ISO 639-1, значение - путь к файлу. Вызывается при запуске, до обработки запросов.
+// Файл с ошибкой (строка не в формате, неизвестный уровень) не загружается: ошибка называет номер строки.
+func LoadFiles(paths map[string]string) error {
+	for language, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read frequency list %q: %w", path, err)
+		}
+		entries, err := parseList(string(data))
+		if err != nil {
+			return fmt.Errorf("frequency list %q: %w", path, err)
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("frequency list %q has no entries", path)
+		}
+		lists[language] = entries
+	}
+	return nil
+}
+
+// Band возвращает частотную полосу для ранга слова
+func Band(rank int) string {
+	switch {
+	case rank <= 1000:
+		return BandTop1000
+	case rank <= 3000:
+		return BandTop3000
+	case rank <= 5000:
+		return BandTop5000
+	case rank <= 10000:
+		return BandTop10000
+	default:
+		return BandRare
+	}
+}
+
+// LevelForRank - приблизительный уровень CEFR по частотному рангу для списков без разметки уровней
+// (wordfreq, SUBTLEX): частые слова осваивают раньше, но тематическую лексику ранг недооценивает
+func LevelForRank(rank int) string {
+	switch {
+	case rank <= 800:
+		return "A1"
+	case rank <= 1600:
+		return "A2"
+	case rank <= 3000:
+		return "B1"
+	case rank <= 5000:
+		return "B2"
+	case rank <= 10000:
+		return "C1"
+	default:
+		return "C2"
+	}
+}
+
+// parseList разбирает частотный список в формате "<ранг> <лемма> <уровень>";
+// пустые строки и строки с "#" пропускаются, на первой строке с ошибкой разбор прекращается
+func parseList(list string) (map[string]Entry, error) {
+	entries := make(map[string]Entry)
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: want \"<rank> <lemma> <level>\", got %q", line, text)
+		}
+		rank, err := strconv.Atoi(fields[0])
+		if err != nil || rank < 1 {
+			return nil, fmt.Errorf("line %d: invalid rank %q", line, fields[0])
+		}
+		if !models.IsCEFRLevel(fields[2]) {
+			return nil, fmt.Errorf("line %d: invalid CEFR level %q", line, fields[2])
+		}
+		// Для повторяющихся лемм побеждает более частая запись
+		if existing, exists := entries[fields[1]]; !exists || rank < existing.Rank {
+			entries[fields[1]] = Entry{Rank: rank, Level: fields[2]}
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// mustParseList разбирает встроенный список; ошибка в нём - ошибка сборки, поэтому она прерывает запуск
+func mustParseList(name, list string) map[string]Entry {
+	entries, err := parseList(list)
+	if err != nil {
+		panic(fmt.Sprintf("built-in frequency list %s: %v", name, err))
+	}
+	return entries
+}
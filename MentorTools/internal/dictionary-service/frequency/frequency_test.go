@@ -0,0 +1,92 @@
+package frequency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFiles(t *testing.T) {
+	builtin := lists["en"]
+	t.Cleanup(func() { lists["en"] = builtin })
+
+	path := filepath.Join(t.TempDir(), "en.txt")
+	list := "# Тестовый список\n1 the A1\n2 serendipity C2\n\n3 the B1\n"
+	if err := os.WriteFile(path, []byte(list), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFiles(map[string]string{"en": path}); err != nil {
+		t.Fatalf("LoadFiles: %v", err)
+	}
+
+	if entry, ok := Lookup("serendipity", "en"); !ok || entry != (Entry{Rank: 2, Level: "C2"}) {
+		t.Errorf("Lookup(serendipity) = %+v, %v; want rank 2, C2", entry, ok)
+	}
+	// Для повторяющейся леммы остаётся более частая запись
+	if entry, _ := Lookup("the", "en"); entry.Level != "A1" {
+		t.Errorf("Lookup(the) = %+v, want the first entry", entry)
+	}
+	// Загруженный список заменяет встроенный целиком
+	if _, ok := Lookup("time", "en"); ok {
+		t.Errorf("word of the built-in list is still found after LoadFiles")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("# нет записей\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{empty, filepath.Join(t.TempDir(), "missing.txt")} {
+		if err := LoadFiles(map[string]string{"en": path}); err == nil {
+			t.Errorf("LoadFiles(%s): no error", filepath.Base(path))
+		}
+	}
+}
+
+func TestParseListErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want string
+	}{
+		{"missing level", "1 the A1\n2 time\n", "line 2"},
+		{"extra field", "1 the A1 x\n", "line 1"},
+		{"invalid rank", "# заголовок\nfirst the A1\n", `line 2: invalid rank "first"`},
+		{"zero rank", "0 the A1\n", "invalid rank"},
+		{"invalid level", "1 the A1\n2 time D1\n", `line 2: invalid CEFR level "D1"`},
+		{"lowercase level", "1 the a1\n", "invalid CEFR level"},
+	}
+	for _, tt := range tests {
+		if _, err := parseList(tt.list); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: parseList err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestLoadFilesKeepsListOnError(t *testing.T) {
+	builtin := lists["en"]
+	t.Cleanup(func() { lists["en"] = builtin })
+
+	path := filepath.Join(t.TempDir(), "en.txt")
+	if err := os.WriteFile(path, []byte("1 the A1\nbroken line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := LoadFiles(map[string]string{"en": path})
+	if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("LoadFiles err = %v, want path and line number", err)
+	}
+	if _, ok := Lookup("time", "en"); !ok {
+		t.Errorf("built-in list was replaced by a list with errors")
+	}
+}
+
+func TestLevelForRank(t *testing.T) {
+	for rank, want := range map[int]string{
+		1: "A1", 800: "A1", 801: "A2", 1600: "A2", 3000: "B1",
+		3001: "B2", 5000: "B2", 10000: "C1", 10001: "C2", 60000: "C2",
+	} {
+		if got := LevelForRank(rank); got != want {
+			t.Errorf("LevelForRank(%d) = %s, want %s", rank, got, want)
+		}
+	}
+}
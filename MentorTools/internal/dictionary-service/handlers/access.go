@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/pkg/common"
+	"MentorTools/pkg/middleware"
+	services2 "MentorTools/user-service/services"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// tutorRole - название роли репетитора в таблице roles
+const tutorRole = "tutor"
+
+// userIDFromRequest - определяет пользователя по токену запроса; тесты обработчиков подменяют его
+var userIDFromRequest = services2.GetUserIDFromToken
+
+// isTutor - проверяет, что запрос выполняет репетитор
+func isTutor(r *http.Request) bool {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		return false
+	}
+	role, _ := claims["role"].(string)
+	return role == tutorRole
+}
+
+// studentIDFromQuery - возвращает ID ученика из параметра student_id,
+// а если параметр не передан, то ID текущего пользователя
+func studentIDFromQuery(r *http.Request, userID int) (int, error) {
+	param := r.URL.Query().Get("student_id")
+	if param == "" {
+		return userID, nil
+	}
+	return strconv.Atoi(param)
+}
+
+// accessChecker - проверка доступа пользователя к данным ученика
+type accessChecker func(ctx context.Context, userID, studentID int) (bool, error)
+
+// authorizeStudent - определяет ученика по параметру student_id и проверяет доступ к его данным.
+// При ошибке сам отвечает клиенту и возвращает false.
+func authorizeStudent(w http.ResponseWriter, r *http.Request, dbpool *pgxpool.Pool) (int, bool) {
+	return authorizeStudentWith(w, r, func(ctx context.Context, userID, studentID int) (bool, error) {
+		return repository.CanAccessStudent(ctx, dbpool, userID, studentID)
+	})
+}
+
+// authorizeStudentWith - то же, что authorizeStudent, но доступ проверяет canAccess
+func authorizeStudentWith(w http.ResponseWriter, r *http.Request, canAccess accessChecker) (int, bool) {
+	userID, err := userIDFromRequest(r)
+	if err != nil {
+		common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+		return 0, false
+	}
+
+	studentID, err := studentIDFromQuery(r, userID)
+	if err != nil {
+		common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid student id"))
+		return 0, false
+	}
+
+	allowed, err := canAccess(r.Context(), userID, studentID)
+	if err != nil {
+		common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to check access"))
+		return 0, false
+	}
+	if !allowed {
+		common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+		return 0, false
+	}
+	return studentID, true
+}
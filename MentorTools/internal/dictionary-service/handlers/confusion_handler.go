@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Размер списка пар слов, которые путает ученик
+const (
+	defaultConfusions = 10
+	maxConfusions     = 50
+)
+
+// GetConfusionsHandler - обработчик списка пар слов, которые ученик путает чаще всего (параметр limit).
+// Пары строятся по неправильным ответам при повторении, в тестах и в тренировке написания.
+// Ученик видит свои пары, репетитор - пары привязанного ученика по параметру student_id.
+func GetConfusionsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		limit, err := intQueryParam(r, "limit", defaultConfusions)
+		if err != nil || limit <= 0 || limit > maxConfusions {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid limit"))
+			return
+		}
+
+		pairs, err := repository.GetConfusionPairs(r.Context(), dbpool, studentID, limit)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve confusions"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Confusions", pairs))
+	}
+}
+
+// GetContrastiveExerciseHandler - обработчик упражнения на различение двух слов (word_id и other_word_id),
+// которые путает ученик: пары предложений с пропуском, отличающихся минимально
+func GetContrastiveExerciseHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		wordID, err := intQueryParam(r, "word_id", 0)
+		if err != nil || wordID <= 0 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+		otherWordID, err := intQueryParam(r, "other_word_id", 0)
+		if err != nil || otherWordID <= 0 || otherWordID == wordID {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid other word id"))
+			return
+		}
+
+		exercise, err := services.GetContrastiveExercise(r.Context(), dbpool, studentID, wordID, otherWordID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Student has not confused these words"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to generate exercise"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Contrastive exercise", exercise))
+	}
+}
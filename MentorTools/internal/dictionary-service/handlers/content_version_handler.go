@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RegenerateWordHandler - обработчик для перегенерации контента слова (?id=), доступен только репетитору.
+// Новая версия сразу становится активной; с параметром activate=false она только сохраняется,
+// чтобы её можно было сравнить с текущей и выбрать позже.
+func RegenerateWordHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		activate := true
+		if value := r.URL.Query().Get("activate"); value != "" {
+			if activate, err = strconv.ParseBool(value); err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid activate flag"))
+				return
+			}
+		}
+
+		version, err := services.RegenerateWord(r.Context(), dbpool, wordID, &tutorID, activate)
+		if !writeVersionError(w, err, "Failed to regenerate word content") {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusCreated, common.NewSuccessResponse("Word content regenerated", version))
+	}
+}
+
+// GetContentVersionsHandler - обработчик для получения всех версий контента слова (?id=)
+func GetContentVersionsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		versions, err := repository.GetContentVersions(r.Context(), dbpool, wordID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve content versions"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Content versions", versions))
+	}
+}
+
+// CompareContentVersionsHandler - обработчик для сравнения двух версий контента слова (?id=&from=&to=)
+func CompareContentVersionsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		wordID, err := strconv.Atoi(query.Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+		from, errFrom := strconv.Atoi(query.Get("from"))
+		to, errTo := strconv.Atoi(query.Get("to"))
+		if errFrom != nil || errTo != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid version number"))
+			return
+		}
+
+		comparison, err := services.CompareContentVersions(r.Context(), dbpool, wordID, from, to)
+		if !writeVersionError(w, err, "Failed to compare content versions") {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Content versions compared", comparison))
+	}
+}
+
+// ActivateContentVersionHandler - обработчик для выбора активной версии контента слова (?id=&version=)
+func ActivateContentVersionHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		wordID, err := strconv.Atoi(query.Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+		version, err := strconv.Atoi(query.Get("version"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid version number"))
+			return
+		}
+
+		err = services.ActivateContentVersion(r.Context(), dbpool, wordID, version)
+		if !writeVersionError(w, err, "Failed to activate content version") {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Content version activated", nil))
+	}
+}
+
+// writeVersionError - отвечает ошибкой работы с версиями контента; возвращает true, если ошибки нет
+func writeVersionError(w http.ResponseWriter, err error, message string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, repository.ErrNotFound):
+		common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word or content version not found"))
+	case errors.Is(err, repository.ErrWordNotCompleted):
+		common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Word content is not generated yet"))
+	default:
+		common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", message))
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/worker"
+	"MentorTools/pkg/common"
+	"net/http"
+)
+
+// EnrichmentStatusHandler - обработчик для получения глубины очереди обогащения и прогресса worker-а.
+// Состояние очереди - служебная информация, поэтому она доступна только репетиторам.
+func EnrichmentStatusHandler(enrichment *worker.EnrichmentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		stats, err := enrichment.Stats(r.Context())
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve enrichment stats"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Enrichment queue status", stats))
+	}
+}
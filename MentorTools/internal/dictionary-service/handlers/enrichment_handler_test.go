@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/worker"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnrichmentStatusHandlerDeniesStudents(t *testing.T) {
+	authenticateAs(t, testStudentID)
+	handler := EnrichmentStatusHandler(worker.NewEnrichmentWorker(nil, worker.DefaultEnrichmentConfig))
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/enrichment/status", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("student: status %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/exporter"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/pkg/common"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ExportWordsHandler - обработчик для выгрузки словаря ученика в CSV, колоду Anki (.apkg)
+// или PDF-листок с упражнениями на заполнение пропусков.
+//
+// Формат задаётся параметром format (csv, apkg или pdf), слова отбираются теми же
+// параметрами, что и в GetWordsHandler (status, deck, topic_id, added_from, added_to, due). Репетитор выгружает словарь привязанного ученика через student_id.
+// В CSV выгружается адрес картинки слова, а в колоду Anki - сама миниатюра картинки.
+func ExportWordsHandler(dbpool *pgxpool.Pool, media storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		format := query.Get("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "apkg" && format != "pdf" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Unsupported format, expected csv, apkg or pdf"))
+			return
+		}
+
+		filter, err := wordFilterFromQuery(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+			return
+		}
+
+		words, err := repository.GetWordsForExport(r.Context(), dbpool, studentID, filter)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve words"))
+			return
+		}
+		if len(words) == 0 {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "No words match the filter"))
+			return
+		}
+
+		for i := range words {
+			if words[i].ImageKey != "" {
+				words[i].ImageURL = media.URL(words[i].ImageKey)
+			}
+		}
+
+		// Файл собирается в памяти, чтобы ошибка при сборке вернулась клиенту кодом 500, а не обрывом файла
+		var buf bytes.Buffer
+		var contentType string
+		switch format {
+		case "csv":
+			contentType = "text/csv; charset=utf-8"
+			err = exporter.WriteCSV(&buf, words)
+		case "apkg":
+			contentType = "application/octet-stream"
+			loadExportThumbnails(r.Context(), words, media)
+			err = exporter.WriteAnki(&buf, words, exportDeckName(filter))
+		case "pdf":
+			exercises := exporter.BuildExercises(words)
+			if len(exercises) == 0 {
+				common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "No examples to build a worksheet from"))
+				return
+			}
+			contentType = "application/pdf"
+			err = exporter.WriteWorksheet(&buf, exportDeckName(filter), exercises)
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to export words"))
+			return
+		}
+
+		filename := fmt.Sprintf("vocabulary-%d-%s.%s", studentID, time.Now().Format("2006-01-02"), format)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	}
+}
+
+// exportDeckName - название колоды Anki и заголовок листка
+func exportDeckName(filter models.WordFilter) string {
+	if filter.Deck != "" {
+		return filter.Deck
+	}
+	return "MentorTools vocabulary"
+}
+
+// loadExportThumbnails - загружает из хранилища миниатюры картинок слов. Слово, миниатюру которого
+// не удалось загрузить, выгружается без картинки.
+func loadExportThumbnails(ctx context.Context, words []models.ExportWord, media storage.BlobStore) {
+	for i := range words {
+		if words[i].ThumbnailKey == "" {
+			continue
+		}
+		blob, _, err := media.Get(ctx, words[i].ThumbnailKey)
+		if err != nil {
+			log.Printf("Failed to load thumbnail %s: %v", words[i].ThumbnailKey, err)
+			continue
+		}
+		data, err := io.ReadAll(blob)
+		blob.Close()
+		if err != nil {
+			log.Printf("Failed to load thumbnail %s: %v", words[i].ThumbnailKey, err)
+			continue
+		}
+		words[i].Thumbnail = data
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// maxLeaderboardWeeksAgo - на сколько недель назад можно посмотреть таблицу лидеров
+const maxLeaderboardWeeksAgo = 52
+
+// GetXPHandler - обработчик для получения очков опыта ученика, текущей серии занятий и значков.
+// Репетитор получает данные привязанного ученика через student_id.
+func GetXPHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		summary, err := services.GetXPSummary(r.Context(), dbpool, studentID, time.Now())
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve XP"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("XP summary", summary))
+	}
+}
+
+// GetLeaderboardHandler - обработчик недельной таблицы лидеров учеников одного репетитора.
+// Репетитор видит своих учеников; ученик - учеников своего репетитора (tutor_id, если репетиторов несколько).
+// weeks_ago - номер недели в прошлом, по умолчанию текущая неделя.
+func GetLeaderboardHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		weeksAgo, err := intQueryParam(r, "weeks_ago", 0)
+		if err != nil || weeksAgo < 0 || weeksAgo > maxLeaderboardWeeksAgo {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "weeks_ago must be between 0 and 52"))
+			return
+		}
+
+		tutorID := userID
+		if !isTutor(r) {
+			tutorID, err = intQueryParam(r, "tutor_id", 0)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid tutor id"))
+				return
+			}
+			if tutorID == 0 {
+				tutorID, err = repository.GetStudentTutor(r.Context(), dbpool, userID)
+				if errors.Is(err, repository.ErrNotFound) {
+					common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Student is not linked to a tutor"))
+					return
+				}
+				if err != nil {
+					common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve tutor"))
+					return
+				}
+			}
+			allowed, err := repository.CanAccessStudent(r.Context(), dbpool, tutorID, userID)
+			if err != nil {
+				common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to check access"))
+				return
+			}
+			if !allowed || tutorID == userID {
+				common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+				return
+			}
+		}
+
+		leaderboard, err := services.GetLeaderboard(r.Context(), dbpool, tutorID, userID, weeksAgo, time.Now())
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve leaderboard"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Leaderboard", leaderboard))
+	}
+}
+
+// GetGamificationSettingsHandler - обработчик для получения настроек приватности ученика в таблице лидеров
+func GetGamificationSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		settings, err := repository.GetGamificationSettings(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Gamification settings", settings))
+	}
+}
+
+// UpdateGamificationSettingsHandler - обработчик для изменения настроек приватности.
+// Скрыть себя из таблицы лидеров или вернуться в неё может только сам ученик.
+func UpdateGamificationSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var settings models.GamificationSettings
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		if err := repository.SaveGamificationSettings(r.Context(), dbpool, userID, settings); err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Gamification settings updated", settings))
+	}
+}
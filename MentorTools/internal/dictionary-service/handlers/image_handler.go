@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/imaging"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	gpt "MentorTools/gpt-service/services"
+	"MentorTools/pkg/common"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// maxImageFileSize - ограничение размера загружаемой картинки (10 МБ)
+const maxImageFileSize = 10 << 20
+
+// UploadWordImageHandler - обработчик загрузки репетитором картинки к слову: файл JPEG, PNG или GIF
+// в поле image формы multipart. Прежняя картинка слова заменяется.
+func UploadWordImageHandler(dbpool *pgxpool.Pool, images *storage.ContentStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxImageFileSize+1<<20)
+		if err := r.ParseMultipartForm(maxImageFileSize); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid multipart payload or file is too large"))
+			return
+		}
+		file, _, err := r.FormFile("image")
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Image is required"))
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(io.LimitReader(file, maxImageFileSize+1))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Failed to read image"))
+			return
+		}
+		if len(data) > maxImageFileSize {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Image is too large"))
+			return
+		}
+
+		image, err := services.SetWordImage(r.Context(), dbpool, images, wordID, tutorID, data, models.ImageSourceUpload)
+		if !writeImageError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Image uploaded", image))
+	}
+}
+
+// GenerateWordImageHandler - обработчик генерации картинки к слову провайдером картинок (доступен только репетитору).
+// Прежняя картинка слова заменяется.
+func GenerateWordImageHandler(dbpool *pgxpool.Pool, images *storage.ContentStore, generator gpt.ImageGenerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		image, err := services.GenerateWordImage(r.Context(), dbpool, images, generator, wordID, tutorID)
+		if !writeImageError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Image generated", image))
+	}
+}
+
+// DeleteWordImageHandler - обработчик удаления картинки слова (доступен только репетитору)
+func DeleteWordImageHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		err = repository.DeleteWordImage(r.Context(), dbpool, wordID, tutorID)
+		if !writeImageError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Image deleted", nil))
+	}
+}
+
+// writeImageError - отвечает клиенту на ошибку работы с картинкой; возвращает true, если ошибки не было
+func writeImageError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, repository.ErrNotFound):
+		common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word not found"))
+	case errors.Is(err, imaging.ErrUnsupportedImage):
+		common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Unsupported image format, expected JPEG, PNG or GIF"))
+	case errors.Is(err, imaging.ErrImageTooLarge):
+		common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Image dimensions are too large"))
+	default:
+		common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update image"))
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/importer"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	maxImportFileSize = 20 << 20 // Ограничение размера файла импорта (20 МБ)
+	maxImportRows     = 5000     // Ограничение количества слов в одном импорте
+)
+
+// ImportWordsHandler - обработчик для массового импорта слов из CSV или колоды Anki (.apkg).
+//
+// Файл передаётся в multipart-поле "file". Формат определяется полем "format" (csv или apkg)
+// или расширением файла. Для CSV колонки задаются полями word_column и translation_column
+// (название из заголовка или номер с 0), а также delimiter и has_header.
+// Для Anki - номерами полей заметки word_field и translation_field.
+// Слова помещаются в колоду из поля "deck", по умолчанию - по имени файла.
+// Репетитор может импортировать слова привязанному ученику, передав параметр student_id.
+// В ответ возвращается отчёт по каждой строке файла.
+func ImportWordsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid multipart payload or file is too large"))
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "File is required"))
+			return
+		}
+		defer file.Close()
+
+		format := strings.ToLower(r.FormValue("format"))
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+
+		var entries []models.ImportEntry
+		switch format {
+		case "csv", "tsv", "txt":
+			opts, err := csvOptionsFromForm(r, format)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+				return
+			}
+			entries, err = importer.ReadCSV(file, opts, maxImportRows)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("DICT400", err.Error()))
+				return
+			}
+		case "apkg":
+			opts, err := ankiOptionsFromForm(r)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+				return
+			}
+			data, err := io.ReadAll(file)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Failed to read file"))
+				return
+			}
+			entries, err = importer.ReadAnki(data, opts, maxImportRows)
+			if err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("DICT400", err.Error()))
+				return
+			}
+		default:
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Unsupported format, expected csv or apkg"))
+			return
+		}
+
+		deck := strings.TrimSpace(r.FormValue("deck"))
+		if deck == "" {
+			deck = strings.TrimSuffix(filepath.Base(header.Filename), filepath.Ext(header.Filename))
+		}
+		if len(deck) > 255 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Deck name is too long"))
+			return
+		}
+
+		pair, err := repository.GetStudentLanguagePair(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve student languages"))
+			return
+		}
+
+		report := services.ImportWords(r.Context(), dbpool, studentID, pair, deck, entries)
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Import finished", report))
+	}
+}
+
+// csvOptionsFromForm читает сопоставление колонок CSV из полей формы
+func csvOptionsFromForm(r *http.Request, format string) (importer.CSVOptions, error) {
+	delimiter := r.FormValue("delimiter")
+	if delimiter == "" && format == "tsv" {
+		delimiter = "tab"
+	}
+
+	comma, err := importer.ParseDelimiter(delimiter)
+	if err != nil {
+		return importer.CSVOptions{}, err
+	}
+
+	hasHeader := true
+	if value := r.FormValue("has_header"); value != "" {
+		if hasHeader, err = strconv.ParseBool(value); err != nil {
+			return importer.CSVOptions{}, err
+		}
+	}
+
+	return importer.CSVOptions{
+		Delimiter:         comma,
+		HasHeader:         hasHeader,
+		WordColumn:        r.FormValue("word_column"),
+		TranslationColumn: r.FormValue("translation_column"),
+	}, nil
+}
+
+// ankiOptionsFromForm читает номера полей заметки Anki из полей формы;
+// по умолчанию слово - первое поле, перевод - второе
+func ankiOptionsFromForm(r *http.Request) (importer.AnkiOptions, error) {
+	opts := importer.AnkiOptions{WordField: 0, TranslationField: 1}
+
+	if value := r.FormValue("word_field"); value != "" {
+		field, err := strconv.Atoi(value)
+		if err != nil || field < 0 {
+			return opts, errors.New("invalid word_field")
+		}
+		opts.WordField = field
+	}
+	if value := r.FormValue("translation_field"); value != "" {
+		field, err := strconv.Atoi(value)
+		if err != nil || field < -1 {
+			return opts, errors.New("invalid translation_field")
+		}
+		opts.TranslationField = field
+	}
+	return opts, nil
+}
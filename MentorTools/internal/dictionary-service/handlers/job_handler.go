@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// jobIDRegex - формат ID задачи и тренировки написания (UUID)
+var jobIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// jobEventsInterval - как часто поток событий проверяет состояние задачи
+const jobEventsInterval = time.Second
+
+// JobsHandler - обработчик маршрутов /jobs/{id} (состояние задачи) и /jobs/{id}/events (поток событий)
+func JobsHandler(dbpool *pgxpool.Pool, words *services.WordService) http.HandlerFunc {
+	getJob := GetJobHandler(dbpool, words)
+	jobEvents := JobEventsHandler(dbpool, words)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			jobEvents(w, r)
+			return
+		}
+		getJob(w, r)
+	}
+}
+
+// GetJobHandler - обработчик для получения состояния задачи на добавление слова.
+// Для завершённой задачи в ответ включается карточка слова.
+func GetJobHandler(dbpool *pgxpool.Pool, words *services.WordService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		jobID := jobIDFromPath(r.URL.Path)
+		if !jobIDRegex.MatchString(jobID) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Job not found"))
+			return
+		}
+
+		job, err := loadJob(r.Context(), dbpool, words, jobID, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Job not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve job"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Job status", job))
+	}
+}
+
+// JobEventsHandler - обработчик потока server-sent events по задаче на добавление слова.
+// При каждом изменении этапа отправляется событие "progress", по завершении - событие "done"
+// с итоговым состоянием задачи (и карточкой слова), после чего поток закрывается.
+func JobEventsHandler(dbpool *pgxpool.Pool, words *services.WordService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		jobID := jobIDFromPath(strings.TrimSuffix(r.URL.Path, "/events"))
+		if !jobIDRegex.MatchString(jobID) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Job not found"))
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Streaming is not supported"))
+			return
+		}
+
+		job, err := loadJob(r.Context(), dbpool, words, jobID, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Job not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve job"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		ticker := time.NewTicker(jobEventsInterval)
+		defer ticker.Stop()
+
+		lastStage := ""
+		for {
+			if job.Finished() {
+				writeEvent(w, "done", job)
+				flusher.Flush()
+				return
+			}
+
+			if job.Status+job.Stage != lastStage {
+				lastStage = job.Status + job.Stage
+				writeEvent(w, "progress", job)
+				flusher.Flush()
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+
+			job, err = loadJob(r.Context(), dbpool, words, jobID, userID)
+			if err != nil {
+				writeEvent(w, "error", common.NewErrorResponse("DICT500", "Failed to retrieve job"))
+				flusher.Flush()
+				return
+			}
+		}
+	}
+}
+
+// loadJob возвращает задачу ученика; для завершённой задачи подгружает карточку слова
+func loadJob(ctx context.Context, dbpool *pgxpool.Pool, words *services.WordService, jobID string, userID int) (models.WordJob, error) {
+	job, err := repository.GetWordJob(ctx, dbpool, jobID, userID)
+	if err != nil {
+		return job, err
+	}
+
+	if job.Status == models.JobStatusCompleted && job.WordID != nil {
+		details, err := words.GetWordDetails(ctx, *job.WordID, job.StudentID, false)
+		if err != nil {
+			return job, err
+		}
+		job.Result = &details
+	}
+	return job, nil
+}
+
+// jobIDFromPath извлекает ID задачи из пути вида /jobs/{id}
+func jobIDFromPath(path string) string {
+	return strings.Trim(strings.TrimPrefix(path, "/jobs/"), "/")
+}
+
+// writeEvent записывает одно server-sent event с JSON-данными
+func writeEvent(w http.ResponseWriter, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetStudentLanguagesHandler - обработчик для получения языковой пары ученика.
+// Репетитор может получить пару привязанного ученика, передав параметр student_id.
+func GetStudentLanguagesHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		pair, err := repository.GetStudentLanguagePair(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve student languages"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Student languages", pair))
+	}
+}
+
+// SetStudentLanguagesHandler - обработчик для выбора изучаемого языка и языка объяснений ученика.
+// Репетитор может изменить пару привязанного ученика, передав параметр student_id.
+func SetStudentLanguagesHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		var pair models.LanguagePair
+		if err := json.NewDecoder(r.Body).Decode(&pair); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		pair, err := services.SetStudentLanguagePair(r.Context(), dbpool, studentID, pair)
+		if errors.Is(err, services.ErrUnsupportedLanguagePair) {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("DICT400", "Unsupported language pair"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update student languages"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Student languages updated", pair))
+	}
+}
+
+// languagePairFromQuery - возвращает языковую пару из параметров source и target,
+// а если они не переданы, то пару текущего пользователя. При ошибке сам отвечает клиенту и возвращает false.
+func languagePairFromQuery(w http.ResponseWriter, r *http.Request, dbpool *pgxpool.Pool) (models.LanguagePair, bool) {
+	query := r.URL.Query()
+	pair := models.LanguagePair{Source: query.Get("source"), Target: query.Get("target")}
+	if pair.Source != "" || pair.Target != "" {
+		if pair.Source == "" || pair.Target == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Both source and target languages are required"))
+			return pair, false
+		}
+		return pair, true
+	}
+
+	userID, err := userIDFromRequest(r)
+	if err != nil {
+		common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+		return pair, false
+	}
+
+	pair, err = repository.GetStudentLanguagePair(r.Context(), dbpool, userID)
+	if err != nil {
+		common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve student languages"))
+		return pair, false
+	}
+	return pair, true
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Глубина истории для графиков активности
+const (
+	activityDays  = 30
+	activityWeeks = 12
+)
+
+// AnswerHandler - обработчик ответа ученика на слово: при повторении (eventType = models.EventReviewed)
+// или в тесте (models.EventQuizAnswered). Ответ записывается в события обучения и сдвигает
+// дату следующего повторения; в ответе возвращается новое состояние слова.
+func AnswerHandler(dbpool *pgxpool.Pool, eventType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var request models.AnswerRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.WordID <= 0 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		result, err := services.RecordAnswer(r.Context(), dbpool, userID, eventType, request)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word not found in vocabulary"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to record answer"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Answer recorded", result))
+	}
+}
+
+// GetLearningStatsHandler - обработчик сводной статистики ученика: серии, доля правильных ответов,
+// время до освоения слова. Ученик видит свою статистику, репетитор - статистику привязанных учеников.
+func GetLearningStatsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		stats, err := repository.GetLearningStats(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve stats"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Learning stats", stats))
+	}
+}
+
+// GetLearningActivityHandler - обработчик активности ученика по дням (period=day, последние 30 дней)
+// или по неделям (period=week, последние 12 недель)
+func GetLearningActivityHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		period := r.URL.Query().Get("period")
+		var since time.Time
+		switch period {
+		case "", "day":
+			period = "day"
+			since = time.Now().AddDate(0, 0, -activityDays)
+		case "week":
+			since = time.Now().AddDate(0, 0, -7*activityWeeks)
+		default:
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "period must be day or week"))
+			return
+		}
+
+		points, err := repository.GetLearningActivity(r.Context(), dbpool, studentID, period, since)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve activity"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Learning activity", points))
+	}
+}
+
+// GetTopicStatsHandler - обработчик прогресса ученика по темам
+func GetTopicStatsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		topics, err := repository.GetTopicStats(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve topic stats"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Topic stats", topics))
+	}
+}
+
+// GetVocabularyProfileHandler - обработчик для получения профиля словаря ученика по уровням CEFR
+// и частотным полосам: сколько слов каждого уровня добавлено и сколько из них выучено
+func GetVocabularyProfileHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		profile, err := repository.GetVocabularyProfile(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve vocabulary profile"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Vocabulary profile", profile))
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Ограничения настроек урока
+const (
+	maxDailyGoal   = 200
+	maxLessonWords = 50
+	maxLessonDecks = 20
+)
+
+// GetTodayLessonHandler - обработчик для получения урока на сегодня: слово дня, новые слова, повторение и тест.
+// День считается в часовом поясе ученика; если урок ещё не составлен, он составляется при запросе.
+// Репетитор получает урок привязанного ученика через student_id.
+func GetTodayLessonHandler(dbpool *pgxpool.Pool, media storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		lesson, err := services.GetTodayLesson(r.Context(), dbpool, studentID, time.Now())
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve lesson"))
+			return
+		}
+		attachLessonAudioURLs(&lesson, media)
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Today's lesson", lesson))
+	}
+}
+
+// GetLessonSettingsHandler - обработчик для получения настроек урока ученика
+func GetLessonSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		settings, err := repository.GetLessonSettings(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve lesson settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Lesson settings", settings))
+	}
+}
+
+// UpdateLessonSettingsHandler - обработчик для изменения настроек урока: часового пояса, дневной цели,
+// числа новых слов и часа, к которому готовится урок. Колоды для новых слов назначает только репетитор
+// (через student_id). Изменения действуют с урока следующего дня.
+func UpdateLessonSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var update models.LessonSettingsUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		if update.Decks != nil && !isTutor(r) {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Only a tutor can assign decks"))
+			return
+		}
+		if message := validateLessonSettings(&update); message != "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", message))
+			return
+		}
+
+		settings, err := services.UpdateLessonSettings(r.Context(), dbpool, studentID, userID, update)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update lesson settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Lesson settings updated", settings))
+	}
+}
+
+// validateLessonSettings - проверяет заполненные поля настроек урока и убирает пустые и повторяющиеся колоды.
+// Возвращает текст ошибки или пустую строку.
+func validateLessonSettings(update *models.LessonSettingsUpdate) string {
+	if update.Timezone != nil {
+		if _, err := time.LoadLocation(*update.Timezone); err != nil || *update.Timezone == "" || *update.Timezone == "Local" {
+			return "Invalid timezone"
+		}
+	}
+	if update.DailyGoal != nil && (*update.DailyGoal < 1 || *update.DailyGoal > maxDailyGoal) {
+		return "Daily goal must be between 1 and 200"
+	}
+	if update.NewWords != nil && (*update.NewWords < 0 || *update.NewWords > maxLessonWords) {
+		return "New words must be between 0 and 50"
+	}
+	if update.LessonHour != nil && (*update.LessonHour < 0 || *update.LessonHour > 23) {
+		return "Lesson hour must be between 0 and 23"
+	}
+
+	if update.Decks != nil {
+		decks := []string{}
+		seen := make(map[string]bool)
+		for _, deck := range *update.Decks {
+			deck = strings.TrimSpace(deck)
+			if deck == "" || seen[deck] {
+				continue
+			}
+			if len(deck) > 255 {
+				return "Deck name is too long"
+			}
+			seen[deck] = true
+			decks = append(decks, deck)
+		}
+		if len(decks) > maxLessonDecks {
+			return "Too many decks"
+		}
+		update.Decks = &decks
+	}
+	return ""
+}
+
+// attachLessonAudioURLs - заполняет адреса аудио произношения слов урока
+func attachLessonAudioURLs(lesson *models.DailyLesson, media storage.BlobStore) {
+	if lesson.WordOfTheDay != nil && lesson.WordOfTheDay.AudioKey != "" {
+		lesson.WordOfTheDay.AudioURL = media.URL(lesson.WordOfTheDay.AudioKey)
+	}
+	for i := range lesson.Items {
+		if lesson.Items[i].Word.AudioKey != "" {
+			lesson.Items[i].Word.AudioURL = media.URL(lesson.Items[i].Word.AudioKey)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/storage"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// MediaHandler - обработчик для выдачи файлов из хранилища по адресу /media/{key}.
+// Ключи выводятся из содержимого и не меняются, поэтому ответ можно кэшировать бессрочно.
+// Маршрут открыт без авторизации: браузер загружает аудио и картинки тегами <audio> и <img> без заголовка Authorization.
+func MediaHandler(store storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/media/")
+		if !storage.ValidKey(key) {
+			http.NotFound(w, r)
+			return
+		}
+
+		blob, contentType, err := store.Get(r.Context(), key)
+		if errors.Is(err, storage.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to read media", http.StatusInternalServerError)
+			return
+		}
+		defer blob.Close()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		if _, err := io.Copy(w, blob); err != nil {
+			log.Printf("Failed to send media %s: %v", key, err)
+		}
+	}
+}
+
+// attachMediaURLs - проставляет в карточку слова адреса сгенерированного аудио и картинки
+func attachMediaURLs(word *models.WordDetails, media storage.BlobStore) {
+	if word.AudioKey != "" {
+		word.AudioURL = media.URL(word.AudioKey)
+	}
+	if word.ImageKey != "" {
+		word.ImageURL = media.URL(word.ImageKey)
+		word.ThumbnailURL = media.URL(word.ThumbnailKey)
+	}
+	for i := range word.Examples {
+		if word.Examples[i].AudioKey != "" {
+			word.Examples[i].AudioURL = media.URL(word.Examples[i].AudioKey)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// queueCursor - позиция последнего слова страницы очереди проверки
+type queueCursor struct {
+	ID int `json:"id"`
+}
+
+// GetModerationSettingsHandler - обработчик для получения настроек модерации (доступен только репетитору)
+func GetModerationSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		settings, err := repository.GetModerationSettings(r.Context(), dbpool)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Moderation settings", settings))
+	}
+}
+
+// SetModerationSettingsHandler - обработчик для включения и выключения очереди проверки нового контента от GPT
+func SetModerationSettingsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		var settings models.ModerationSettings
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		if err := repository.SetModerationSettings(r.Context(), dbpool, settings, tutorID); err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to save settings"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Moderation settings updated", settings))
+	}
+}
+
+// GetModerationQueueHandler - обработчик для получения слов, контент которых ждёт проверки
+func GetModerationQueueHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		pageRequest, err := common.ParsePageRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+			return
+		}
+
+		var after queueCursor
+		if pageRequest.Cursor != "" {
+			if err := common.DecodeCursor(pageRequest.Cursor, &after); err != nil {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid cursor"))
+				return
+			}
+		}
+
+		words, total, err := repository.GetModerationQueue(r.Context(), dbpool, after.ID, pageRequest.Limit)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve moderation queue"))
+			return
+		}
+
+		page, err := common.NewPage(words, pageRequest.Limit, total, func(word models.QueuedWord) interface{} {
+			return queueCursor{ID: word.ID}
+		})
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to build page"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Moderation queue", page))
+	}
+}
+
+// ApproveWordHandler - обработчик для публикации контента слова после проверки (?id=)
+func ApproveWordHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		err = services.ApproveWord(r.Context(), dbpool, tutorID, wordID)
+		if !writeEditError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Word content approved", nil))
+	}
+}
+
+// RejectWordHandler - обработчик для отклонения контента слова (?id=): контент скрывается,
+// а слово снова отправляется в GPT
+func RejectWordHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		err = services.RejectWord(r.Context(), dbpool, tutorID, wordID)
+		if !writeEditError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Word content rejected and queued for regeneration", nil))
+	}
+}
+
+// EditWordContentHandler - обработчик для исправления транскрипции, перевода и описания слова (?id=)
+func EditWordContentHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		var request models.WordContentRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		trimFields(request.Transcription, request.Translation, request.Description)
+
+		err = services.EditWordContent(r.Context(), dbpool, tutorID, wordID, request)
+		if !writeEditError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Word content updated", nil))
+	}
+}
+
+// EditExampleHandler - обработчик для исправления или отклонения примера слова (?id=&word_id=)
+func EditExampleHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		exampleID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid example id"))
+			return
+		}
+		wordID, err := strconv.Atoi(r.URL.Query().Get("word_id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		var request models.ExampleContentRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		trimFields(request.Sentence, request.Translation)
+		if request.Sentence != nil && *request.Sentence == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Sentence cannot be empty"))
+			return
+		}
+
+		err = services.EditExample(r.Context(), dbpool, tutorID, wordID, exampleID, request)
+		if !writeEditError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Example updated", nil))
+	}
+}
+
+// SetSynonymHiddenHandler - обработчик для скрытия или возврата синонима слова (?id=)
+func SetSynonymHiddenHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		var request models.SynonymRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || strings.TrimSpace(request.Synonym) == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		synonym := strings.TrimSpace(strings.ToLower(request.Synonym))
+		err = services.SetSynonymHidden(r.Context(), dbpool, tutorID, wordID, synonym, request.Hidden)
+		if !writeEditError(w, err) {
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Synonym updated", nil))
+	}
+}
+
+// GetContentHistoryHandler - обработчик для получения истории правок слова (?id=)
+func GetContentHistoryHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authorizeTutor(w, r); !ok {
+			return
+		}
+
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid word id"))
+			return
+		}
+
+		edits, err := repository.GetContentEdits(r.Context(), dbpool, wordID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve history"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Content history", edits))
+	}
+}
+
+// authorizeTutor - проверяет, что запрос выполняет репетитор, и возвращает его ID.
+// При ошибке сам отвечает клиенту и возвращает false.
+func authorizeTutor(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userID, err := userIDFromRequest(r)
+	if err != nil {
+		common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+		return 0, false
+	}
+	if !isTutor(r) {
+		common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+		return 0, false
+	}
+	return userID, true
+}
+
+// writeEditError - отвечает клиенту на ошибку правки контента; возвращает true, если ошибки не было
+func writeEditError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, repository.ErrNotFound):
+		common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word, example or synonym not found"))
+	default:
+		common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update content"))
+	}
+	return false
+}
+
+// trimFields - убирает пробелы по краям у заданных строковых полей запроса
+func trimFields(fields ...*string) {
+	for _, field := range fields {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/worker"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Ограничения помощника чтения
+const (
+	maxReadingText     = 50000 // Максимальная длина текста в символах
+	maxReadingSentence = 1000  // Максимальная длина предложения, сохраняемого как пример
+)
+
+// AnalyzeTextHandler - обработчик для разбора текста по словарю ученика: каждое слово помечается
+// как выученное, изучаемое или незнакомое, и считается покрытие текста словарём.
+// Репетитор может разобрать текст для привязанного ученика, передав параметр student_id.
+func AnalyzeTextHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		var request models.ReadingRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		if strings.TrimSpace(request.Text) == "" || utf8.RuneCountInString(request.Text) > maxReadingText {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Text must be between 1 and 50000 characters"))
+			return
+		}
+
+		analysis, err := services.AnalyzeText(r.Context(), dbpool, studentID, request.Text)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to analyze text"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Text analyzed", analysis))
+	}
+}
+
+// AddReadingWordHandler - обработчик для добавления незнакомого слова из текста в один клик.
+// Слово добавляется так же, как через POST /words, - асинхронной задачей, а предложение,
+// в котором оно встретилось, сохраняется как личный пример ученика.
+func AddReadingWordHandler(dbpool *pgxpool.Pool, jobs *worker.WordJobWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		var request models.ReadingWordRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		word := strings.TrimSpace(strings.ToLower(request.Word))
+		sentence := strings.TrimSpace(request.Sentence)
+		if word == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Word is required"))
+			return
+		}
+		if utf8.RuneCountInString(sentence) > maxReadingSentence {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Sentence is too long"))
+			return
+		}
+
+		idempotencyKey := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+		if len(idempotencyKey) > 255 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Idempotency-Key is too long"))
+			return
+		}
+
+		job, created, err := repository.CreateWordJob(r.Context(), dbpool, studentID, word, sentence, idempotencyKey)
+		if errors.Is(err, repository.ErrIdempotencyKeyReused) {
+			common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Idempotency-Key was already used for another word"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to add word"))
+			return
+		}
+		if created {
+			jobs.Notify()
+		}
+
+		w.Header().Set("Location", "/jobs/"+job.ID)
+		common.JSONResponse(w, http.StatusAccepted, common.NewSuccessResponse("Word addition accepted", map[string]string{"jobId": job.ID}))
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// maxRelationDepth - на сколько шагов от слова можно обходить граф связей
+const maxRelationDepth = 3
+
+// GetRelationGraphHandler - обработчик для получения графа слов, связанных со словом id.
+//
+// depth - число шагов от слова (1-3, по умолчанию 1), type - типы связей через запятую
+// (synonym, antonym, collocation, derivative, hypernym; по умолчанию все),
+// min_confidence - минимальная уверенность связи от 0 до 1.
+// Связи слов, которые ждут проверки, показываются только репетиторам.
+func GetRelationGraphHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := models.RelationGraphQuery{}
+
+		var err error
+		query.WordID, err = strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid id"))
+			return
+		}
+
+		query.Depth, err = intQueryParam(r, "depth", 1)
+		if err != nil || query.Depth < 1 || query.Depth > maxRelationDepth {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Depth must be between 1 and 3"))
+			return
+		}
+
+		if param := r.URL.Query().Get("type"); param != "" {
+			for _, relationType := range strings.Split(param, ",") {
+				relationType = strings.ToLower(strings.TrimSpace(relationType))
+				if !models.IsRelationType(relationType) {
+					common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid type"))
+					return
+				}
+				query.Types = append(query.Types, relationType)
+			}
+		}
+
+		if param := r.URL.Query().Get("min_confidence"); param != "" {
+			query.MinConfidence, err = strconv.ParseFloat(param, 64)
+			if err != nil || query.MinConfidence < 0 || query.MinConfidence > 1 {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "min_confidence must be between 0 and 1"))
+				return
+			}
+		}
+
+		graph, err := services.BuildRelationGraph(r.Context(), dbpool, query, isTutor(r))
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to build relation graph"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Relation graph", graph))
+	}
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/dictionary-service/worker"
+	gpt "MentorTools/gpt-service/services"
+	"MentorTools/pkg/middleware"
+	"net/http"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RegisterRoutes - регистрирует маршруты dictionary-service; все маршруты, кроме выдачи файлов из хранилища media,
+// требуют авторизации
+func RegisterRoutes(mux *http.ServeMux, dbpool *pgxpool.Pool, enrichment *worker.EnrichmentWorker, jobs *worker.WordJobWorker,
+	media storage.BlobStore, imageGenerator gpt.ImageGenerator) {
+	images := storage.NewContentStore(media)
+	words := services.NewWordService(repository.NewWordRepository(dbpool), repository.NewStudentWordRepository(dbpool),
+		repository.NewExampleRepository(dbpool))
+
+	mux.Handle("/words", middleware.AuthMiddleware(methods{
+		http.MethodGet:  GetWordsHandler(words),
+		http.MethodPost: AddWordHandler(dbpool, jobs),
+	}))
+	mux.Handle("/words/status", middleware.AuthMiddleware(methods{
+		http.MethodPut: UpdateWordStatusHandler(words),
+	}))
+	mux.Handle("/words/import", middleware.AuthMiddleware(methods{
+		http.MethodPost: ImportWordsHandler(dbpool),
+	}))
+	mux.Handle("/words/export", middleware.AuthMiddleware(methods{
+		http.MethodGet: ExportWordsHandler(dbpool, media),
+	}))
+	mux.Handle("/words/image", middleware.AuthMiddleware(methods{
+		http.MethodPut:    UploadWordImageHandler(dbpool, images),
+		http.MethodDelete: DeleteWordImageHandler(dbpool),
+	}))
+	mux.Handle("/words/image/generate", middleware.AuthMiddleware(methods{
+		http.MethodPost: GenerateWordImageHandler(dbpool, images, imageGenerator),
+	}))
+	mux.Handle("/words/search", middleware.AuthMiddleware(methods{
+		http.MethodGet: SearchWordsHandler(dbpool),
+	}))
+	mux.Handle("/words/autocomplete", middleware.AuthMiddleware(methods{
+		http.MethodGet: AutocompleteHandler(dbpool),
+	}))
+	mux.Handle("/words/details", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetWordDetailsHandler(words, media),
+	}))
+	mux.Handle("/words/relations", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetRelationGraphHandler(dbpool),
+	}))
+	mux.Handle("/words/regenerate", middleware.AuthMiddleware(methods{
+		http.MethodPost: RegenerateWordHandler(dbpool),
+	}))
+	mux.Handle("/words/versions", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetContentVersionsHandler(dbpool),
+	}))
+	mux.Handle("/words/versions/compare", middleware.AuthMiddleware(methods{
+		http.MethodGet: CompareContentVersionsHandler(dbpool),
+	}))
+	mux.Handle("/words/versions/active", middleware.AuthMiddleware(methods{
+		http.MethodPut: ActivateContentVersionHandler(dbpool),
+	}))
+	mux.Handle("/words/review", middleware.AuthMiddleware(methods{
+		http.MethodPost: AnswerHandler(dbpool, models.EventReviewed),
+	}))
+	mux.Handle("/quiz/answers", middleware.AuthMiddleware(methods{
+		http.MethodPost: AnswerHandler(dbpool, models.EventQuizAnswered),
+	}))
+	mux.Handle("/practice/typing", middleware.AuthMiddleware(methods{
+		http.MethodGet:  GetTypingSessionHandler(dbpool, media),
+		http.MethodPost: StartTypingSessionHandler(dbpool, media),
+	}))
+	mux.Handle("/practice/typing/answers", middleware.AuthMiddleware(methods{
+		http.MethodPost: TypingAnswerHandler(dbpool),
+	}))
+	mux.Handle("/practice/confusions", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetConfusionsHandler(dbpool),
+	}))
+	mux.Handle("/practice/confusions/exercise", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetContrastiveExerciseHandler(dbpool),
+	}))
+	mux.Handle("/lessons/today", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetTodayLessonHandler(dbpool, media),
+	}))
+	mux.Handle("/lessons/settings", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetLessonSettingsHandler(dbpool),
+		http.MethodPut: UpdateLessonSettingsHandler(dbpool),
+	}))
+	mux.Handle("/gamification/xp", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetXPHandler(dbpool),
+	}))
+	mux.Handle("/gamification/leaderboard", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetLeaderboardHandler(dbpool),
+	}))
+	mux.Handle("/gamification/settings", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetGamificationSettingsHandler(dbpool),
+		http.MethodPut: UpdateGamificationSettingsHandler(dbpool),
+	}))
+	mux.Handle("/examples/submissions", middleware.AuthMiddleware(methods{
+		http.MethodGet:  ListSubmissionsHandler(dbpool),
+		http.MethodPost: SubmitExampleHandler(dbpool),
+	}))
+	mux.Handle("/examples/submissions/review", middleware.AuthMiddleware(methods{
+		http.MethodPut: ReviewSubmissionHandler(dbpool),
+	}))
+	mux.Handle("/reading/analyze", middleware.AuthMiddleware(methods{
+		http.MethodPost: AnalyzeTextHandler(dbpool),
+	}))
+	mux.Handle("/reading/words", middleware.AuthMiddleware(methods{
+		http.MethodPost: AddReadingWordHandler(dbpool, jobs),
+	}))
+	mux.Handle("/jobs/", middleware.AuthMiddleware(methods{
+		http.MethodGet: JobsHandler(dbpool, words),
+	}))
+
+	mux.Handle("/topics", middleware.AuthMiddleware(methods{
+		http.MethodGet:    GetTopicsHandler(dbpool),
+		http.MethodPost:   CreateTopicHandler(dbpool),
+		http.MethodPut:    UpdateTopicHandler(dbpool),
+		http.MethodDelete: DeleteTopicHandler(dbpool),
+	}))
+	mux.Handle("/students/topics", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetStudentTopicsHandler(dbpool),
+		http.MethodPut: SetStudentTopicsHandler(dbpool),
+	}))
+	mux.Handle("/students/languages", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetStudentLanguagesHandler(dbpool),
+		http.MethodPut: SetStudentLanguagesHandler(dbpool),
+	}))
+
+	mux.Handle("/stats", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetLearningStatsHandler(dbpool),
+	}))
+	mux.Handle("/stats/activity", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetLearningActivityHandler(dbpool),
+	}))
+	mux.Handle("/stats/topics", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetTopicStatsHandler(dbpool),
+	}))
+	mux.Handle("/stats/levels", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetVocabularyProfileHandler(dbpool),
+	}))
+
+	mux.Handle("/moderation/settings", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetModerationSettingsHandler(dbpool),
+		http.MethodPut: SetModerationSettingsHandler(dbpool),
+	}))
+	mux.Handle("/moderation/queue", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetModerationQueueHandler(dbpool),
+	}))
+	mux.Handle("/moderation/approve", middleware.AuthMiddleware(methods{
+		http.MethodPost: ApproveWordHandler(dbpool),
+	}))
+	mux.Handle("/moderation/reject", middleware.AuthMiddleware(methods{
+		http.MethodPost: RejectWordHandler(dbpool),
+	}))
+	mux.Handle("/moderation/words", middleware.AuthMiddleware(methods{
+		http.MethodPut: EditWordContentHandler(dbpool),
+	}))
+	mux.Handle("/moderation/examples", middleware.AuthMiddleware(methods{
+		http.MethodPut: EditExampleHandler(dbpool),
+	}))
+	mux.Handle("/moderation/synonyms", middleware.AuthMiddleware(methods{
+		http.MethodPut: SetSynonymHiddenHandler(dbpool),
+	}))
+	mux.Handle("/moderation/history", middleware.AuthMiddleware(methods{
+		http.MethodGet: GetContentHistoryHandler(dbpool),
+	}))
+
+	mux.Handle("/enrichment/status", middleware.AuthMiddleware(methods{
+		http.MethodGet: EnrichmentStatusHandler(enrichment),
+	}))
+
+	mux.Handle("/media/", methods{
+		http.MethodGet: MediaHandler(media),
+	})
+}
+
+// methods - выбирает обработчик по HTTP-методу запроса, на остальные методы отвечает 405
+type methods map[string]http.Handler
+
+func (m methods) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, ok := m[r.Method]
+	if !ok {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
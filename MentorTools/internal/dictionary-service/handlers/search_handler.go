@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/pkg/common"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Ограничения поиска
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	maxSearchQuery     = 200 // Максимальная длина запроса в символах
+	maxSuggestions     = 10
+)
+
+// SearchWordsHandler - обработчик полнотекстового поиска по словарю.
+// Параметры: q - запрос на изучаемом языке или языке перевода, limit и offset - страница результатов,
+// source и target - языковая пара (по умолчанию - пара текущего пользователя).
+func SearchWordsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		if query == "" || utf8.RuneCountInString(query) > maxSearchQuery {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Query must be between 1 and 200 characters"))
+			return
+		}
+
+		limit, err := intQueryParam(r, "limit", defaultSearchLimit)
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid limit"))
+			return
+		}
+		offset, err := intQueryParam(r, "offset", 0)
+		if err != nil || offset < 0 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid offset"))
+			return
+		}
+
+		pair, ok := languagePairFromQuery(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		page, err := repository.SearchWords(r.Context(), dbpool, query, pair, limit, offset)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to search words"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Search results", page))
+	}
+}
+
+// AutocompleteHandler - обработчик автодополнения для поля добавления слова.
+// Параметр q - начало слова или начало перевода на русском; source и target - языковая пара,
+// по умолчанию - пара текущего пользователя.
+func AutocompleteHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimSpace(r.URL.Query().Get("q"))
+		if prefix == "" || utf8.RuneCountInString(prefix) > maxSearchQuery {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Query must be between 1 and 200 characters"))
+			return
+		}
+
+		limit, err := intQueryParam(r, "limit", maxSuggestions)
+		if err != nil || limit < 1 || limit > maxSuggestions {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid limit"))
+			return
+		}
+
+		pair, ok := languagePairFromQuery(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		suggestions, err := repository.SuggestWords(r.Context(), dbpool, prefix, pair, limit)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve suggestions"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Suggestions", suggestions))
+	}
+}
+
+// intQueryParam - возвращает числовой параметр запроса или значение по умолчанию, если параметр не передан
+func intQueryParam(r *http.Request, name string, fallback int) (int, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(param)
+}
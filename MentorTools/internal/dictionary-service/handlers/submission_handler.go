@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Ограничения примеров учеников
+const (
+	maxSubmissionLength   = 500
+	maxReviewComment      = 1000
+	defaultSubmissionPage = 50
+	maxSubmissionPage     = 200
+)
+
+// SubmitExampleHandler - обработчик для отправки учеником собственного примера к слову из своего словаря.
+// GPT проверяет грамматику и употребление слова; в ответе возвращаются исправления и объяснение.
+// Правильное предложение сразу сохраняется примером к слову и ждёт отзыва репетитора.
+func SubmitExampleHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var request models.ExampleSubmissionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.WordID <= 0 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		request.Sentence = strings.TrimSpace(request.Sentence)
+		if request.Sentence == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Sentence is required"))
+			return
+		}
+		if utf8.RuneCountInString(request.Sentence) > maxSubmissionLength {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Sentence is too long"))
+			return
+		}
+
+		submission, err := services.SubmitExample(r.Context(), dbpool, userID, request)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word not found in student's dictionary"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to check sentence"))
+			return
+		}
+
+		message := "Sentence has mistakes"
+		if submission.Accepted {
+			message = "Sentence accepted"
+		}
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse(message, submission))
+	}
+}
+
+// ListSubmissionsHandler - обработчик для получения примеров ученика с проверкой GPT и отзывом репетитора.
+// Репетитор получает примеры привязанного ученика через student_id; status=pending - примеры, ждущие отзыва.
+func ListSubmissionsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		status := r.URL.Query().Get("status")
+		switch status {
+		case "", models.SubmissionPending, models.SubmissionApproved, models.SubmissionRejected:
+		default:
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid status"))
+			return
+		}
+		limit, err := intQueryParam(r, "limit", defaultSubmissionPage)
+		if err != nil || limit <= 0 || limit > maxSubmissionPage {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid limit"))
+			return
+		}
+
+		submissions, err := repository.ListSubmissions(r.Context(), dbpool, studentID, status, limit)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve submissions"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Example submissions", submissions))
+	}
+}
+
+// ReviewSubmissionHandler - обработчик отзыва репетитора о примере привязанного ученика:
+// одобренный пример становится виден всем ученикам, отклонённый скрывается
+func ReviewSubmissionHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, ok := authorizeTutor(w, r)
+		if !ok {
+			return
+		}
+
+		submissionID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid submission id"))
+			return
+		}
+
+		var request models.SubmissionReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		request.Comment = strings.TrimSpace(request.Comment)
+		if utf8.RuneCountInString(request.Comment) > maxReviewComment {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Comment is too long"))
+			return
+		}
+
+		submission, err := repository.GetSubmission(r.Context(), dbpool, submissionID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Submission not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve submission"))
+			return
+		}
+		allowed, err := repository.CanAccessStudent(r.Context(), dbpool, tutorID, submission.StudentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to check access"))
+			return
+		}
+		if !allowed {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+			return
+		}
+
+		submission, err = services.ReviewSubmission(r.Context(), dbpool, submissionID, tutorID, request)
+		if errors.Is(err, services.ErrAlreadyReviewed) {
+			common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Submission is already reviewed"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to review submission"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Submission reviewed", submission))
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetTopicsHandler - обработчик для получения списка всех тем
+func GetTopicsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		topics, err := repository.GetTopics(r.Context(), dbpool)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve topics"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Topics list", topics))
+	}
+}
+
+// CreateTopicHandler - обработчик для создания темы (доступен только репетитору)
+func CreateTopicHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isTutor(r) {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+			return
+		}
+
+		var request models.TopicRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		name := strings.TrimSpace(strings.ToLower(request.Name))
+		if name == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Topic name cannot be empty"))
+			return
+		}
+
+		topic, err := repository.CreateTopic(r.Context(), dbpool, name)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to create topic"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusCreated, common.NewSuccessResponse("Topic created successfully", topic))
+	}
+}
+
+// UpdateTopicHandler - обработчик для переименования темы (доступен только репетитору)
+func UpdateTopicHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isTutor(r) {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+			return
+		}
+
+		topicID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid topic id"))
+			return
+		}
+
+		var request models.TopicRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		name := strings.TrimSpace(strings.ToLower(request.Name))
+		if name == "" {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Topic name cannot be empty"))
+			return
+		}
+
+		err = repository.UpdateTopic(r.Context(), dbpool, topicID, name)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Topic not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Failed to update topic"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Topic updated successfully", models.Topic{ID: topicID, Name: name}))
+	}
+}
+
+// DeleteTopicHandler - обработчик для удаления темы (доступен только репетитору)
+func DeleteTopicHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isTutor(r) {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+			return
+		}
+
+		topicID, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid topic id"))
+			return
+		}
+
+		err = repository.DeleteTopic(r.Context(), dbpool, topicID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Topic not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to delete topic"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Topic deleted successfully", nil))
+	}
+}
+
+// GetStudentTopicsHandler - обработчик для получения интересов ученика.
+// Без параметра student_id возвращает интересы текущего пользователя.
+func GetStudentTopicsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeStudent(w, r, dbpool)
+		if !ok {
+			return
+		}
+
+		topics, err := repository.GetStudentTopics(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve student topics"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Student topics", topics))
+	}
+}
+
+// SetStudentTopicsHandler - обработчик для установки интересов ученика.
+// Ученик меняет свои интересы, репетитор - интересы привязанного ученика (поле studentId).
+func SetStudentTopicsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var request models.StudentTopicsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+
+		studentID := request.StudentID
+		if studentID == 0 {
+			studentID = userID
+		}
+
+		allowed, err := repository.CanAccessStudent(r.Context(), dbpool, userID, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to check access"))
+			return
+		}
+		if !allowed {
+			common.JSONResponse(w, http.StatusForbidden, common.NewErrorResponse("AUTH403", "Access denied"))
+			return
+		}
+
+		if err := repository.SetStudentTopics(r.Context(), dbpool, studentID, request.TopicIDs); err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to update student topics"))
+			return
+		}
+
+		topics, err := repository.GetStudentTopics(r.Context(), dbpool, studentID)
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve student topics"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Student topics updated successfully", topics))
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/pkg/common"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// typingModes - допустимые режимы тренировки написания
+var typingModes = map[string]bool{
+	models.TypingModeTranslation: true,
+	models.TypingModeAudio:       true,
+	models.TypingModeBoth:        true,
+}
+
+// StartTypingSessionHandler - обработчик для начала тренировки написания: ученик получает задания
+// с переводом и (или) аудио слов, которые пора повторить, и набирает слова по памяти
+func StartTypingSessionHandler(dbpool *pgxpool.Pool, media storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		// Тело запроса необязательно: без него тренировка строится с настройками по умолчанию
+		var request models.TypingSessionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		if request.Mode != "" && !typingModes[request.Mode] {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Mode must be translation, audio or both"))
+			return
+		}
+
+		session, err := services.StartTypingSession(r.Context(), dbpool, userID, request)
+		if errors.Is(err, services.ErrNothingToPractice) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "No words to practice"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to start typing practice"))
+			return
+		}
+		attachPromptAudioURLs(&session, media)
+
+		common.JSONResponse(w, http.StatusCreated, common.NewSuccessResponse("Typing practice started", session))
+	}
+}
+
+// GetTypingSessionHandler - обработчик для получения тренировки написания (?id=) с ответами и итогом
+func GetTypingSessionHandler(dbpool *pgxpool.Pool, media storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		sessionID := r.URL.Query().Get("id")
+		if !jobIDRegex.MatchString(sessionID) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Typing practice not found"))
+			return
+		}
+
+		session, err := services.GetTypingSession(r.Context(), dbpool, sessionID, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Typing practice not found"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve typing practice"))
+			return
+		}
+		attachPromptAudioURLs(&session, media)
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Typing practice", session))
+	}
+}
+
+// TypingAnswerHandler - обработчик набранного слова: ответ проверяется с допуском на опечатки,
+// в ответе возвращаются ошибки по буквам и новое состояние повторения слова
+func TypingAnswerHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusUnauthorized, common.NewErrorResponse("AUTH401", "Unauthorized"))
+			return
+		}
+
+		var request models.TypingAnswerRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.WordID <= 0 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid request payload"))
+			return
+		}
+		request.Typed = strings.TrimSpace(request.Typed)
+		if utf8.RuneCountInString(request.Typed) > 255 {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Answer is too long"))
+			return
+		}
+		if !jobIDRegex.MatchString(request.SessionID) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Typing practice not found"))
+			return
+		}
+
+		result, err := services.AnswerTyping(r.Context(), dbpool, userID, request)
+		if errors.Is(err, repository.ErrNotFound) {
+			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("DICT404", "Word not found in typing practice"))
+			return
+		}
+		if errors.Is(err, repository.ErrAlreadyAnswered) {
+			common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Word is already answered"))
+			return
+		}
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to record answer"))
+			return
+		}
+
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Answer checked", result))
+	}
+}
+
+// attachPromptAudioURLs - проставляет в задания тренировки адреса аудио из хранилища
+func attachPromptAudioURLs(session *models.TypingSession, media storage.BlobStore) {
+	for i := range session.Prompts {
+		if session.Prompts[i].AudioKey != "" {
+			session.Prompts[i].AudioURL = media.URL(session.Prompts[i].AudioKey)
+		}
+	}
+}
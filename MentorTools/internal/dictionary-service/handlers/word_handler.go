@@ -2,272 +2,232 @@ package handlers
 
 import (
 	"MentorTools/dictionary-service/models"
-	"MentorTools/gpt-service/services"
-	services2 "MentorTools/user-service/services"
-	"context"
-	"database/sql"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/dictionary-service/worker"
+	"MentorTools/pkg/common"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
-// GetWordsHandler - обработчик для получения всех слов ученика с дополнительной информацией
-func GetWordsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+// GetWordsHandler - обработчик для получения словаря ученика постранично.
+//
+// Фильтры: status, deck, topic_id, added_from и added_to (дата или время в RFC 3339),
+// due=true - только слова, которые пора повторить. Сортировка: sort (added, word или review)
+// и order (asc или desc). Страница задаётся параметрами limit и cursor (nextCursor из предыдущего ответа).
+// Репетитор получает словарь привязанного ученика через student_id.
+func GetWordsHandler(words *services.WordService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Извлечение userID из токена
-		userID, err := services2.GetUserIDFromToken(r)
-		if err != nil {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		studentID, ok := authorizeStudentWith(w, r, words.CanAccessStudent)
+		if !ok {
 			return
 		}
 
-		// Запрос для получения слов ученика с транскрипцией, переводом и описанием
-		rows, err := dbpool.Query(context.Background(), `
-            SELECT w.id, w.word, w.transcription, w.translation, s.status
-            FROM words w
-            JOIN student_words s ON w.id = s.word_id
-            WHERE s.student_id = $1`, userID)
+		filter, err := wordFilterFromQuery(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+			return
+		}
 
+		sort, err := wordSortFromQuery(r)
 		if err != nil {
-			http.Error(w, "Failed to retrieve words", http.StatusInternalServerError)
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
 			return
 		}
-		defer rows.Close()
 
-		// Массив для хранения слов
-		var words []map[string]interface{}
-		for rows.Next() {
-			var id int
-			var word, transcription, translation, status string
+		pageRequest, err := common.ParsePageRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+			return
+		}
 
-			// Сканирование данных из запроса
-			if err := rows.Scan(&id, &word, &transcription, &translation, &status); err != nil {
-				http.Error(w, "Error scanning word data", http.StatusInternalServerError)
+		// Курсор действителен только для той сортировки, с которой он был получен
+		var after *models.WordCursor
+		if pageRequest.Cursor != "" {
+			var cursor models.WordCursor
+			if err := common.DecodeCursor(pageRequest.Cursor, &cursor); err != nil || cursor.Sort != sort.Field || cursor.Desc != sort.Desc {
+				common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", "Invalid cursor"))
 				return
 			}
-
-			// Добавление слова в массив с дополнительной информацией
-			words = append(words, map[string]interface{}{
-				"id":            id,
-				"word":          word,
-				"transcription": transcription,
-				"translation":   translation,
-				"status":        status,
-			})
+			after = &cursor
 		}
 
-		// Возвращение JSON с массивом слов
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"words": words})
-	}
-}
-
-// AddWordHandler - обработчик для добавления нового слова с использованием GPT
-// Обработчик для добавления нового слова
-func AddWordHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := services2.GetUserIDFromToken(r)
+		list, total, err := words.ListWords(r.Context(), studentID, filter, sort, after, pageRequest.Limit)
 		if err != nil {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to retrieve words"))
 			return
 		}
 
-		var newWord struct {
-			Word string `json:"word"`
-		}
-
-		if err := json.NewDecoder(r.Body).Decode(&newWord); err != nil {
-			http.Error(w, "Invalid input", http.StatusBadRequest)
+		page, err := common.NewPage(list, pageRequest.Limit, total, func(word models.StudentWord) interface{} {
+			return wordCursor(word, sort)
+		})
+		if err != nil {
+			common.JSONResponse(w, http.StatusInternalServerError, common.NewErrorResponse("DICT500", "Failed to build page"))
 			return
 		}
 
-		// Убираем лишние пробелы и приводим к нижнему регистру
-		word := strings.TrimSpace(strings.ToLower(newWord.Word))
+		common.JSONResponse(w, http.StatusOK, common.NewSuccessResponse("Words list", page))
+	}
+}
 
-		// Проверяем, существует ли уже это слово в базе данных
-		var wordID int
-		var wordStatus string
-
-		err = dbpool.QueryRow(context.Background(), "SELECT id, status FROM words WHERE word = $1", word).Scan(&wordID, &wordStatus)
-		if err == nil {
-			// Если слово существует и его статус не "pending"
-			if wordStatus != "pending" {
-				// Добавляем связь между пользователем и уже существующим словом
-				_, err := dbpool.Exec(context.Background(), "INSERT INTO student_words (student_id, word_id, status) VALUES ($1, $2, $3)", userID, wordID, "need to learn")
-				if err != nil {
-					http.Error(w, "Failed to add word to user", http.StatusInternalServerError)
-					return
-				}
-
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(map[string]bool{"success": true})
-				return
-			}
-			// Если слово в статусе "pending", продолжаем и запрашиваем данные у OpenAI
-		}
+// wordFilterFromQuery - разбирает параметры отбора слов ученика
+func wordFilterFromQuery(r *http.Request) (models.WordFilter, error) {
+	query := r.URL.Query()
+	filter := models.WordFilter{Status: query.Get("status"), Deck: query.Get("deck")}
 
-		// Извлекаем слова и топики для примеров
-		var wordsForExamples []string
-		var topics []string
+	if filter.Status != "" && !services.IsWordStatus(filter.Status) {
+		return filter, errors.New("invalid status")
+	}
 
-		// Получаем 5 рандомных слова, которые ученик уже выучил
-		rows, err := dbpool.Query(context.Background(), "SELECT w.word FROM words w JOIN student_words sw ON w.id = sw.word_id WHERE sw.student_id = $1 AND sw.status = 'learned' ORDER BY random() LIMIT 5", userID)
+	if param := query.Get("topic_id"); param != "" {
+		topicID, err := strconv.Atoi(param)
 		if err != nil {
-			http.Error(w, "Failed to fetch learned words", http.StatusInternalServerError)
-			return
-		}
-		for rows.Next() {
-			var learnedWord string
-			if err := rows.Scan(&learnedWord); err != nil {
-				http.Error(w, "Failed to read learned word", http.StatusInternalServerError)
-				return
-			}
-			wordsForExamples = append(wordsForExamples, learnedWord)
+			return filter, errors.New("invalid topic id")
 		}
+		filter.TopicID = topicID
+	}
 
-		// Получаем 2 рандомных слов, которые нужно выучить
-		rowss, err := dbpool.Query(context.Background(), "SELECT w.word FROM words w JOIN student_words sw ON w.id = sw.word_id WHERE sw.student_id = $1 AND sw.status = 'need to learn' ORDER BY random() LIMIT 2", userID)
+	for name, target := range map[string]**time.Time{"added_from": &filter.AddedFrom, "added_to": &filter.AddedTo} {
+		param := query.Get(name)
+		if param == "" {
+			continue
+		}
+		value, err := parseDateParam(param)
 		if err != nil {
-			http.Error(w, "Failed to fetch upcoming words!", http.StatusInternalServerError)
-			return
+			return filter, errors.New("invalid " + name)
 		}
-		for rowss.Next() {
-			var upcomingWord string
-			if err := rowss.Scan(&upcomingWord); err != nil {
-				http.Error(w, "Failed to read upcoming word", http.StatusInternalServerError)
-				return
+		*target = &value
+	}
+
+	// Несколько уровней перечисляются через запятую: level=A1,A2
+	if param := query.Get("level"); param != "" {
+		for _, level := range strings.Split(param, ",") {
+			level = strings.ToUpper(strings.TrimSpace(level))
+			if !models.IsCEFRLevel(level) {
+				return filter, errors.New("invalid level")
 			}
-			wordsForExamples = append(wordsForExamples, upcomingWord)
+			filter.Levels = append(filter.Levels, level)
 		}
+	}
 
-		// Получаем топики
-		topicRows, err := dbpool.Query(context.Background(), `SELECT c.topic_name FROM student_topics st JOIN topics c ON st.topic_id = c.id WHERE st.student_id = $1 ORDER BY random() LIMIT 5`, userID)
-
+	if param := query.Get("due"); param != "" {
+		due, err := strconv.ParseBool(param)
 		if err != nil {
-			http.Error(w, "Failed to fetch topics", http.StatusInternalServerError)
-			return
+			return filter, errors.New("invalid due")
 		}
-		defer topicRows.Close()
+		filter.DueOnly = due
+	}
+	return filter, nil
+}
 
-		for topicRows.Next() {
-			var topic string
-			if err := topicRows.Scan(&topic); err != nil {
-				http.Error(w, "Error scanning topics", http.StatusInternalServerError)
-				return
-			}
-			topics = append(topics, topic)
-		}
+// wordSortFromQuery - разбирает параметры сортировки словаря; по умолчанию новые слова идут первыми
+func wordSortFromQuery(r *http.Request) (models.WordSort, error) {
+	sort := models.WordSort{Field: r.URL.Query().Get("sort")}
+	switch sort.Field {
+	case "", models.WordSortAdded:
+		sort.Field = models.WordSortAdded
+		sort.Desc = true
+	case models.WordSortWord, models.WordSortReview:
+	default:
+		return sort, errors.New("sort must be one of added, word, review")
+	}
+
+	switch r.URL.Query().Get("order") {
+	case "":
+	case "asc":
+		sort.Desc = false
+	case "desc":
+		sort.Desc = true
+	default:
+		return sort, errors.New("order must be asc or desc")
+	}
+	return sort, nil
+}
 
-		// Запрашиваем данные у OpenAI, включая дополнительные слова
-		transcription, translation, description, synonyms, examples, err := services.GetWordDetailsFromGPT(word, wordsForExamples, topics)
+// wordCursor - позиция слова в словаре для выбранной сортировки
+func wordCursor(word models.StudentWord, sort models.WordSort) models.WordCursor {
+	cursor := models.WordCursor{Sort: sort.Field, Desc: sort.Desc, ID: word.ID}
+	switch sort.Field {
+	case models.WordSortWord:
+		cursor.Key = word.Word
+	case models.WordSortReview:
+		cursor.Key = word.NextReviewAt.Format(time.RFC3339Nano)
+	default:
+		cursor.Key = word.AddedAt.Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// parseDateParam - разбирает дату (2006-01-02) или время в RFC 3339
+func parseDateParam(param string) (time.Time, error) {
+	if value, err := time.Parse("2006-01-02", param); err == nil {
+		return value, nil
+	}
+	return time.Parse(time.RFC3339, param)
+}
+
+// AddWordHandler - обработчик для добавления нового слова.
+// Обращение к GPT может занимать десятки секунд, поэтому слово добавляется асинхронно:
+// обработчик ставит задачу в очередь и сразу отвечает 202 с ID задачи,
+// а ход выполнения доступен через GET /jobs/{id} и поток событий /jobs/{id}/events.
+func AddWordHandler(dbpool *pgxpool.Pool, jobs *worker.WordJobWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := userIDFromRequest(r)
 		if err != nil {
-			http.Error(w, "Failed to fetch word details from GPT", http.StatusInternalServerError)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		if wordID == 0 {
-			// Если слово не существует в БД, добавляем его
-			err = dbpool.QueryRow(context.Background(), "INSERT INTO words (word, transcription, translation, definition, status) VALUES ($1, $2, $3, $4, $5) RETURNING id",
-				word, transcription, translation, description, "completed").Scan(&wordID)
-			if err != nil {
-				http.Error(w, "Failed to add word", http.StatusInternalServerError)
-				return
-			}
-		} else {
-			// Если слово в статусе "pending", обновляем его информацию
-			_, err = dbpool.Exec(context.Background(), "UPDATE words SET transcription = $1, translation = $2, definition = $3, status = 'completed' WHERE id = $4",
-				transcription, translation, description, wordID)
-			if err != nil {
-				http.Error(w, "Failed to update word", http.StatusInternalServerError)
-				return
-			}
+		var newWord struct {
+			Word string `json:"word"`
 		}
 
-		// Добавляем связь с пользователем
-		_, err = dbpool.Exec(context.Background(), "INSERT INTO student_words (student_id, word_id, status) VALUES ($1, $2, $3)", userID, wordID, "need to learn")
-		if err != nil {
-			http.Error(w, "Failed to add word to user", http.StatusInternalServerError)
+		if err := json.NewDecoder(r.Body).Decode(&newWord); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
 			return
 		}
 
-		// Сохраняем синонимы (если есть)
-		for _, synonym := range synonyms {
-			var synonymID int
-			err := dbpool.QueryRow(context.Background(), "SELECT id FROM words WHERE word = $1", synonym).Scan(&synonymID)
-			if err != nil {
-				err = dbpool.QueryRow(context.Background(), "INSERT INTO words (word, status) VALUES ($1, $2) RETURNING id", synonym, "pending").Scan(&synonymID)
-				if err != nil {
-					http.Error(w, "Failed to save synonym", http.StatusInternalServerError)
-					return
-				}
-			}
-			_, err = dbpool.Exec(context.Background(), "INSERT INTO word_links (word_id, linked_word_id) VALUES ($1, $2)", wordID, synonymID)
-			if err != nil {
-				http.Error(w, "Failed to link synonym", http.StatusInternalServerError)
-				return
-			}
+		// Убираем лишние пробелы и приводим к нижнему регистру
+		word := strings.TrimSpace(strings.ToLower(newWord.Word))
+		if word == "" {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
 		}
 
-		// Сохраняем примеры и ключевые слова
-		for _, example := range examples {
-			var exampleID int
-			err = dbpool.QueryRow(context.Background(), "INSERT INTO examples (example, context, translation) VALUES ($1, $2, $3) RETURNING id", example.Text, example.Context, example.Translation).Scan(&exampleID)
-			if err != nil {
-				http.Error(w, "Failed to save example", http.StatusInternalServerError)
-				return
-			}
-
-			_, err = dbpool.Exec(context.Background(), "INSERT INTO word_example (word_id, example_id) VALUES ($1, $2)", wordID, exampleID)
-			if err != nil {
-				http.Error(w, "Failed to link word and example", http.StatusInternalServerError)
-				return
-			}
+		// Повторный запрос с тем же Idempotency-Key вернёт уже созданную задачу
+		idempotencyKey := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+		if len(idempotencyKey) > 255 {
+			http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+			return
+		}
 
-			for _, keyword := range example.Keywords {
-				var keywordID int
-				err := dbpool.QueryRow(context.Background(), "SELECT id FROM words WHERE word = $1", keyword).Scan(&keywordID)
-
-				if err != nil {
-					// Если слово не найдено, добавляем его со статусом 'pending'
-					err = dbpool.QueryRow(context.Background(), "INSERT INTO words (word, status) VALUES ($1, $2) RETURNING id", keyword, "pending").Scan(&keywordID)
-
-					if err != nil {
-						http.Error(w, "Failed to save keyword", http.StatusInternalServerError)
-						return
-					}
-				}
-
-				// Проверяем, существует ли уже связь между словом и примером
-				var exists bool
-				err = dbpool.QueryRow(context.Background(), "SELECT EXISTS(SELECT 1 FROM word_example WHERE word_id = $1 AND example_id = $2)", keywordID, exampleID).Scan(&exists)
-				if err != nil {
-					http.Error(w, "Failed to check if word and example are linked", http.StatusInternalServerError)
-					return
-				}
-
-				// Если связь уже существует, просто продолжаем цикл
-				if exists {
-					continue // Продолжаем цикл, чтобы обработать остальные ключевые слова
-				}
-
-				// Если связи нет, создаем ее
-				_, err = dbpool.Exec(context.Background(), "INSERT INTO word_example (word_id, example_id) VALUES ($1, $2)", keywordID, exampleID)
-				if err != nil {
-					http.Error(w, "Failed to link keyword and example", http.StatusInternalServerError)
-					return
-				}
-			}
+		job, created, err := repository.CreateWordJob(r.Context(), dbpool, userID, word, "", idempotencyKey)
+		if errors.Is(err, repository.ErrIdempotencyKeyReused) {
+			common.JSONResponse(w, http.StatusConflict, common.NewErrorResponse("DICT409", "Idempotency-Key was already used for another word"))
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to add word", http.StatusInternalServerError)
+			return
+		}
+		if created {
+			jobs.Notify()
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		w.Header().Set("Location", "/jobs/"+job.ID)
+		common.JSONResponse(w, http.StatusAccepted, common.NewSuccessResponse("Word addition accepted", map[string]string{"jobId": job.ID}))
 	}
 }
 
 // UpdateWordStatusHandler - обработчик для обновления статуса слова
-func UpdateWordStatusHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+func UpdateWordStatusHandler(words *services.WordService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var statusUpdate struct {
 			WordID int    `json:"wordId"`
@@ -279,17 +239,22 @@ func UpdateWordStatusHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		userID, err := services2.GetUserIDFromToken(r)
+		userID, err := userIDFromRequest(r)
 		if err != nil {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		_, err = dbpool.Exec(context.Background(), `
-            UPDATE student_words
-            SET status = $1
-            WHERE word_id = $2 AND student_id = $3`, statusUpdate.Status, statusUpdate.WordID, userID)
-
+		// Изменение статуса записывается в события обучения
+		err = words.UpdateWordStatus(r.Context(), userID, statusUpdate.WordID, statusUpdate.Status)
+		if errors.Is(err, services.ErrInvalidWordStatus) {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Word not found", http.StatusNotFound)
+			return
+		}
 		if err != nil {
 			http.Error(w, "Failed to update status", http.StatusInternalServerError)
 			return
@@ -301,93 +266,31 @@ func UpdateWordStatusHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
 }
 
 // GetWordDetailsHandler - обработчик для получения деталей по слову
-func GetWordDetailsHandler(dbpool *pgxpool.Pool) http.HandlerFunc {
+// вместе с адресами аудио произношения слова и примеров из хранилища media
+func GetWordDetailsHandler(words *services.WordService, media storage.BlobStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		wordID := r.URL.Query().Get("id")
-
-		var word models.WordDetails
-		err := dbpool.QueryRow(context.Background(), `
-            SELECT w.word, w.transcription, w.translation, w.definition
-            FROM words w
-            WHERE w.id = $1`, wordID).Scan(&word.Word, &word.Transcription, &word.Translation, &word.Description)
-
+		wordID, err := strconv.Atoi(r.URL.Query().Get("id"))
 		if err != nil {
 			http.Error(w, "Word not found", http.StatusNotFound)
 			return
 		}
 
-		// Получаем синонимы
-		rows, err := dbpool.Query(context.Background(), "SELECT linked_word_id FROM word_links WHERE word_id = $1", wordID)
-		if err != nil {
-			http.Error(w, "Failed to fetch synonyms", http.StatusInternalServerError)
+		// Личные примеры из текстов показываются ученику, а репетитору - по параметру student_id
+		studentID, ok := authorizeStudentWith(w, r, words.CanAccessStudent)
+		if !ok {
 			return
 		}
-		defer rows.Close()
 
-		var synonyms []string
-		for rows.Next() {
-			var linkedWordID int
-			if err := rows.Scan(&linkedWordID); err != nil {
-				http.Error(w, "Error reading synonym ID", http.StatusInternalServerError)
-				return
-			}
-
-			// Получаем отдельное соединение из пула
-			var synonym string
-			err = dbpool.QueryRow(context.Background(), "SELECT word FROM words WHERE id = $1", linkedWordID).Scan(&synonym)
-
-			// Если возникает ошибка, логируем её и продолжаем с другими синонимами
-			if err != nil {
-				continue // Пропускаем синоним в случае ошибки
-			}
-
-			synonyms = append(synonyms, synonym)
+		word, err := words.GetWordDetails(r.Context(), wordID, studentID, isTutor(r))
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Word not found", http.StatusNotFound)
+			return
 		}
-
-		// Устанавливаем синонимы для слова
-		word.Synonyms = synonyms
-
-		// Получаем примеры
-		exampleRows, err := dbpool.Query(context.Background(), `
-    SELECT e.example, e.translation
-    FROM examples e
-    JOIN word_example we ON e.id = we.example_id
-    WHERE we.word_id = $1`, wordID)
-
 		if err != nil {
-			http.Error(w, "Failed to fetch examples", http.StatusInternalServerError)
+			http.Error(w, "Failed to fetch word details", http.StatusInternalServerError)
 			return
 		}
-		defer exampleRows.Close()
-
-		var examples []models.Example
-		for exampleRows.Next() {
-			var sentence sql.NullString
-			var translation sql.NullString
-
-			if err := exampleRows.Scan(&sentence, &translation); err != nil {
-				http.Error(w, "Error reading example", http.StatusInternalServerError)
-				return
-			}
-
-			var example models.Example
-
-			// Проверяем и присваиваем значение, если оно не NULL
-			if sentence.Valid {
-				example.Sentence = sentence.String
-			} else {
-				example.Sentence = "" // Или установите какое-то значение по умолчанию
-			}
-
-			if translation.Valid {
-				example.Translation = translation.String
-			} else {
-				example.Translation = "" // Или установите какое-то значение по умолчанию
-			}
-
-			examples = append(examples, example)
-		}
-		word.Examples = examples
+		attachMediaURLs(&word, media)
 
 		// Отправляем ответ
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository/memory"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	"MentorTools/dictionary-service/worker"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// authenticateAs подменяет пользователя запросов до конца теста
+func authenticateAs(t *testing.T, userID int) {
+	previous := userIDFromRequest
+	userIDFromRequest = func(*http.Request) (int, error) { return userID, nil }
+	t.Cleanup(func() { userIDFromRequest = previous })
+}
+
+func TestAddWordHandlerIdempotencyKey(t *testing.T) {
+	pool := dbtest.Pool(t)
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+	authenticateAs(t, studentID)
+
+	handler := AddWordHandler(pool, worker.NewWordJobWorker(pool, worker.DefaultWordJobConfig))
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/words", strings.NewReader(body))
+		r.Header.Set("Idempotency-Key", "add-apple")
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+
+	first := post(`{"word": "apple"}`)
+	if first.Code != http.StatusAccepted {
+		t.Fatalf("first request: status %d, body %s", first.Code, first.Body)
+	}
+	replay := post(`{"word": "apple"}`)
+	if replay.Code != http.StatusAccepted {
+		t.Fatalf("replay: status %d, body %s", replay.Code, replay.Body)
+	}
+	if jobID(t, replay) != jobID(t, first) || replay.Header().Get("Location") != first.Header().Get("Location") {
+		t.Errorf("replay returned %s, want the stored response %s", replay.Body, first.Body)
+	}
+
+	conflict := post(`{"word": "pear"}`)
+	if conflict.Code != http.StatusConflict {
+		t.Errorf("same key with another body: status %d, want %d", conflict.Code, http.StatusConflict)
+	}
+}
+
+// jobID читает ID задачи из ответа AddWordHandler
+func jobID(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var response struct {
+		Data struct {
+			JobID string `json:"jobId"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Data.JobID == "" {
+		t.Fatalf("decode response %s: %v", w.Body, err)
+	}
+	return response.Data.JobID
+}
+
+// Ученик и репетитор словаря memory.NewFixtureStore
+const (
+	testStudentID = memory.FixtureStudentID
+	testTutorID   = memory.FixtureTutorID
+)
+
+// wordsPage - данные ответа GetWordsHandler
+type wordsPage struct {
+	Items      []models.StudentWord `json:"items"`
+	NextCursor string               `json:"nextCursor"`
+	HasMore    bool                 `json:"hasMore"`
+	Total      int                  `json:"total"`
+}
+
+func TestGetWordsHandler(t *testing.T) {
+	store := memory.NewFixtureStore()
+	handler := GetWordsHandler(services.NewWordService(store, store, store))
+	get := func(userID int, query string) (*httptest.ResponseRecorder, wordsPage) {
+		t.Helper()
+		authenticateAs(t, userID)
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/words?"+query, nil))
+
+		var response struct {
+			Data wordsPage `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode response %s: %v", w.Body, err)
+			}
+		}
+		return w, response.Data
+	}
+	words := func(page wordsPage) []string {
+		var list []string
+		for _, word := range page.Items {
+			list = append(list, word.Word)
+		}
+		return list
+	}
+
+	tests := []struct {
+		name   string
+		userID int
+		query  string
+		status int
+		want   []string
+	}{
+		{"own words, newest first", testStudentID, "", http.StatusOK, []string{"pear", "apple"}},
+		{"status", testStudentID, "status=learned", http.StatusOK, []string{"pear"}},
+		{"level", testStudentID, "level=a1", http.StatusOK, []string{"apple"}},
+		{"topic", testStudentID, "topic_id=5", http.StatusOK, []string{"apple"}},
+		{"linked tutor", testTutorID, "student_id=1&sort=word", http.StatusOK, []string{"apple", "pear"}},
+		{"stranger", 3, "student_id=1", http.StatusForbidden, nil},
+		{"invalid status", testStudentID, "status=forgotten", http.StatusBadRequest, nil},
+		{"invalid topic", testStudentID, "topic_id=food", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		w, page := get(tt.userID, tt.query)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d (body %s)", tt.name, w.Code, tt.status, w.Body)
+			continue
+		}
+		if got := words(page); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: words %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	_, first := get(testStudentID, "sort=word&limit=1")
+	if !first.HasMore || first.Total != 2 || first.NextCursor == "" || !reflect.DeepEqual(words(first), []string{"apple"}) {
+		t.Fatalf("first page = %+v, want [apple] with a cursor", first)
+	}
+	_, next := get(testStudentID, "sort=word&limit=1&cursor="+url.QueryEscape(first.NextCursor))
+	if next.HasMore || !reflect.DeepEqual(words(next), []string{"pear"}) {
+		t.Errorf("next page = %+v, want [pear] without more pages", next)
+	}
+	if w, _ := get(testStudentID, "sort=added&limit=1&cursor="+url.QueryEscape(first.NextCursor)); w.Code != http.StatusBadRequest {
+		t.Errorf("cursor of another sort: status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestUpdateWordStatusHandler(t *testing.T) {
+	store := memory.NewFixtureStore()
+	handler := UpdateWordStatusHandler(services.NewWordService(store, store, store))
+	authenticateAs(t, testStudentID)
+	put := func(body string) int {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPut, "/words/status", strings.NewReader(body)))
+		return w.Code
+	}
+
+	if code := put(`{"wordId": 10, "status": "learned"}`); code != http.StatusOK {
+		t.Fatalf("update: status %d, want %d", code, http.StatusOK)
+	}
+	if events := store.Events(); len(events) != 1 || events[0].WordID != 10 || events[0].NewStatus != models.WordStatusLearned {
+		t.Errorf("events = %+v, want one status change of word 10", events)
+	}
+
+	for body, want := range map[string]int{
+		`{"wordId": 10, "status": "forgotten"}`: http.StatusBadRequest,
+		`{"wordId": 404, "status": "learned"}`:  http.StatusNotFound,
+		`not json`:                              http.StatusBadRequest,
+	} {
+		if code := put(body); code != want {
+			t.Errorf("%s: status %d, want %d", body, code, want)
+		}
+	}
+}
+
+func TestGetWordDetailsHandler(t *testing.T) {
+	store := memory.NewFixtureStore()
+	handler := GetWordDetailsHandler(services.NewWordService(store, store, store), storage.NewFileStore(t.TempDir(), "/media/"))
+	authenticateAs(t, testStudentID)
+	get := func(id string) (*httptest.ResponseRecorder, models.WordDetails) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/words/details?id="+id, nil))
+		var word models.WordDetails
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &word); err != nil {
+				t.Fatalf("decode response %s: %v", w.Body, err)
+			}
+		}
+		return w, word
+	}
+
+	w, apple := get("10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("details: status %d, body %s", w.Code, w.Body)
+	}
+	if apple.AudioURL != "/media/audio/apple.mp3" {
+		t.Errorf("audioUrl = %q, want /media/audio/apple.mp3", apple.AudioURL)
+	}
+	if len(apple.Examples) != 3 {
+		t.Errorf("examples = %+v, want common and own examples without other students' ones", apple.Examples)
+	}
+
+	// Ученик не видит контент, который ещё не проверил репетитор
+	if _, pear := get("11"); pear.Word != "pear" || pear.Translation != "" {
+		t.Errorf("unpublished word = %+v, want it without translation", pear)
+	}
+
+	for _, id := range []string{"404", "abc"} {
+		if w, _ := get(id); w.Code != http.StatusNotFound {
+			t.Errorf("id=%s: status %d, want %d", id, w.Code, http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package imaging проверяет картинки слов и уменьшает их до миниатюр.
+// Поддерживаются JPEG, PNG и GIF - форматы, которые декодирует стандартная библиотека.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // Декодер GIF для image.Decode
+	"image/jpeg"
+	_ "image/png" // Декодер PNG для image.Decode
+)
+
+// MaxPixels - наибольший размер картинки в пикселях (16 Мп, до 64 МБ в памяти после декодирования);
+// большие картинки отклоняются до декодирования, чтобы маленький файл не развернулся в сотни мегабайт памяти
+const MaxPixels = 16_000_000
+
+// ThumbnailSize - наибольшая сторона миниатюры в пикселях
+const ThumbnailSize = 320
+
+// ErrUnsupportedImage возвращается для файла, который не является картинкой поддерживаемого формата
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// ErrImageTooLarge возвращается для картинки больше MaxPixels
+var ErrImageTooLarge = errors.New("image is too large")
+
+// contentTypes - типы содержимого по названию формата из image.Decode
+var contentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// Decode проверяет размер картинки, декодирует её и возвращает тип содержимого по её формату
+// (тип, заявленный клиентом, не учитывается)
+func Decode(data []byte) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return nil, "", ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, "", ErrUnsupportedImage
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, "", ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+	return img, contentType, nil
+}
+
+// Thumbnail уменьшает картинку так, чтобы большая сторона была не больше size, и кодирует её в JPEG.
+// Прозрачные области закрашиваются белым. Маленькие картинки не увеличиваются.
+func Thumbnail(img image.Image, size int) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, height*size/width
+		} else {
+			width, height = width*size/height, size
+		}
+		if width < 1 {
+			width = 1
+		}
+		if height < 1 {
+			height = 1
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resize(img, width, height), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resize уменьшает картинку усреднением: каждый пиксель результата - среднее пикселей исходной
+// картинки, которые на него приходятся. В отличие от выбора ближайшего пикселя, мелкие детали не рябят.
+// Пиксели читаются прямо из исходной картинки, без её копии в RGBA; прозрачные пиксели
+// смешиваются с белой подложкой, потому что JPEG не поддерживает прозрачность.
+func resize(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	pixel := pixelReader(img)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, (y+1)*srcHeight/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, (x+1)*srcWidth/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, count uint32
+			for sy := bounds.Min.Y + y0; sy < bounds.Min.Y+y1; sy++ {
+				for sx := bounds.Min.X + x0; sx < bounds.Min.X+x1; sx++ {
+					pr, pg, pb, pa := pixel(sx, sy)
+					// Цвета с предумноженной альфой: смешивание с белым - прибавить недостающую непрозрачность
+					r += pr + 255 - pa
+					g += pg + 255 - pa
+					b += pb + 255 - pa
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = 255
+		}
+	}
+	return dst
+}
+
+// pixelReader возвращает функцию чтения пикселя в 8-битных каналах с предумноженной альфой.
+// Для типов, которые возвращают декодеры JPEG и PNG, пиксель читается напрямую, без промежуточного color.Color.
+func pixelReader(img image.Image) func(x, y int) (r, g, b, a uint32) {
+	switch img := img.(type) {
+	case *image.YCbCr:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			c := img.YCbCrAt(x, y)
+			r, g, b := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+			return uint32(r), uint32(g), uint32(b), 255
+		}
+	case *image.RGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			c := img.RGBAAt(x, y)
+			return uint32(c.R), uint32(c.G), uint32(c.B), uint32(c.A)
+		}
+	case *image.NRGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			c := img.NRGBAAt(x, y)
+			a := uint32(c.A)
+			return uint32(c.R) * a / 255, uint32(c.G) * a / 255, uint32(c.B) * a / 255, a
+		}
+	case *image.Gray:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			v := uint32(img.GrayAt(x, y).Y)
+			return v, v, v, 255
+		}
+	default:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			r, g, b, a := img.At(x, y).RGBA()
+			return r >> 8, g >> 8, b >> 8, a >> 8
+		}
+	}
+}
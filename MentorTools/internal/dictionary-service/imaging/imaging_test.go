@@ -0,0 +1,95 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// encodePNG кодирует картинку в PNG
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize подменяет размер картинки в заголовке IHDR: так проверяется отказ до декодирования
+// без создания большой картинки
+func withPNGSize(data []byte, width, height uint32) []byte {
+	data = append([]byte(nil), data...)
+	// Сигнатура (8 байт), длина чанка (4), тип "IHDR" (4), ширина и высота по 4 байта, CRC после 13 байт данных
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	small := encodePNG(t, image.NewGray(image.Rect(0, 0, 4, 4)))
+
+	if _, contentType, err := Decode(small); err != nil || contentType != "image/png" {
+		t.Errorf("Decode(png) = %q, %v; want image/png", contentType, err)
+	}
+	if _, _, err := Decode(withPNGSize(small, 4000, 4000)); errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("16 Mpx image rejected as too large")
+	}
+	if _, _, err := Decode(withPNGSize(small, 4001, 4000)); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("image above MaxPixels: err = %v, want ErrImageTooLarge", err)
+	}
+	if _, _, err := Decode([]byte("not an image")); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("text: err = %v, want ErrUnsupportedImage", err)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	// Левая половина красная, правая прозрачная
+	src := image.NewNRGBA(image.Rect(0, 0, 640, 320))
+	for y := 0; y < 320; y++ {
+		for x := 0; x < 320; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	data, err := Thumbnail(src, ThumbnailSize)
+	if err != nil {
+		t.Fatalf("Thumbnail: %v", err)
+	}
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if size := thumbnail.Bounds().Size(); size != image.Pt(320, 160) {
+		t.Errorf("thumbnail size %v, want 320x160", size)
+	}
+
+	near := func(c color.Color, want color.RGBA) bool {
+		r, g, b, _ := c.RGBA()
+		diff := func(got uint32, want uint8) bool { return int(got>>8)-int(want) < 16 && int(want)-int(got>>8) < 16 }
+		return diff(r, want.R) && diff(g, want.G) && diff(b, want.B)
+	}
+	if c := thumbnail.At(40, 80); !near(c, color.RGBA{R: 255}) {
+		t.Errorf("opaque part = %v, want red", c)
+	}
+	if c := thumbnail.At(280, 80); !near(c, color.RGBA{R: 255, G: 255, B: 255}) {
+		t.Errorf("transparent part = %v, want white", c)
+	}
+
+	// Маленькие картинки не увеличиваются; картинка с ненулевым началом координат читается целиком
+	offset := image.NewRGBA(image.Rect(10, 10, 110, 60))
+	data, err = Thumbnail(offset, ThumbnailSize)
+	if err != nil {
+		t.Fatalf("Thumbnail(small): %v", err)
+	}
+	if config, err := jpeg.DecodeConfig(bytes.NewReader(data)); err != nil || config.Width != 100 || config.Height != 50 {
+		t.Errorf("small thumbnail = %dx%d (err %v), want 100x50", config.Width, config.Height, err)
+	}
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"MentorTools/dictionary-service/models"
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	_ "modernc.org/sqlite" // Драйвер SQLite: колода Anki - это zip-архив с базой SQLite
+)
+
+// ankiFieldSeparator разделяет поля заметки в колонке notes.flds
+const ankiFieldSeparator = "\x1f"
+
+// maxCollectionSize - наибольший размер распакованной базы коллекции в байтах. Сжатая база может быть
+// во много раз меньше распакованной, поэтому размер проверяется и по заголовку архива, и при распаковке.
+// Переменная, а не константа, чтобы тесты могли уменьшить лимит.
+var maxCollectionSize int64 = 256 << 20
+
+// ankiCollections - имена файла коллекции внутри .apkg, от новых форматов к старым.
+// collection.anki21b (Anki 2.1.50+) сжат zstd и не поддерживается: такие колоды нужно
+// экспортировать с опцией "Support older Anki versions".
+var ankiCollections = []string{"collection.anki21", "collection.anki2"}
+
+// errCollectionTooLarge возвращается для коллекции, которая после распаковки больше maxCollectionSize
+var errCollectionTooLarge = errors.New("anki collection is too large when unpacked")
+
+var (
+	htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+	ankiSoundTag = regexp.MustCompile(`\[sound:[^\]]*\]`)
+	spacesRegex  = regexp.MustCompile(`\s+`)
+)
+
+// AnkiOptions - сопоставление полей заметки Anki полям слова
+type AnkiOptions struct {
+	WordField        int // Номер поля со словом, начиная с 0
+	TranslationField int // Номер поля с переводом; -1 - перевода нет
+}
+
+// ReadAnki читает слова из колоды Anki (.apkg)
+func ReadAnki(data []byte, opts AnkiOptions, maxRows int) ([]models.ImportEntry, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid .apkg archive: %w", err)
+	}
+
+	collection, err := openAnkiCollection(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(collection)
+
+	db, err := sql.Open("sqlite", collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Anki collection: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT flds FROM notes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Anki notes: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.ImportEntry
+	row := 0
+	for rows.Next() {
+		var flds string
+		if err := rows.Scan(&flds); err != nil {
+			return nil, fmt.Errorf("failed to read Anki note: %w", err)
+		}
+		row++
+		if len(entries) >= maxRows {
+			return nil, fmt.Errorf("deck contains more than %d notes", maxRows)
+		}
+
+		fields := strings.Split(flds, ankiFieldSeparator)
+		entry := models.ImportEntry{Row: row, Word: ankiField(fields, opts.WordField)}
+		if opts.TranslationField >= 0 {
+			entry.Translation = ankiField(fields, opts.TranslationField)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// openAnkiCollection распаковывает базу коллекции во временный файл и возвращает путь к нему
+func openAnkiCollection(archive *zip.Reader) (string, error) {
+	for _, name := range ankiCollections {
+		for _, file := range archive.File {
+			if file.Name != name {
+				continue
+			}
+
+			if file.UncompressedSize64 > uint64(maxCollectionSize) {
+				return "", errCollectionTooLarge
+			}
+			src, err := file.Open()
+			if err != nil {
+				return "", fmt.Errorf("failed to unpack %s: %w", name, err)
+			}
+			defer src.Close()
+
+			dst, err := os.CreateTemp("", "anki-*.sqlite")
+			if err != nil {
+				return "", err
+			}
+			// Заголовок архива может занижать размер: читаем не больше лимита и одного лишнего байта
+			written, err := io.Copy(dst, io.LimitReader(src, maxCollectionSize+1))
+			if err == nil && written > maxCollectionSize {
+				err = errCollectionTooLarge
+			}
+			if err != nil {
+				dst.Close()
+				os.Remove(dst.Name())
+				if errors.Is(err, errCollectionTooLarge) {
+					return "", err
+				}
+				return "", fmt.Errorf("failed to unpack %s: %w", name, err)
+			}
+			if err := dst.Close(); err != nil {
+				os.Remove(dst.Name())
+				return "", err
+			}
+			return dst.Name(), nil
+		}
+	}
+	return "", fmt.Errorf("anki collection not found in archive; export the deck with \"Support older Anki versions\" enabled")
+}
+
+// ankiField возвращает текст поля заметки без HTML-разметки и звуковых тегов
+func ankiField(fields []string, index int) string {
+	if index < 0 || index >= len(fields) {
+		return ""
+	}
+	value := ankiSoundTag.ReplaceAllString(fields[index], " ")
+	value = strings.NewReplacer("<br>", " ", "<br/>", " ", "<br />", " ", "&nbsp;", " ").Replace(value)
+	value = html.UnescapeString(htmlTagRegex.ReplaceAllString(value, " "))
+	return strings.TrimSpace(spacesRegex.ReplaceAllString(value, " "))
+}
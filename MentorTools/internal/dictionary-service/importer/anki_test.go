@@ -0,0 +1,211 @@
+package importer
+
+import (
+	"MentorTools/dictionary-service/models"
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// zipEntry упаковывает в архив один несжатый файл, заявляя в заголовке размер declaredSize
+func zipEntry(t *testing.T, name string, data []byte, declaredSize uint64) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	entry, err := writer.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(data),
+		CompressedSize64:   uint64(len(data)),
+		UncompressedSize64: declaredSize,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+func TestOpenAnkiCollectionSizeLimit(t *testing.T) {
+	previous := maxCollectionSize
+	maxCollectionSize = 64
+	t.Cleanup(func() { maxCollectionSize = previous })
+
+	data := bytes.Repeat([]byte{'x'}, 100)
+	if _, err := openAnkiCollection(zipEntry(t, "collection.anki2", data, 100)); !errors.Is(err, errCollectionTooLarge) {
+		t.Errorf("entry over the limit: err = %v, want errCollectionTooLarge", err)
+	}
+	// Заголовок занижает размер: распаковка всё равно не выходит за лимит
+	if _, err := openAnkiCollection(zipEntry(t, "collection.anki2", data, 10)); err == nil {
+		t.Errorf("entry with a forged size: no error")
+	}
+
+	path, err := openAnkiCollection(zipEntry(t, "collection.anki2", data[:64], 64))
+	if err != nil {
+		t.Fatalf("entry within the limit: %v", err)
+	}
+	os.Remove(path)
+}
+
+// ankiPackage собирает колоду .apkg с коллекцией name, в которой заметки notes - значения колонки notes.flds
+func ankiPackage(t *testing.T, name string, notes ...string) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "collection.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY, flds TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	// Заметки вставляются в обратном порядке: ReadAnki упорядочивает их по id
+	for i := len(notes) - 1; i >= 0; i-- {
+		if _, err := db.Exec("INSERT INTO notes (id, flds) VALUES (?, ?)", i+1, notes[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	collection, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	entry, err := writer.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entry.Write(collection); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadAnki(t *testing.T) {
+	deck := ankiPackage(t, "collection.anki2",
+		"apple\x1fяблоко",
+		"<b>ice</b>&nbsp;cream [sound:ice.mp3]\x1fмороженое<br>(десерт)",
+		"pear",
+		"\x1fгруша",
+	)
+	tests := []struct {
+		name    string
+		opts    AnkiOptions
+		entries []models.ImportEntry
+	}{
+		{
+			name: "word and translation",
+			opts: AnkiOptions{WordField: 0, TranslationField: 1},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+				{Row: 2, Word: "ice cream", Translation: "мороженое (десерт)"},
+				{Row: 3, Word: "pear"},
+				{Row: 4, Translation: "груша"},
+			},
+		},
+		{
+			name: "without translation",
+			opts: AnkiOptions{WordField: 0, TranslationField: -1},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple"},
+				{Row: 2, Word: "ice cream"},
+				{Row: 3, Word: "pear"},
+				{Row: 4},
+			},
+		},
+		{
+			name: "swapped fields",
+			opts: AnkiOptions{WordField: 1, TranslationField: 0},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "яблоко", Translation: "apple"},
+				{Row: 2, Word: "мороженое (десерт)", Translation: "ice cream"},
+				{Row: 3, Translation: "pear"},
+				{Row: 4, Word: "груша"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		entries, err := ReadAnki(deck, tt.opts, 10)
+		if err != nil {
+			t.Errorf("%s: ReadAnki: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(entries, tt.entries) {
+			t.Errorf("%s: ReadAnki = %+v, want %+v", tt.name, entries, tt.entries)
+		}
+	}
+
+	if _, err := ReadAnki(deck, AnkiOptions{TranslationField: -1}, 3); err == nil || !strings.Contains(err.Error(), "more than 3 notes") {
+		t.Errorf("deck over the limit: err = %v", err)
+	}
+}
+
+func TestReadAnkiCollections(t *testing.T) {
+	opts := AnkiOptions{TranslationField: -1}
+
+	// Формат Anki 2.1 читается так же, как старый
+	entries, err := ReadAnki(ankiPackage(t, "collection.anki21", "apple"), opts, 10)
+	if err != nil || len(entries) != 1 || entries[0].Word != "apple" {
+		t.Errorf("collection.anki21: ReadAnki = %+v, %v", entries, err)
+	}
+
+	for name, deck := range map[string][]byte{
+		"zstd collection": ankiPackage(t, "collection.anki21b", "apple"),
+		"not a zip":       []byte("apple,яблоко"),
+	} {
+		if _, err := ReadAnki(deck, opts, 10); err == nil {
+			t.Errorf("%s: ReadAnki: no error", name)
+		}
+	}
+}
+
+func TestAnkiField(t *testing.T) {
+	fields := []string{
+		"  apple  ",
+		"<div>red <i>apple</i></div>",
+		"[sound:apple.mp3]apple",
+		"fish &amp; chips",
+		"line<br/>break<br />and<br>more",
+		"\n\tspaced\t out ",
+	}
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "apple"},
+		{1, "red apple"},
+		{2, "apple"},
+		{3, "fish & chips"},
+		{4, "line break and more"},
+		{5, "spaced out"},
+		{-1, ""},
+		{len(fields), ""},
+	}
+	for _, tt := range tests {
+		if got := ankiField(fields, tt.index); got != tt.want {
+			t.Errorf("ankiField(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
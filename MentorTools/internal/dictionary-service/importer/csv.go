@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"MentorTools/dictionary-service/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVOptions - сопоставление колонок CSV-файла полям слова
+type CSVOptions struct {
+	Delimiter         rune   // Разделитель колонок, по умолчанию ","
+	HasHeader         bool   // Первая строка содержит названия колонок
+	WordColumn        string // Название колонки со словом или её номер, начиная с 0
+	TranslationColumn string // Название колонки с переводом или её номер; пустая строка - перевода нет
+}
+
+// ReadCSV читает слова из CSV-файла; пустые строки пропускаются
+func ReadCSV(r io.Reader, opts CSVOptions, maxRows int) ([]models.ImportEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	var header []string
+	if opts.HasHeader {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		header = record
+		// Excel сохраняет UTF-8 с BOM, который прилипает к названию первой колонки
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+	}
+
+	wordIndex, err := columnIndex(opts.WordColumn, header)
+	if err != nil {
+		return nil, err
+	}
+	translationIndex := -1
+	if opts.TranslationColumn != "" {
+		if translationIndex, err = columnIndex(opts.TranslationColumn, header); err != nil {
+			return nil, err
+		}
+	}
+
+	var entries []models.ImportEntry
+	row := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row++
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+		if len(entries) >= maxRows {
+			return nil, fmt.Errorf("file contains more than %d rows", maxRows)
+		}
+
+		if isBlank(record) {
+			continue
+		}
+
+		entry := models.ImportEntry{Row: row, Word: field(record, wordIndex)}
+		if translationIndex >= 0 {
+			entry.Translation = field(record, translationIndex)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ParseDelimiter разбирает разделитель колонок из параметра запроса; "tab" и "\t" означают табуляцию
+func ParseDelimiter(value string) (rune, error) {
+	switch value {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("delimiter must be a single character")
+	}
+	delimiter, _ := utf8.DecodeRuneInString(value)
+	return delimiter, nil
+}
+
+// columnIndex находит колонку по названию из заголовка или по номеру
+func columnIndex(column string, header []string) (int, error) {
+	if column == "" {
+		return 0, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i, nil
+		}
+	}
+	index, err := strconv.Atoi(column)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("unknown column %q", column)
+	}
+	return index, nil
+}
+
+func field(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
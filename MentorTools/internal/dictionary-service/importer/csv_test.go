@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"MentorTools/dictionary-service/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		opts    CSVOptions
+		entries []models.ImportEntry
+	}{
+		{
+			name: "one column without header",
+			data: "apple\n banana \n",
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple"},
+				{Row: 2, Word: "banana"},
+			},
+		},
+		{
+			name: "translation by column number",
+			data: "apple,яблоко\nbanana,банан\n",
+			opts: CSVOptions{TranslationColumn: "1"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+				{Row: 2, Word: "banana", Translation: "банан"},
+			},
+		},
+		{
+			name: "semicolon delimiter",
+			data: "apple;яблоко\n",
+			opts: CSVOptions{Delimiter: ';', TranslationColumn: "1"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+			},
+		},
+		{
+			name: "tab delimiter and quoted field",
+			data: "\"ice cream\"\tмороженое\n",
+			opts: CSVOptions{Delimiter: '\t', TranslationColumn: "1"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "ice cream", Translation: "мороженое"},
+			},
+		},
+		{
+			name: "columns by header name",
+			data: "Translation,Word\nяблоко,apple\n",
+			opts: CSVOptions{HasHeader: true, WordColumn: "word", TranslationColumn: " TRANSLATION "},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+			},
+		},
+		{
+			name: "header with BOM",
+			data: "\ufeffword,translation\napple,яблоко\n",
+			opts: CSVOptions{HasHeader: true, WordColumn: "word", TranslationColumn: "translation"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+			},
+		},
+		{
+			name: "column number with header",
+			data: "word,translation\napple,яблоко\n",
+			opts: CSVOptions{HasHeader: true, TranslationColumn: "1"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+			},
+		},
+		{
+			name: "blank rows and short rows",
+			data: "apple,яблоко\n\n , \nbanana\n",
+			opts: CSVOptions{TranslationColumn: "1"},
+			entries: []models.ImportEntry{
+				{Row: 1, Word: "apple", Translation: "яблоко"},
+				{Row: 3, Word: "banana"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		entries, err := ReadCSV(strings.NewReader(tt.data), tt.opts, 10)
+		if err != nil {
+			t.Errorf("%s: ReadCSV: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(entries, tt.entries) {
+			t.Errorf("%s: ReadCSV = %+v, want %+v", tt.name, entries, tt.entries)
+		}
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts CSVOptions
+		want string
+	}{
+		{"unknown column", "word\napple\n", CSVOptions{HasHeader: true, WordColumn: "lemma"}, `unknown column "lemma"`},
+		{"negative column", "apple\n", CSVOptions{TranslationColumn: "-1"}, "unknown column"},
+		{"empty file with header", "", CSVOptions{HasHeader: true}, "failed to read CSV header"},
+		{"too many rows", "a\nb\nc\nd\n", CSVOptions{}, "more than 3 rows"},
+	}
+	for _, tt := range tests {
+		if _, err := ReadCSV(strings.NewReader(tt.data), tt.opts, 3); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: ReadCSV err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	// Пустые строки не считаются строками файла при проверке лимита
+	entries, err := ReadCSV(strings.NewReader("a\n , \n,\nb\nc\n"), CSVOptions{}, 3)
+	if err != nil || len(entries) != 3 {
+		t.Errorf("blank rows within the limit: %d entries, err = %v; want 3 entries", len(entries), err)
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		value     string
+		delimiter rune
+		ok        bool
+	}{
+		{"", ',', true},
+		{";", ';', true},
+		{"tab", '\t', true},
+		{`\t`, '\t', true},
+		{"|", '|', true},
+		{"§", '§', true},
+		{",;", 0, false},
+	}
+	for _, tt := range tests {
+		delimiter, err := ParseDelimiter(tt.value)
+		if delimiter != tt.delimiter || (err == nil) != tt.ok {
+			t.Errorf("ParseDelimiter(%q) = %q, %v; want %q, ok = %v", tt.value, delimiter, err, tt.delimiter, tt.ok)
+		}
+	}
+}
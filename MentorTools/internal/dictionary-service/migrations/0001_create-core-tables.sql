@@ -0,0 +1,51 @@
+-- Основные таблицы словаря в том виде, в котором их использовал сервис до появления миграций.
+-- Колонки, уникальные индексы и ограничения добавляются следующими миграциями.
+
+-- Слова общего словаря; контент заполняет GPT
+CREATE TABLE IF NOT EXISTS words (
+                                     id SERIAL PRIMARY KEY,
+                                     word VARCHAR(255) NOT NULL,
+                                     transcription VARCHAR(255) NULL,
+                                     translation TEXT NULL,
+                                     definition TEXT NULL,
+                                     status VARCHAR(20) NOT NULL DEFAULT 'pending'
+);
+
+-- Слова в словаре ученика
+CREATE TABLE IF NOT EXISTS student_words (
+                                             student_id INT NOT NULL,
+                                             word_id INT NOT NULL,
+                                             status VARCHAR(50) NOT NULL DEFAULT 'need to learn'
+);
+
+-- Синонимы слов
+CREATE TABLE IF NOT EXISTS word_links (
+                                          word_id INT NOT NULL,
+                                          linked_word_id INT NOT NULL
+);
+
+-- Примеры употребления
+CREATE TABLE IF NOT EXISTS examples (
+                                        id SERIAL PRIMARY KEY,
+                                        example TEXT NULL,
+                                        context VARCHAR(255) NULL,
+                                        translation TEXT NULL
+);
+
+-- Слова примера: само слово и ключевые слова
+CREATE TABLE IF NOT EXISTS word_example (
+                                            word_id INT NOT NULL,
+                                            example_id INT NOT NULL
+);
+
+COMMENT ON COLUMN public.words.word IS 'Word or expression in its dictionary form';
+COMMENT ON COLUMN public.words.transcription IS 'IPA transcription';
+COMMENT ON COLUMN public.words.translation IS 'Translation of the word';
+COMMENT ON COLUMN public.words.definition IS 'Explanation of the meaning of the word';
+COMMENT ON COLUMN public.words.status IS 'pending, processing, completed or failed';
+COMMENT ON COLUMN public.student_words.student_id IS 'Reference to student (users.id)';
+COMMENT ON COLUMN public.student_words.status IS 'need to learn or learned';
+COMMENT ON COLUMN public.word_links.linked_word_id IS 'Related word';
+COMMENT ON COLUMN public.examples.example IS 'Example sentence';
+COMMENT ON COLUMN public.examples.context IS 'Topic or area of the example';
+COMMENT ON COLUMN public.examples.translation IS 'Translation of the example sentence';
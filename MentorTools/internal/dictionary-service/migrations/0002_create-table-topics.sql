@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS topics (
+                                      id SERIAL PRIMARY KEY,
+                                      topic_name VARCHAR(100) UNIQUE NOT NULL,
+                                      created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN public.topics.id IS 'Identifier';
+COMMENT ON COLUMN public.topics.topic_name IS 'Name of topic used as context for examples';
+COMMENT ON COLUMN public.topics.created_at IS 'Timestamp when the topic was created';
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS student_topics (
+                                              student_id INT NOT NULL,
+                                              topic_id INT NOT NULL,
+                                              use_count INT NOT NULL DEFAULT 0,
+                                              last_used_at TIMESTAMP NULL,
+                                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                              PRIMARY KEY (student_id, topic_id),
+                                              FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.student_topics.student_id IS 'Reference to student (users.id)';
+COMMENT ON COLUMN public.student_topics.topic_id IS 'Reference to topic';
+COMMENT ON COLUMN public.student_topics.use_count IS 'How many times the topic was sent to GPT for examples';
+COMMENT ON COLUMN public.student_topics.last_used_at IS 'When the topic was last sent to GPT for examples';
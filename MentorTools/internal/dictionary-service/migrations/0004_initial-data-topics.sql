@@ -0,0 +1,15 @@
+INSERT INTO topics (topic_name)
+VALUES
+    ('travel'),
+    ('food'),
+    ('work'),
+    ('sport'),
+    ('music'),
+    ('movies'),
+    ('technology'),
+    ('family'),
+    ('health'),
+    ('nature'),
+    ('shopping'),
+    ('education')
+ON CONFLICT (topic_name) DO NOTHING;
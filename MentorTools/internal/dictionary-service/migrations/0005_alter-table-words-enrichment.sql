@@ -0,0 +1,13 @@
+ALTER TABLE words ADD COLUMN IF NOT EXISTS enrichment_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS enrichment_error TEXT NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS enrichment_started_at TIMESTAMP NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NULL;
+
+-- Очередь обогащения выбирает слова по статусу, поэтому индексируем только незавершённые
+CREATE INDEX IF NOT EXISTS idx_words_enrichment_queue ON words (status, next_attempt_at)
+    WHERE status IN ('pending', 'processing');
+
+COMMENT ON COLUMN public.words.enrichment_attempts IS 'How many times the background worker tried to enrich the word';
+COMMENT ON COLUMN public.words.enrichment_error IS 'Last enrichment error';
+COMMENT ON COLUMN public.words.enrichment_started_at IS 'When the word was claimed by the background worker';
+COMMENT ON COLUMN public.words.next_attempt_at IS 'Earliest time of the next enrichment attempt';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS word_jobs (
+                                         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                         student_id INT NOT NULL,
+                                         word VARCHAR(255) NOT NULL,
+                                         status VARCHAR(20) NOT NULL DEFAULT 'queued',
+                                         stage VARCHAR(50) NOT NULL DEFAULT 'queued',
+                                         word_id INT NULL REFERENCES words(id) ON DELETE SET NULL,
+                                         error TEXT NULL,
+                                         started_at TIMESTAMP NULL,
+                                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                         CONSTRAINT word_jobs_status_check CHECK (status IN ('queued', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_jobs_queue ON word_jobs (created_at) WHERE status IN ('queued', 'running');
+
+COMMENT ON COLUMN public.word_jobs.id IS 'Job identifier returned to the client';
+COMMENT ON COLUMN public.word_jobs.student_id IS 'Student who added the word';
+COMMENT ON COLUMN public.word_jobs.word IS 'Normalized word as requested by the student';
+COMMENT ON COLUMN public.word_jobs.status IS 'queued, running, completed or failed';
+COMMENT ON COLUMN public.word_jobs.stage IS 'Current processing stage shown to the user';
+COMMENT ON COLUMN public.word_jobs.word_id IS 'Resulting word once the job is completed';
+COMMENT ON COLUMN public.word_jobs.error IS 'Error message if the job failed';
+COMMENT ON COLUMN public.word_jobs.started_at IS 'When the job was claimed by a worker';
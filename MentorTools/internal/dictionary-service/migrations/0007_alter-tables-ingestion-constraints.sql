@@ -0,0 +1,50 @@
+-- Ограничения, на которые опирается идемпотентное добавление слов (INSERT ... ON CONFLICT).
+-- Перед созданием ограничений удаляем дубликаты, накопленные до их появления.
+
+-- Дубликаты слов: ссылки переносим на самую раннюю запись, остальные удаляем
+WITH duplicates AS (
+    SELECT id, min(id) OVER (PARTITION BY word) AS keep_id FROM words
+)
+UPDATE student_words sw SET word_id = d.keep_id FROM duplicates d WHERE sw.word_id = d.id AND d.id <> d.keep_id;
+
+WITH duplicates AS (
+    SELECT id, min(id) OVER (PARTITION BY word) AS keep_id FROM words
+)
+UPDATE word_links wl SET word_id = d.keep_id FROM duplicates d WHERE wl.word_id = d.id AND d.id <> d.keep_id;
+
+WITH duplicates AS (
+    SELECT id, min(id) OVER (PARTITION BY word) AS keep_id FROM words
+)
+UPDATE word_links wl SET linked_word_id = d.keep_id FROM duplicates d WHERE wl.linked_word_id = d.id AND d.id <> d.keep_id;
+
+WITH duplicates AS (
+    SELECT id, min(id) OVER (PARTITION BY word) AS keep_id FROM words
+)
+UPDATE word_example we SET word_id = d.keep_id FROM duplicates d WHERE we.word_id = d.id AND d.id <> d.keep_id;
+
+DELETE FROM words w USING words k WHERE w.word = k.word AND w.id > k.id;
+
+-- Дубликаты связей
+DELETE FROM student_words a USING student_words b
+WHERE a.student_id = b.student_id AND a.word_id = b.word_id AND a.ctid > b.ctid;
+
+DELETE FROM word_links a USING word_links b
+WHERE a.word_id = b.word_id AND a.linked_word_id = b.linked_word_id AND a.ctid > b.ctid;
+
+DELETE FROM word_example a USING word_example b
+WHERE a.word_id = b.word_id AND a.example_id = b.example_id AND a.ctid > b.ctid;
+
+-- Примеры, оставшиеся без слов после неудачных добавлений
+DELETE FROM examples e WHERE NOT EXISTS (SELECT 1 FROM word_example we WHERE we.example_id = e.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_words_word ON words (word);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_student_words_student_word ON student_words (student_id, word_id);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_word_links_word_linked ON word_links (word_id, linked_word_id);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_word_example_word_example ON word_example (word_id, example_id);
+
+-- Idempotency-Key: повторный запрос с тем же ключом возвращает уже созданную задачу
+ALTER TABLE word_jobs ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255) NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_word_jobs_idempotency_key ON word_jobs (student_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+COMMENT ON COLUMN public.word_jobs.idempotency_key IS 'Client-provided Idempotency-Key header';
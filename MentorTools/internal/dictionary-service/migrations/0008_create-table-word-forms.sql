@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS word_forms (
+                                          form VARCHAR(255) PRIMARY KEY,
+                                          word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                          created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_forms_word_id ON word_forms (word_id);
+
+-- Форма, в которой ученик ввёл слово ("ran" для леммы "run")
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS typed_form VARCHAR(255) NULL;
+
+COMMENT ON COLUMN public.word_forms.form IS 'Inflected form or spelling variant typed by a student';
+COMMENT ON COLUMN public.word_forms.word_id IS 'Reference to the lemma in words';
+COMMENT ON COLUMN public.student_words.typed_form IS 'Form of the word as the student typed it';
@@ -0,0 +1,6 @@
+-- Колода, в которую слово попало у ученика (например, при импорте из Anki или CSV)
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS deck VARCHAR(255) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_student_words_deck ON student_words (student_id, deck);
+
+COMMENT ON COLUMN public.student_words.deck IS 'Name of the deck the word belongs to for the student';
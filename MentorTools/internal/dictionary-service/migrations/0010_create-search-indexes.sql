@@ -0,0 +1,31 @@
+-- Поиск по словарю: полнотекстовый поиск и нечёткое сравнение по триграммам.
+-- Конфигурация 'russian' разбирает русский текст русским стеммером, а латиницу - английским,
+-- поэтому одна конфигурация подходит и для слов, и для переводов, и для смешанных запросов.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(word, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(translation, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(definition, '')), 'C')
+    ) STORED;
+
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(example, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(translation, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_words_search_vector ON words USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_examples_search_vector ON examples USING GIN (search_vector);
+
+-- Триграммы для запросов с опечатками
+CREATE INDEX IF NOT EXISTS idx_words_word_trgm ON words USING GIN (word gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_words_translation_trgm ON words USING GIN (lower(translation) gin_trgm_ops);
+
+-- Поиск по префиксу для автодополнения
+CREATE INDEX IF NOT EXISTS idx_words_word_prefix ON words (word text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_words_translation_prefix ON words (lower(translation) text_pattern_ops);
+
+COMMENT ON COLUMN public.words.search_vector IS 'Full-text search vector over word, translation and definition';
+COMMENT ON COLUMN public.examples.search_vector IS 'Full-text search vector over example sentence and its translation';
@@ -0,0 +1,11 @@
+-- Дата добавления слова ученику и дата следующего повторения.
+-- Новое слово сразу попадает в повторение; у существующих слов обе даты заполняются моментом миграции.
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS next_review_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Индексы под курсорную пагинацию словаря ученика
+CREATE INDEX IF NOT EXISTS idx_student_words_added ON student_words (student_id, added_at, word_id);
+CREATE INDEX IF NOT EXISTS idx_student_words_review ON student_words (student_id, next_review_at, word_id);
+
+COMMENT ON COLUMN public.student_words.added_at IS 'Timestamp when the word was added to the student vocabulary';
+COMMENT ON COLUMN public.student_words.next_review_at IS 'Timestamp when the word is due for the next review';
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS learning_events (
+                                               id BIGSERIAL PRIMARY KEY,
+                                               student_id INT NOT NULL,
+                                               word_id INT NULL REFERENCES words(id) ON DELETE SET NULL,
+                                               event_type VARCHAR(30) NOT NULL,
+                                               correct BOOLEAN NULL,
+                                               old_status VARCHAR(50) NULL,
+                                               new_status VARCHAR(50) NULL,
+                                               created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                               CONSTRAINT learning_events_type_check CHECK (event_type IN ('added', 'reviewed', 'status_changed', 'quiz_answered'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_learning_events_student ON learning_events (student_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_learning_events_word ON learning_events (student_id, word_id, event_type);
+
+-- Интервальное повторение: текущий интервал и серия правильных ответов подряд
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS review_interval_days INT NOT NULL DEFAULT 0;
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS correct_streak INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN public.learning_events.student_id IS 'Student the event belongs to';
+COMMENT ON COLUMN public.learning_events.word_id IS 'Word the event refers to';
+COMMENT ON COLUMN public.learning_events.event_type IS 'added, reviewed, status_changed or quiz_answered';
+COMMENT ON COLUMN public.learning_events.correct IS 'Whether the answer was correct, for reviewed and quiz_answered events';
+COMMENT ON COLUMN public.learning_events.old_status IS 'Previous word status, for status_changed events';
+COMMENT ON COLUMN public.learning_events.new_status IS 'New word status, for status_changed events';
+COMMENT ON COLUMN public.learning_events.created_at IS 'Timestamp when the event happened';
+COMMENT ON COLUMN public.student_words.review_interval_days IS 'Current spaced repetition interval in days';
+COMMENT ON COLUMN public.student_words.correct_streak IS 'Number of consecutive correct answers for the word';
@@ -0,0 +1,54 @@
+-- Модерация контента, сгенерированного GPT: правки репетиторов и очередь проверки
+
+-- Настройки модерации (ключ - значение)
+CREATE TABLE IF NOT EXISTS moderation_settings (
+                                                   key VARCHAR(100) PRIMARY KEY,
+                                                   value VARCHAR(255) NOT NULL,
+                                                   updated_by INT NULL,
+                                                   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO moderation_settings (key, value) VALUES ('review_generated_content', 'false')
+ON CONFLICT (key) DO NOTHING;
+
+-- Опубликован ли контент слова; неопубликованный ждёт проверки репетитором
+ALTER TABLE words ADD COLUMN IF NOT EXISTS published BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS reviewed_by INT NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP NULL;
+
+-- Отклонённые примеры и скрытые синонимы не показываются ученикам
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE word_links ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_words_unpublished ON words (id) WHERE NOT published;
+
+-- История правок: каждая правка слова получает следующий номер версии
+CREATE TABLE IF NOT EXISTS content_edits (
+                                             id BIGSERIAL PRIMARY KEY,
+                                             word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                             version INT NOT NULL,
+                                             target VARCHAR(20) NOT NULL,
+                                             target_id INT NOT NULL DEFAULT 0,
+                                             field VARCHAR(30) NOT NULL,
+                                             old_value TEXT NULL,
+                                             new_value TEXT NULL,
+                                             author_id INT NOT NULL,
+                                             created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                             CONSTRAINT content_edits_target_check CHECK (target IN ('word', 'example', 'synonym')),
+                                             CONSTRAINT content_edits_version_unique UNIQUE (word_id, version)
+);
+
+COMMENT ON COLUMN public.moderation_settings.key IS 'Setting name, e.g. review_generated_content';
+COMMENT ON COLUMN public.moderation_settings.value IS 'Setting value';
+COMMENT ON COLUMN public.words.published IS 'Whether GPT content of the word is visible to students';
+COMMENT ON COLUMN public.words.reviewed_by IS 'Tutor who approved the content';
+COMMENT ON COLUMN public.words.reviewed_at IS 'Timestamp when the content was approved';
+COMMENT ON COLUMN public.examples.hidden IS 'Example was rejected by a tutor';
+COMMENT ON COLUMN public.word_links.hidden IS 'Synonym was hidden by a tutor';
+COMMENT ON COLUMN public.content_edits.version IS 'Sequential edit number within the word';
+COMMENT ON COLUMN public.content_edits.target IS 'word, example or synonym';
+COMMENT ON COLUMN public.content_edits.target_id IS 'Example ID or linked word ID; 0 for the word itself';
+COMMENT ON COLUMN public.content_edits.field IS 'Changed field: transcription, translation, definition, sentence, hidden, published';
+COMMENT ON COLUMN public.content_edits.old_value IS 'Value before the edit';
+COMMENT ON COLUMN public.content_edits.new_value IS 'Value after the edit';
+COMMENT ON COLUMN public.content_edits.author_id IS 'Tutor who made the edit';
@@ -0,0 +1,31 @@
+-- Версии контента слова, сгенерированного GPT: каждая генерация сохраняется целиком,
+-- а в words, word_example и word_links развёрнута активная версия
+
+CREATE TABLE IF NOT EXISTS word_content_versions (
+                                                     id SERIAL PRIMARY KEY,
+                                                     word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                     version INT NOT NULL,
+                                                     model VARCHAR(100) NOT NULL DEFAULT '',
+                                                     prompt_version INT NOT NULL DEFAULT 0,
+                                                     content JSONB NOT NULL,
+                                                     created_by INT NULL,
+                                                     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                                     CONSTRAINT word_content_versions_unique UNIQUE (word_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_content_versions_prompt ON word_content_versions (prompt_version);
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS active_version_id INT NULL REFERENCES word_content_versions(id) ON DELETE SET NULL;
+
+-- Пример принадлежит версии, в которой он был сгенерирован
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS content_version_id INT NULL REFERENCES word_content_versions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_examples_content_version ON examples (content_version_id);
+
+COMMENT ON COLUMN public.word_content_versions.version IS 'Sequential generation number within the word';
+COMMENT ON COLUMN public.word_content_versions.model IS 'GPT model that produced the content; empty for content generated before versioning';
+COMMENT ON COLUMN public.word_content_versions.prompt_version IS 'Version of the system prompt; 0 for content generated before versioning';
+COMMENT ON COLUMN public.word_content_versions.content IS 'Full GPT response: transcription, translation, description, synonyms and examples';
+COMMENT ON COLUMN public.word_content_versions.created_by IS 'Tutor who requested regeneration; NULL for automatic generation';
+COMMENT ON COLUMN public.words.active_version_id IS 'Content version shown to students';
+COMMENT ON COLUMN public.examples.content_version_id IS 'Content version that produced the example';
@@ -0,0 +1,28 @@
+-- Языковые пары: язык изучаемого слова и язык перевода и объяснений (коды ISO 639-1).
+-- Всё, что было добавлено до появления пар, - английские слова с переводом на русский.
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS source_lang VARCHAR(5) NOT NULL DEFAULT 'en';
+ALTER TABLE words ADD COLUMN IF NOT EXISTS target_lang VARCHAR(5) NOT NULL DEFAULT 'ru';
+
+-- Одно и то же написание может быть словом разных языков ("die", "once") и иметь разный перевод
+DROP INDEX IF EXISTS ux_words_word;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_words_word_languages ON words (word, source_lang, target_lang);
+
+-- Формы слов тоже различаются по языку
+ALTER TABLE word_forms ADD COLUMN IF NOT EXISTS source_lang VARCHAR(5) NOT NULL DEFAULT 'en';
+ALTER TABLE word_forms DROP CONSTRAINT IF EXISTS word_forms_pkey;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_word_forms_form_language ON word_forms (form, source_lang);
+
+-- Языковая пара ученика; если записи нет, ученик учит английский с объяснениями на русском
+CREATE TABLE IF NOT EXISTS student_languages (
+                                                 student_id INT PRIMARY KEY,
+                                                 source_lang VARCHAR(5) NOT NULL DEFAULT 'en',
+                                                 target_lang VARCHAR(5) NOT NULL DEFAULT 'ru',
+                                                 updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN public.words.source_lang IS 'Language of the word, ISO 639-1 code';
+COMMENT ON COLUMN public.words.target_lang IS 'Language of the translation and description, ISO 639-1 code';
+COMMENT ON COLUMN public.word_forms.source_lang IS 'Language of the form, ISO 639-1 code';
+COMMENT ON COLUMN public.student_languages.source_lang IS 'Language the student learns';
+COMMENT ON COLUMN public.student_languages.target_lang IS 'Language of translations and explanations for the student';
@@ -0,0 +1,10 @@
+-- Помощник чтения: личные примеры учеников из текстов и предложение, из которого добавлено слово
+
+-- Ученик, из текста которого взят пример; такие примеры видит только он сам
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS student_id INT NULL;
+CREATE INDEX IF NOT EXISTS idx_examples_student_id ON examples (student_id) WHERE student_id IS NOT NULL;
+
+ALTER TABLE word_jobs ADD COLUMN IF NOT EXISTS source_sentence TEXT NULL;
+
+COMMENT ON COLUMN public.examples.student_id IS 'Student whose reading text the example was taken from; NULL for GPT examples';
+COMMENT ON COLUMN public.word_jobs.source_sentence IS 'Sentence the word was added from, saved as the student''s example';
@@ -0,0 +1,13 @@
+-- Уровень сложности слов: ранг во встроенном частотном списке и уровень CEFR.
+-- Слова без уровня получают его при следующем заполнении или командой tag-levels.
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS frequency_rank INT NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS cefr_level VARCHAR(2) NULL
+    CHECK (cefr_level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2'));
+ALTER TABLE words ADD COLUMN IF NOT EXISTS level_source VARCHAR(10) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_words_cefr_level ON words (cefr_level);
+
+COMMENT ON COLUMN public.words.frequency_rank IS 'Rank in the bundled frequency list, 1 is the most frequent; NULL if the word is not on the list';
+COMMENT ON COLUMN public.words.cefr_level IS 'CEFR level of the word, A1-C2';
+COMMENT ON COLUMN public.words.level_source IS 'Where the level came from: list or gpt';
@@ -0,0 +1,22 @@
+-- Аудио произношения слов и предложений примеров. Файлы лежат в хранилище (файловая система или S3),
+-- в БД хранится только ключ объекта. Ключ выводится из текста, языка и голоса, поэтому одинаковые
+-- тексты озвучиваются один раз.
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS audio_key VARCHAR(255) NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS audio_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS audio_error TEXT NULL;
+
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS audio_key VARCHAR(255) NULL;
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS audio_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS audio_error TEXT NULL;
+
+-- Очередь озвучивания: заполненные слова и примеры без аудио
+CREATE INDEX IF NOT EXISTS idx_words_audio_queue ON words (audio_attempts, id) WHERE audio_key IS NULL AND status = 'completed';
+CREATE INDEX IF NOT EXISTS idx_examples_audio_queue ON examples (audio_attempts, id) WHERE audio_key IS NULL AND NOT hidden;
+
+COMMENT ON COLUMN public.words.audio_key IS 'Blob store key of the pronunciation audio';
+COMMENT ON COLUMN public.words.audio_attempts IS 'Failed attempts to generate the audio';
+COMMENT ON COLUMN public.words.audio_error IS 'Error of the last failed attempt to generate the audio';
+COMMENT ON COLUMN public.examples.audio_key IS 'Blob store key of the sentence audio';
+COMMENT ON COLUMN public.examples.audio_attempts IS 'Failed attempts to generate the audio';
+COMMENT ON COLUMN public.examples.audio_error IS 'Error of the last failed attempt to generate the audio';
@@ -0,0 +1,36 @@
+-- Тренировка написания: ученик видит перевод или слышит слово и набирает его
+
+CREATE TABLE IF NOT EXISTS typing_sessions (
+                                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                               student_id INT NOT NULL,
+                                               mode VARCHAR(20) NOT NULL DEFAULT 'both',
+                                               created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                               CONSTRAINT typing_sessions_mode_check CHECK (mode IN ('translation', 'audio', 'both'))
+);
+
+CREATE TABLE IF NOT EXISTS typing_session_words (
+                                                    session_id UUID NOT NULL REFERENCES typing_sessions(id) ON DELETE CASCADE,
+                                                    word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                    position INT NOT NULL,
+                                                    typed VARCHAR(255) NULL,
+                                                    correct BOOLEAN NULL,
+                                                    distance INT NULL,
+                                                    answered_at TIMESTAMP NULL,
+                                                    PRIMARY KEY (session_id, word_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_typing_sessions_student ON typing_sessions (student_id, created_at);
+
+-- Ответ в тренировке написания - такое же событие обучения, как повторение и тест
+ALTER TABLE learning_events DROP CONSTRAINT IF EXISTS learning_events_type_check;
+ALTER TABLE learning_events ADD CONSTRAINT learning_events_type_check
+    CHECK (event_type IN ('added', 'reviewed', 'status_changed', 'quiz_answered', 'typed'));
+
+COMMENT ON COLUMN public.typing_sessions.student_id IS 'Student practicing spelling';
+COMMENT ON COLUMN public.typing_sessions.mode IS 'What the prompts show: translation, audio or both';
+COMMENT ON COLUMN public.typing_session_words.position IS 'Order of the word in the session';
+COMMENT ON COLUMN public.typing_session_words.typed IS 'Answer typed by the student';
+COMMENT ON COLUMN public.typing_session_words.correct IS 'Whether the answer was accepted';
+COMMENT ON COLUMN public.typing_session_words.distance IS 'Edit distance between the answer and the word after normalization';
+COMMENT ON COLUMN public.typing_session_words.answered_at IS 'When the word was answered; NULL while it is pending';
+COMMENT ON COLUMN public.learning_events.event_type IS 'added, reviewed, status_changed, quiz_answered or typed';
@@ -0,0 +1,18 @@
+-- Типизированные связи между словами: синонимы, антонимы, сочетания, однокоренные слова и более общие понятия.
+-- Существующие связи были синонимами, поэтому получают тип synonym.
+
+ALTER TABLE word_links ADD COLUMN IF NOT EXISTS relation_type VARCHAR(20) NOT NULL DEFAULT 'synonym'
+    CHECK (relation_type IN ('synonym', 'antonym', 'collocation', 'derivative', 'hypernym'));
+ALTER TABLE word_links ADD COLUMN IF NOT EXISTS direction VARCHAR(10) NOT NULL DEFAULT 'both'
+    CHECK (direction IN ('both', 'forward'));
+ALTER TABLE word_links ADD COLUMN IF NOT EXISTS confidence REAL NOT NULL DEFAULT 1
+    CHECK (confidence >= 0 AND confidence <= 1);
+
+-- Одна пара слов может быть связана несколькими типами связей
+DROP INDEX IF EXISTS ux_word_links_word_linked;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_word_links_word_linked_type ON word_links (word_id, linked_word_id, relation_type);
+CREATE INDEX IF NOT EXISTS idx_word_links_linked ON word_links (linked_word_id);
+
+COMMENT ON COLUMN public.word_links.relation_type IS 'Relation type: synonym, antonym, collocation, derivative or hypernym';
+COMMENT ON COLUMN public.word_links.direction IS 'both - the relation holds both ways, forward - only from word_id to linked_word_id (linked word is the hypernym)';
+COMMENT ON COLUMN public.word_links.confidence IS 'How sure GPT was about the relation, 0-1';
@@ -0,0 +1,49 @@
+-- Ежедневные уроки: план на день для ученика, который строится утром по его часовому поясу
+
+-- Настройки урока ученика; если записи нет, используются настройки по умолчанию
+CREATE TABLE IF NOT EXISTS student_lesson_settings (
+                                                       student_id INT PRIMARY KEY,
+                                                       timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+                                                       daily_goal INT NOT NULL DEFAULT 20,
+                                                       new_words INT NOT NULL DEFAULT 5,
+                                                       lesson_hour INT NOT NULL DEFAULT 6,
+                                                       decks TEXT[] NOT NULL DEFAULT '{}',
+                                                       updated_by INT NULL,
+                                                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                       CONSTRAINT student_lesson_settings_goal_check CHECK (daily_goal BETWEEN 1 AND 200),
+                                                       CONSTRAINT student_lesson_settings_new_words_check CHECK (new_words BETWEEN 0 AND 50),
+                                                       CONSTRAINT student_lesson_settings_hour_check CHECK (lesson_hour BETWEEN 0 AND 23)
+);
+
+CREATE TABLE IF NOT EXISTS daily_lessons (
+                                             id BIGSERIAL PRIMARY KEY,
+                                             student_id INT NOT NULL,
+                                             lesson_date DATE NOT NULL,
+                                             timezone VARCHAR(64) NOT NULL,
+                                             daily_goal INT NOT NULL,
+                                             word_of_the_day_id INT NULL REFERENCES words(id) ON DELETE SET NULL,
+                                             created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                             CONSTRAINT ux_daily_lessons_student_date UNIQUE (student_id, lesson_date)
+);
+
+CREATE TABLE IF NOT EXISTS daily_lesson_items (
+                                                  lesson_id BIGINT NOT NULL REFERENCES daily_lessons(id) ON DELETE CASCADE,
+                                                  position INT NOT NULL,
+                                                  kind VARCHAR(10) NOT NULL,
+                                                  word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                  options TEXT[] NULL,
+                                                  answer_index INT NULL,
+                                                  PRIMARY KEY (lesson_id, position),
+                                                  CONSTRAINT daily_lesson_items_kind_check CHECK (kind IN ('review', 'new', 'quiz'))
+);
+
+COMMENT ON COLUMN public.student_lesson_settings.timezone IS 'IANA time zone the lesson day is counted in';
+COMMENT ON COLUMN public.student_lesson_settings.daily_goal IS 'How many exercises the daily lesson has at most';
+COMMENT ON COLUMN public.student_lesson_settings.new_words IS 'How many new words the lesson introduces at most';
+COMMENT ON COLUMN public.student_lesson_settings.lesson_hour IS 'Local hour after which the lesson for the day is generated';
+COMMENT ON COLUMN public.student_lesson_settings.decks IS 'Decks assigned by the tutor to take new words from; empty - any deck';
+COMMENT ON COLUMN public.daily_lessons.lesson_date IS 'Day of the lesson in the student time zone';
+COMMENT ON COLUMN public.daily_lessons.word_of_the_day_id IS 'Frequent word the student does not have yet';
+COMMENT ON COLUMN public.daily_lesson_items.kind IS 'review - due word, new - word seen for the first time, quiz - multiple choice question';
+COMMENT ON COLUMN public.daily_lesson_items.options IS 'Translations to choose from in a quiz question';
+COMMENT ON COLUMN public.daily_lesson_items.answer_index IS 'Index of the correct translation in options';
@@ -0,0 +1,41 @@
+-- Внешние ключи и допустимые значения статусов основных таблиц.
+-- Перед созданием ключей удаляем связи со словами и примерами, которых уже нет.
+
+DELETE FROM student_words sw WHERE NOT EXISTS (SELECT 1 FROM words w WHERE w.id = sw.word_id);
+DELETE FROM word_links wl
+WHERE NOT EXISTS (SELECT 1 FROM words w WHERE w.id = wl.word_id)
+   OR NOT EXISTS (SELECT 1 FROM words w WHERE w.id = wl.linked_word_id);
+DELETE FROM word_example we
+WHERE NOT EXISTS (SELECT 1 FROM words w WHERE w.id = we.word_id)
+   OR NOT EXISTS (SELECT 1 FROM examples e WHERE e.id = we.example_id);
+
+ALTER TABLE student_words DROP CONSTRAINT IF EXISTS student_words_word_id_fkey;
+ALTER TABLE student_words ADD CONSTRAINT student_words_word_id_fkey
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE;
+
+ALTER TABLE word_links DROP CONSTRAINT IF EXISTS word_links_word_id_fkey;
+ALTER TABLE word_links ADD CONSTRAINT word_links_word_id_fkey
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE;
+ALTER TABLE word_links DROP CONSTRAINT IF EXISTS word_links_linked_word_id_fkey;
+ALTER TABLE word_links ADD CONSTRAINT word_links_linked_word_id_fkey
+    FOREIGN KEY (linked_word_id) REFERENCES words(id) ON DELETE CASCADE;
+
+ALTER TABLE word_example DROP CONSTRAINT IF EXISTS word_example_word_id_fkey;
+ALTER TABLE word_example ADD CONSTRAINT word_example_word_id_fkey
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE;
+ALTER TABLE word_example DROP CONSTRAINT IF EXISTS word_example_example_id_fkey;
+ALTER TABLE word_example ADD CONSTRAINT word_example_example_id_fkey
+    FOREIGN KEY (example_id) REFERENCES examples(id) ON DELETE CASCADE;
+
+-- Статусы
+ALTER TABLE words DROP CONSTRAINT IF EXISTS words_status_check;
+ALTER TABLE words ADD CONSTRAINT words_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+
+ALTER TABLE student_words DROP CONSTRAINT IF EXISTS student_words_status_check;
+ALTER TABLE student_words ADD CONSTRAINT student_words_status_check
+    CHECK (status IN ('need to learn', 'learned'));
+
+-- Поиск слов ученика по слову и примеров слова по примеру
+CREATE INDEX IF NOT EXISTS idx_student_words_word ON student_words (word_id);
+CREATE INDEX IF NOT EXISTS idx_word_example_example ON word_example (example_id);
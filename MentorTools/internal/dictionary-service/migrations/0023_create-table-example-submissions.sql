@@ -0,0 +1,38 @@
+-- Примеры, которые ученики пишут сами: проверка GPT и отзыв репетитора
+
+-- Кто написал пример: GPT, текст для чтения ученика или сам ученик
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS source VARCHAR(10) NOT NULL DEFAULT 'gpt'
+    CHECK (source IN ('gpt', 'reading', 'student'));
+UPDATE examples SET source = 'reading' WHERE student_id IS NOT NULL AND source = 'gpt';
+
+CREATE TABLE IF NOT EXISTS example_submissions (
+                                                   id BIGSERIAL PRIMARY KEY,
+                                                   student_id INT NOT NULL,
+                                                   word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                   sentence TEXT NOT NULL,
+                                                   accepted BOOLEAN NOT NULL,
+                                                   grammar_correct BOOLEAN NOT NULL,
+                                                   usage_correct BOOLEAN NOT NULL,
+                                                   corrected TEXT NULL,
+                                                   explanation TEXT NULL,
+                                                   translation TEXT NULL,
+                                                   example_id INT NULL REFERENCES examples(id) ON DELETE SET NULL,
+                                                   review_status VARCHAR(10) NOT NULL DEFAULT 'pending',
+                                                   review_comment TEXT NULL,
+                                                   reviewed_by INT NULL,
+                                                   reviewed_at TIMESTAMP NULL,
+                                                   created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                                   CONSTRAINT example_submissions_review_check CHECK (review_status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_example_submissions_student ON example_submissions (student_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_example_submissions_pending ON example_submissions (created_at) WHERE review_status = 'pending';
+
+COMMENT ON COLUMN public.examples.source IS 'Who wrote the example: gpt, reading (sentence from a student text) or student';
+COMMENT ON COLUMN public.example_submissions.student_id IS 'Student who wrote the sentence';
+COMMENT ON COLUMN public.example_submissions.sentence IS 'Sentence as written by the student';
+COMMENT ON COLUMN public.example_submissions.accepted IS 'Whether GPT found the grammar and the usage of the word correct';
+COMMENT ON COLUMN public.example_submissions.corrected IS 'Corrected sentence suggested by GPT';
+COMMENT ON COLUMN public.example_submissions.explanation IS 'GPT explanation of the mistakes in the language of explanations';
+COMMENT ON COLUMN public.example_submissions.example_id IS 'Example created from the submission; NULL while the sentence is not accepted';
+COMMENT ON COLUMN public.example_submissions.review_status IS 'Tutor review: pending, approved (shown to all students) or rejected (hidden)';
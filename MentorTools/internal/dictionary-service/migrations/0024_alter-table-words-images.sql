@@ -0,0 +1,14 @@
+-- Картинки слов для карточек: загружает репетитор или генерирует провайдер картинок.
+-- Файлы лежат в хранилище media под ключами, выведенными из содержимого.
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS image_key VARCHAR(255) NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS image_thumb_key VARCHAR(255) NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS image_source VARCHAR(10) NULL
+    CHECK (image_source IN ('upload', 'generated'));
+ALTER TABLE words ADD COLUMN IF NOT EXISTS image_updated_by INT NULL;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS image_updated_at TIMESTAMP NULL;
+
+COMMENT ON COLUMN public.words.image_key IS 'Storage key of the word image; NULL if the word has no image';
+COMMENT ON COLUMN public.words.image_thumb_key IS 'Storage key of the JPEG thumbnail of the image';
+COMMENT ON COLUMN public.words.image_source IS 'Where the image came from: upload (by a tutor) or generated';
+COMMENT ON COLUMN public.words.image_updated_by IS 'Tutor who uploaded or generated the image';
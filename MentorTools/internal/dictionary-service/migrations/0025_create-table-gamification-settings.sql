@@ -0,0 +1,10 @@
+-- Приватность в таблице лидеров. Очки опыта не хранятся: они считаются по событиям обучения.
+-- Если записи нет, ученик показывается в таблице лидеров учеников своего репетитора.
+
+CREATE TABLE IF NOT EXISTS student_gamification_settings (
+                                                             student_id INT PRIMARY KEY,
+                                                             show_on_leaderboard BOOLEAN NOT NULL DEFAULT true,
+                                                             updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN public.student_gamification_settings.show_on_leaderboard IS 'Whether other students of the tutor see the student on the leaderboard';
@@ -0,0 +1,34 @@
+-- Ошибки учеников в ответах и упражнения на различение слов, которые ученики путают
+
+CREATE TABLE IF NOT EXISTS answer_mistakes (
+                                               id BIGSERIAL PRIMARY KEY,
+                                               student_id INT NOT NULL,
+                                               word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                               event_type VARCHAR(20) NOT NULL,
+                                               answer TEXT NULL,
+                                               confused_word_id INT NULL REFERENCES words(id) ON DELETE SET NULL,
+                                               created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                               CONSTRAINT answer_mistakes_confused_check CHECK (confused_word_id <> word_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_answer_mistakes_student ON answer_mistakes (student_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_answer_mistakes_confused ON answer_mistakes (student_id, word_id, confused_word_id)
+    WHERE confused_word_id IS NOT NULL;
+
+-- Упражнение общее для пары слов, поэтому пара хранится упорядоченной: word_id < other_word_id
+CREATE TABLE IF NOT EXISTS contrastive_exercises (
+                                                     id BIGSERIAL PRIMARY KEY,
+                                                     word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                     other_word_id INT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+                                                     explanation TEXT NOT NULL,
+                                                     items JSONB NOT NULL,
+                                                     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                                     CONSTRAINT contrastive_exercises_pair_check CHECK (word_id < other_word_id),
+                                                     CONSTRAINT ux_contrastive_exercises_pair UNIQUE (word_id, other_word_id)
+);
+
+COMMENT ON COLUMN public.answer_mistakes.event_type IS 'Exercise of the answer: reviewed, quiz_answered or typed';
+COMMENT ON COLUMN public.answer_mistakes.answer IS 'What the student answered: the chosen quiz option or the typed word';
+COMMENT ON COLUMN public.answer_mistakes.confused_word_id IS 'Word of the same language pair the student confused the word with; NULL if the answer matches no word';
+COMMENT ON COLUMN public.contrastive_exercises.explanation IS 'GPT explanation of the difference between the two words';
+COMMENT ON COLUMN public.contrastive_exercises.items IS 'Minimal-pair gap-fill sentences written by GPT';
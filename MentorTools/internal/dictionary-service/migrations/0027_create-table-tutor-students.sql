@@ -0,0 +1,19 @@
+-- Привязки учеников к репетиторам. Источник - user_links и users в базе auth_db (user-service);
+-- в базу словаря их копирует worker.LinkSyncWorker, чтобы проверять доступ и строить таблицы лидеров
+-- без запросов в чужую базу.
+
+CREATE TABLE IF NOT EXISTS tutor_students (
+                                              tutor_id INT NOT NULL,
+                                              student_id INT NOT NULL,
+                                              student_name VARCHAR(255) NOT NULL DEFAULT '',
+                                              linked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                              synced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                              PRIMARY KEY (tutor_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tutor_students_student ON tutor_students (student_id, linked_at);
+
+COMMENT ON TABLE public.tutor_students IS 'Copy of auth_db user_links kept in sync by the dictionary service';
+COMMENT ON COLUMN public.tutor_students.student_name IS 'Student display name (users.name in auth_db)';
+COMMENT ON COLUMN public.tutor_students.linked_at IS 'When the tutor linked the student (user_links.created_at)';
+COMMENT ON COLUMN public.tutor_students.synced_at IS 'Last sync that saw the link';
@@ -0,0 +1,11 @@
+-- Уровень слов, которых нет в частотном списке, определяет GPT в фоне (worker уровней), а не при добавлении слова.
+-- Неудачные попытки считаются, чтобы слово с постоянной ошибкой не занимало очередь бесконечно.
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS level_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS level_error TEXT NULL;
+
+-- Очередь определения уровня: заполненные слова без уровня
+CREATE INDEX IF NOT EXISTS idx_words_level_queue ON words (level_attempts, id) WHERE cefr_level IS NULL AND status = 'completed';
+
+COMMENT ON COLUMN public.words.level_attempts IS 'Failed attempts to resolve the CEFR level';
+COMMENT ON COLUMN public.words.level_error IS 'Error of the last failed attempt to resolve the CEFR level';
@@ -0,0 +1,7 @@
+-- Перевод из файла импорта хранится у слова ученика, а не в общем словаре: до обогащения его видит
+-- только ученик, который импортировал слово, а не все ученики, у которых есть это слово.
+-- Переводы, которые импорт уже записал в words.translation, остаются: их перезапишет обогащение.
+
+ALTER TABLE student_words ADD COLUMN IF NOT EXISTS translation_hint TEXT NULL;
+
+COMMENT ON COLUMN public.student_words.translation_hint IS 'Translation from the import file, shown to the student until the word has a reviewed translation';
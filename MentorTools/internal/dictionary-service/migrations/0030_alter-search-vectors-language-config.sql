@@ -0,0 +1,61 @@
+-- Полнотекстовый поиск с конфигурацией языка текста вместо 'russian' для всех пар:
+-- слово и текст примера разбираются конфигурацией языка слова (source_lang), перевод и описание -
+-- конфигурацией языка перевода (target_lang). Для языков без стеммера в PostgreSQL используется 'simple'.
+-- Запрос поиска строится конфигурациями обоих языков пары (repository/search_repository.go).
+
+CREATE OR REPLACE FUNCTION search_config(lang TEXT) RETURNS regconfig
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE lang
+        WHEN 'en' THEN 'english'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'de' THEN 'german'
+        WHEN 'fr' THEN 'french'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'it' THEN 'italian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END::regconfig
+$$;
+
+-- Языковая пара примера: пример принадлежит слову, и разбирать его нужно языком этого слова
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS source_lang VARCHAR(5) NOT NULL DEFAULT 'en';
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS target_lang VARCHAR(5) NOT NULL DEFAULT 'ru';
+
+UPDATE examples e
+SET source_lang = w.source_lang, target_lang = w.target_lang
+FROM word_example we
+JOIN words w ON w.id = we.word_id
+WHERE we.example_id = e.id
+  AND (e.source_lang, e.target_lang) IS DISTINCT FROM (w.source_lang, w.target_lang);
+
+-- Выражение вычисляемого столбца не меняется на месте: столбец и его индекс создаются заново
+DROP INDEX IF EXISTS idx_words_search_vector;
+DROP INDEX IF EXISTS idx_examples_search_vector;
+ALTER TABLE words DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE examples DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE words ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_config(source_lang), coalesce(word, '')), 'A') ||
+        setweight(to_tsvector(search_config(target_lang), coalesce(translation, '')), 'A') ||
+        setweight(to_tsvector(search_config(target_lang), coalesce(definition, '')), 'C')
+    ) STORED;
+
+ALTER TABLE examples ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_config(source_lang), coalesce(example, '')), 'B') ||
+        setweight(to_tsvector(search_config(target_lang), coalesce(translation, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_words_search_vector ON words USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_examples_search_vector ON examples USING GIN (search_vector);
+
+COMMENT ON FUNCTION search_config(TEXT) IS 'Text search configuration for an ISO 639-1 language code, simple for languages without a stemmer';
+COMMENT ON COLUMN public.examples.source_lang IS 'Language of the example sentence, ISO 639-1 code';
+COMMENT ON COLUMN public.examples.target_lang IS 'Language of the example translation, ISO 639-1 code';
+COMMENT ON COLUMN public.words.search_vector IS 'Full-text search vector over word, translation and definition';
+COMMENT ON COLUMN public.examples.search_vector IS 'Full-text search vector over example sentence and its translation';
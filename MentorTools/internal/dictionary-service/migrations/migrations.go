@@ -0,0 +1,276 @@
+// Package migrations хранит схему базы словаря в виде пронумерованных SQL-миграций и применяет их.
+//
+// Файл миграции называется NNNN_описание.sql, где NNNN - версия. Применённые миграции записываются
+// в schema_migrations вместе с контрольной суммой; применённый файл менять нельзя - изменения схемы
+// оформляются новой миграцией.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockID - ключ advisory lock, чтобы несколько экземпляров сервиса не применяли миграции одновременно
+const lockID = 7305914261
+
+// ErrUnversionedSchema возвращается, если в базе уже есть таблицы словаря, созданные вручную,
+// но нет истории миграций. Такую базу нужно один раз отметить командой migrate -baseline.
+var ErrUnversionedSchema = errors.New("database has dictionary tables but no migration history, run migrate -baseline")
+
+// Migration - одна миграция схемы
+type Migration struct {
+	Version  int
+	Name     string
+	SQL      string
+	Checksum string
+}
+
+// Status - миграция и время её применения; AppliedAt = nil, если миграция ещё не применена
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Load возвращает встроенные миграции по возрастанию версии
+func Load() ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	seen := make(map[int]string)
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a version number", name)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, name)
+		}
+		seen[version] = name
+
+		content, err := files.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     strings.TrimSuffix(name, ".sql"),
+			SQL:      string(content),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Apply применяет миграции, которых ещё нет в schema_migrations, каждую в своей транзакции.
+// Возвращает применённые миграции. Если применённая ранее миграция была изменена, ничего не применяет.
+func Apply(ctx context.Context, dbpool *pgxpool.Pool) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	err = withLock(ctx, dbpool, func(conn *pgxpool.Conn) error {
+		history, err := appliedChecksums(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(history) == 0 {
+			exists, err := tableExists(ctx, conn, "words")
+			if err != nil {
+				return err
+			}
+			if exists {
+				return ErrUnversionedSchema
+			}
+		}
+
+		for _, migration := range migrations {
+			checksum, ok := history[migration.Version]
+			if ok && checksum != migration.Checksum {
+				return fmt.Errorf("migration %s was changed after it had been applied", migration.Name)
+			}
+		}
+
+		for _, migration := range migrations {
+			if _, ok := history[migration.Version]; ok {
+				continue
+			}
+			if err := applyOne(ctx, conn, migration); err != nil {
+				return fmt.Errorf("migration %s: %w", migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Baseline отмечает миграции до версии version включительно как применённые, не выполняя их.
+// Нужна для базы, схема которой была создана вручную до появления миграций.
+func Baseline(ctx context.Context, dbpool *pgxpool.Pool, version int) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var marked []Migration
+	err = withLock(ctx, dbpool, func(conn *pgxpool.Conn) error {
+		history, err := appliedChecksums(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := history[migration.Version]; ok {
+				continue
+			}
+			_, err := conn.Exec(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, migration.Checksum)
+			if err != nil {
+				return err
+			}
+			marked = append(marked, migration)
+		}
+		return nil
+	})
+	return marked, err
+}
+
+// GetStatus возвращает все встроенные миграции и время применения каждой
+func GetStatus(ctx context.Context, dbpool *pgxpool.Pool) ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	err = withLock(ctx, dbpool, func(conn *pgxpool.Conn) error {
+		rows, err := conn.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		appliedAt := make(map[int]time.Time)
+		for rows.Next() {
+			var version int
+			var at time.Time
+			if err := rows.Scan(&version, &at); err != nil {
+				return err
+			}
+			appliedAt[version] = at
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if at, ok := appliedAt[migration.Version]; ok {
+				status.AppliedAt = &at
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock выполняет fn на отдельном соединении под advisory lock, создав таблицу истории миграций
+func withLock(ctx context.Context, dbpool *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := dbpool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", int64(lockID)); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", int64(lockID))
+
+	_, err = conn.Exec(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version INT PRIMARY KEY,
+            name VARCHAR(255) NOT NULL,
+            checksum CHAR(64) NOT NULL,
+            applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )`)
+	if err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// appliedChecksums возвращает контрольные суммы применённых миграций по версиям
+func appliedChecksums(ctx context.Context, conn *pgxpool.Conn) (map[int]string, error) {
+	rows, err := conn.Query(ctx, "SELECT version, checksum FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make(map[int]string)
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		history[version] = checksum
+	}
+	return history, rows.Err()
+}
+
+// tableExists проверяет, есть ли таблица в схеме public
+func tableExists(ctx context.Context, conn *pgxpool.Conn, table string) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT to_regclass('public.' || $1) IS NOT NULL", table).Scan(&exists)
+	return exists, err
+}
+
+// applyOne выполняет миграцию и записывает её в историю в одной транзакции
+func applyOne(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Без параметров запрос уходит простым протоколом, поэтому файл может содержать несколько команд
+	if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+		migration.Version, migration.Name, migration.Checksum)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
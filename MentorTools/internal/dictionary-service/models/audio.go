@@ -0,0 +1,15 @@
+package models
+
+// Что озвучивается
+const (
+	AudioWord    = "word"    // Произношение слова
+	AudioExample = "example" // Предложение примера
+)
+
+// AudioTask - слово или пример, для которых ещё нет аудио
+type AudioTask struct {
+	Kind     string // AudioWord или AudioExample
+	ID       int    // ID слова или примера
+	Text     string
+	Language string // Язык текста, код ISO 639-1
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ConfusionPair - два слова, которые ученик путает, и сколько раз он ошибся между ними
+type ConfusionPair struct {
+	WordID        int       `json:"wordId"`
+	Word          string    `json:"word"`
+	OtherWordID   int       `json:"otherWordId"`
+	OtherWord     string    `json:"otherWord"`
+	Mistakes      int       `json:"mistakes"`      // Ошибки в обе стороны
+	LastMistakeAt time.Time `json:"lastMistakeAt"` // Время последней ошибки
+}
+
+// ContrastiveItem - предложение с пропуском, в которое подходит только одно слово пары
+type ContrastiveItem struct {
+	Sentence    string `json:"sentence"` // Предложение с пропуском "___"
+	Answer      string `json:"answer"`   // Слово пары, которое нужно вставить
+	Translation string `json:"translation"`
+}
+
+// ContrastiveExercise - упражнение на различение двух слов, которые ученик путает
+type ContrastiveExercise struct {
+	ID          int64             `json:"id"`
+	WordID      int               `json:"wordId"`
+	Word        string            `json:"word"`
+	OtherWordID int               `json:"otherWordId"`
+	OtherWord   string            `json:"otherWord"`
+	Explanation string            `json:"explanation"` // Чем слова различаются
+	Items       []ContrastiveItem `json:"items"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	gpt "MentorTools/gpt-service/services"
+	"time"
+)
+
+// ContentGeneration - сведения о генерации контента, которые сохраняются вместе с версией
+type ContentGeneration struct {
+	Model         string
+	PromptVersion int
+	CreatedBy     *int // Репетитор, запросивший перегенерацию; nil - автоматическая генерация
+}
+
+// ContentVersion - версия контента слова, сгенерированного GPT
+type ContentVersion struct {
+	ID            int             `json:"id"`
+	WordID        int             `json:"wordId"`
+	Version       int             `json:"version"`
+	Model         string          `json:"model"`
+	PromptVersion int             `json:"promptVersion"`
+	CreatedBy     *int            `json:"createdBy"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Active        bool            `json:"active"`
+	Content       gpt.WordDetails `json:"content"`
+}
+
+// VersionComparison - различия между двумя версиями контента слова
+type VersionComparison struct {
+	From            ContentVersion `json:"from"`
+	To              ContentVersion `json:"to"`
+	ChangedFields   []string       `json:"changedFields"`
+	SynonymsAdded   []string       `json:"synonymsAdded"`
+	SynonymsRemoved []string       `json:"synonymsRemoved"`
+	ExamplesAdded   []string       `json:"examplesAdded"`
+	ExamplesRemoved []string       `json:"examplesRemoved"`
+}
+
+// RegenerationReport - итог пакетной перегенерации контента
+type RegenerationReport struct {
+	Regenerated int      `json:"regenerated"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+}
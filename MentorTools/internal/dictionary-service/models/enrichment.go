@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// EnrichmentStats - состояние очереди фонового обогащения слов
+type EnrichmentStats struct {
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+
+	// Счётчики текущего процесса worker-а
+	Enriched  int64      `json:"enriched"`
+	Errors    int64      `json:"errors"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Кто написал пример
+const (
+	ExampleSourceGPT     = "gpt"
+	ExampleSourceReading = "reading" // Предложение из текста, который читал ученик
+	ExampleSourceStudent = "student" // Предложение, которое ученик написал сам
+)
+
+// Отзыв репетитора о примере ученика
+const (
+	SubmissionPending  = "pending"
+	SubmissionApproved = "approved" // Пример виден всем ученикам
+	SubmissionRejected = "rejected" // Пример скрыт
+)
+
+// ExampleSubmissionRequest - тело запроса на отправку собственного примера к слову
+type ExampleSubmissionRequest struct {
+	WordID   int    `json:"wordId"`
+	Sentence string `json:"sentence"`
+}
+
+// SubmissionReviewRequest - тело запроса на отзыв репетитора о примере ученика
+type SubmissionReviewRequest struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment"`
+}
+
+// ExampleSubmission - пример ученика с проверкой GPT и отзывом репетитора.
+// Пример, который GPT признал правильным, сразу сохраняется в словарь и виден автору (ExampleID);
+// после одобрения репетитором он виден всем ученикам.
+type ExampleSubmission struct {
+	ID             int64      `json:"id"`
+	StudentID      int        `json:"studentId"`
+	WordID         int        `json:"wordId"`
+	Word           string     `json:"word"`
+	Sentence       string     `json:"sentence"`
+	Accepted       bool       `json:"accepted"`
+	GrammarCorrect bool       `json:"grammarCorrect"`
+	UsageCorrect   bool       `json:"usageCorrect"`
+	Corrected      string     `json:"corrected,omitempty"`   // Исправленное предложение, если GPT нашёл ошибки
+	Explanation    string     `json:"explanation,omitempty"` // Объяснение ошибок на языке объяснений
+	Translation    string     `json:"translation,omitempty"`
+	ExampleID      *int       `json:"exampleId,omitempty"`
+	ReviewStatus   string     `json:"reviewStatus"`
+	ReviewComment  string     `json:"reviewComment,omitempty"`
+	ReviewedBy     *int       `json:"reviewedBy,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
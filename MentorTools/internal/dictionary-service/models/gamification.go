@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Показатели, за которые выдаются значки
+const (
+	BadgeMetricXP      = "xp"      // Очки опыта за всё время
+	BadgeMetricLearned = "learned" // Выученные слова
+	BadgeMetricStreak  = "streak"  // Самая длинная серия дней с занятиями
+)
+
+// XP - очки опыта по источникам
+type XP struct {
+	Review int `json:"review"` // За повторение и тренировку написания
+	Quiz   int `json:"quiz"`   // За ответы в тестах
+	Streak int `json:"streak"` // За дни серии занятий
+	Total  int `json:"total"`
+}
+
+// Badge - значок за достижение и прогресс ученика к нему
+type Badge struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Metric   string `json:"metric"` // Один из BadgeMetric*
+	Goal     int    `json:"goal"`
+	Progress int    `json:"progress"` // Не больше Goal
+	Earned   bool   `json:"earned"`
+}
+
+// XPSummary - очки опыта ученика, текущая серия и значки
+type XPSummary struct {
+	AllTime       XP      `json:"allTime"`
+	Week          XP      `json:"week"` // За текущую неделю
+	CurrentStreak int     `json:"currentStreak"`
+	Badges        []Badge `json:"badges"`
+}
+
+// GamificationSettings - настройки приватности ученика
+type GamificationSettings struct {
+	ShowOnLeaderboard bool `json:"showOnLeaderboard"` // false - другие ученики не видят ученика в таблице лидеров
+}
+
+// LeaderboardMember - ученик репетитора и его настройка приватности
+type LeaderboardMember struct {
+	StudentID         int
+	Name              string
+	ShowOnLeaderboard bool
+}
+
+// LeaderboardEntry - строка таблицы лидеров
+type LeaderboardEntry struct {
+	Rank      int    `json:"rank"` // У учеников с равными очками одинаковое место
+	StudentID int    `json:"studentId"`
+	Name      string `json:"name"`
+	XP        XP     `json:"xp"`
+	IsMe      bool   `json:"isMe,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"` // Ученик скрыт от других учеников; видят только он сам и репетитор
+}
+
+// Leaderboard - недельная таблица лидеров учеников репетитора
+type Leaderboard struct {
+	TutorID   int                `json:"tutorId"`
+	WeekStart time.Time          `json:"weekStart"`
+	WeekEnd   time.Time          `json:"weekEnd"`
+	Entries   []LeaderboardEntry `json:"entries"`
+}
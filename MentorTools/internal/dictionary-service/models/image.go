@@ -0,0 +1,17 @@
+package models
+
+// Откуда у слова картинка
+const (
+	ImageSourceUpload    = "upload"    // Загрузил репетитор
+	ImageSourceGenerated = "generated" // Сгенерировал провайдер картинок
+)
+
+// WordImage - картинка слова и её миниатюра в хранилище
+type WordImage struct {
+	WordID       int    `json:"wordId"`
+	Source       string `json:"source"`
+	Key          string `json:"-"`
+	ThumbnailKey string `json:"-"`
+	URL          string `json:"imageUrl"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+}
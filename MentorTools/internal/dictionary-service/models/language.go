@@ -0,0 +1,10 @@
+package models
+
+// LanguagePair - язык изучаемых слов и язык перевода и объяснений (коды ISO 639-1)
+type LanguagePair struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// DefaultLanguagePair - пара по умолчанию: английские слова с объяснениями на русском
+var DefaultLanguagePair = LanguagePair{Source: "en", Target: "ru"}
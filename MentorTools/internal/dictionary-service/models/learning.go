@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Типы событий обучения
+const (
+	EventAdded         = "added"          // Слово добавлено ученику
+	EventReviewed      = "reviewed"       // Ученик повторил слово
+	EventStatusChanged = "status_changed" // Изменился статус слова у ученика
+	EventQuizAnswered  = "quiz_answered"  // Ученик ответил на вопрос теста
+	EventTyped         = "typed"          // Ученик набрал слово в тренировке написания
+)
+
+// Статусы слова у ученика
+const (
+	WordStatusNeedToLearn = "need to learn"
+	WordStatusLearned     = "learned"
+)
+
+// LearningEvent - событие обучения ученика
+type LearningEvent struct {
+	StudentID int
+	WordID    int
+	Type      string
+	Correct   *bool
+	OldStatus string
+	NewStatus string
+}
+
+// AnswerRequest - тело запроса с ответом ученика при повторении или в тесте.
+// Для неправильного ответа по Answer или ChosenWordID определяется слово, с которым ученик перепутал WordID.
+type AnswerRequest struct {
+	WordID       int    `json:"wordId"`
+	Correct      bool   `json:"correct"`
+	Answer       string `json:"answer,omitempty"`       // Что ответил ученик: выбранный вариант теста или набранное слово
+	ChosenWordID int    `json:"chosenWordId,omitempty"` // Слово, которое ученик выбрал вместо WordID, если клиент его знает
+}
+
+// AnswerResult - состояние слова после ответа
+type AnswerResult struct {
+	WordID             int       `json:"wordId"`
+	Status             string    `json:"status"`
+	CorrectStreak      int       `json:"correctStreak"`
+	ReviewIntervalDays int       `json:"reviewIntervalDays"`
+	NextReviewAt       time.Time `json:"nextReviewAt"`
+}
+
+// LearningStats - сводная статистика ученика
+type LearningStats struct {
+	TotalWords           int      `json:"totalWords"`
+	LearnedWords         int      `json:"learnedWords"`
+	DueWords             int      `json:"dueWords"`
+	CurrentStreak        int      `json:"currentStreak"`        // Дней подряд с занятиями, включая сегодня или вчера
+	LongestStreak        int      `json:"longestStreak"`        // Самая длинная серия дней с занятиями
+	RetentionRate        *float64 `json:"retentionRate"`        // Доля правильных ответов за последние 30 дней; nil, если ответов не было
+	AvgHoursToMastery    *float64 `json:"avgHoursToMastery"`    // Среднее время от добавления слова до статуса "learned"
+	MedianHoursToMastery *float64 `json:"medianHoursToMastery"` // Медиана того же времени
+}
+
+// ActivityPoint - количество выученных и повторённых слов за день или неделю
+type ActivityPoint struct {
+	Period   time.Time `json:"period"` // Начало дня или недели
+	Learned  int       `json:"learned"`
+	Reviewed int       `json:"reviewed"`
+	Added    int       `json:"added"`
+}
+
+// TopicStats - прогресс ученика по теме
+type TopicStats struct {
+	TopicID int    `json:"topicId"`
+	Topic   string `json:"topic"`
+	Words   int    `json:"words"`
+	Learned int    `json:"learned"`
+}
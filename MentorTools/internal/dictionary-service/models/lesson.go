@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Виды заданий ежедневного урока
+const (
+	LessonItemReview = "review" // Повторение слова, которое пора повторить
+	LessonItemNew    = "new"    // Новое слово из колод, назначенных репетитором
+	LessonItemQuiz   = "quiz"   // Вопрос теста: выбрать перевод слова
+)
+
+// LessonSettings - настройки ежедневного урока ученика
+type LessonSettings struct {
+	Timezone   string   `json:"timezone"`   // Часовой пояс IANA, в котором считается день урока
+	DailyGoal  int      `json:"dailyGoal"`  // Сколько заданий в уроке не больше
+	NewWords   int      `json:"newWords"`   // Сколько новых слов в уроке не больше
+	LessonHour int      `json:"lessonHour"` // Час по местному времени, после которого готовится урок на день
+	Decks      []string `json:"decks"`      // Колоды, из которых берутся новые слова; пусто - любые
+}
+
+// DefaultLessonSettings - настройки ученика, который их не менял
+var DefaultLessonSettings = LessonSettings{
+	Timezone:   "UTC",
+	DailyGoal:  20,
+	NewWords:   5,
+	LessonHour: 6,
+	Decks:      []string{},
+}
+
+// LessonSettingsUpdate - тело запроса на изменение настроек урока; незаполненные поля не меняются.
+// Колоды назначает только репетитор.
+type LessonSettingsUpdate struct {
+	Timezone   *string   `json:"timezone"`
+	DailyGoal  *int      `json:"dailyGoal"`
+	NewWords   *int      `json:"newWords"`
+	LessonHour *int      `json:"lessonHour"`
+	Decks      *[]string `json:"decks"`
+}
+
+// LessonPlanItem - задание, выбранное при составлении урока
+type LessonPlanItem struct {
+	Kind        string
+	WordID      int
+	Options     []string // Варианты перевода для вопроса теста
+	AnswerIndex int      // Индекс правильного перевода в Options
+}
+
+// LessonPlan - урок, составленный на день перед сохранением
+type LessonPlan struct {
+	StudentID      int
+	Date           time.Time // Полночь дня урока
+	Timezone       string
+	DailyGoal      int
+	WordOfTheDayID *int
+	Items          []LessonPlanItem
+}
+
+// LessonWord - слово в уроке
+type LessonWord struct {
+	ID            int    `json:"id"`
+	Word          string `json:"word"`
+	Transcription string `json:"transcription"`
+	Translation   string `json:"translation"`
+	AudioKey      string `json:"-"`
+	AudioURL      string `json:"audioUrl,omitempty"`
+}
+
+// LessonQuiz - вопрос теста с вариантами перевода; ответ отправляется в /quiz/answers
+type LessonQuiz struct {
+	Options     []string `json:"options"`
+	AnswerIndex int      `json:"answerIndex"`
+}
+
+// LessonItem - задание урока
+type LessonItem struct {
+	Kind string      `json:"kind"`
+	Word LessonWord  `json:"word"`
+	Quiz *LessonQuiz `json:"quiz,omitempty"`
+	Done bool        `json:"done"` // Ученик уже ответил на задание после начала урока
+}
+
+// DailyLesson - урок ученика на день и его прогресс
+type DailyLesson struct {
+	ID           int          `json:"id"`
+	Date         string       `json:"date"` // День урока в часовом поясе ученика, YYYY-MM-DD
+	Timezone     string       `json:"timezone"`
+	DailyGoal    int          `json:"dailyGoal"`
+	WordOfTheDay *LessonWord  `json:"wordOfTheDay,omitempty"`
+	Items        []LessonItem `json:"items"`
+	Done         int          `json:"done"`
+	CreatedAt    time.Time    `json:"createdAt"`
+}
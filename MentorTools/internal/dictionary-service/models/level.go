@@ -0,0 +1,54 @@
+package models
+
+// CEFRLevels - уровни CEFR от простого к сложному
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// IsCEFRLevel проверяет, что строка - один из уровней CEFR
+func IsCEFRLevel(level string) bool {
+	for _, known := range CEFRLevels {
+		if level == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Источники уровня слова
+const (
+	LevelSourceList = "list" // Частотный список языка
+	LevelSourceGPT  = "gpt"  // Оценка GPT для слов, которых нет в списке
+)
+
+// WordLevel - уровень сложности слова
+type WordLevel struct {
+	Level         string // Уровень CEFR
+	FrequencyRank *int   // Ранг в частотном списке; nil, если слова в списке нет
+	Source        string // Один из LevelSource*
+}
+
+// UntaggedWord - заполненное слово, которому ещё не присвоен уровень
+type UntaggedWord struct {
+	ID        int
+	Word      string
+	Languages LanguagePair
+}
+
+// LevelTaggingReport - итог присвоения уровней словам без уровня
+type LevelTaggingReport struct {
+	Tagged int      `json:"tagged"`
+	Failed int      `json:"failed"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// LevelStats - слова ученика одного уровня CEFR или одной частотной полосы
+type LevelStats struct {
+	Level        string `json:"level"` // Уровень, частотная полоса или "unrated" для слов без уровня
+	TotalWords   int    `json:"totalWords"`
+	LearnedWords int    `json:"learnedWords"`
+}
+
+// VocabularyProfile - распределение словаря ученика по уровням CEFR и частотным полосам
+type VocabularyProfile struct {
+	Levels []LevelStats `json:"levels"`
+	Bands  []LevelStats `json:"bands"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Объекты, которые правит репетитор
+const (
+	EditTargetWord    = "word"
+	EditTargetExample = "example"
+	EditTargetSynonym = "synonym"
+)
+
+// ContentEdit - правка контента слова в истории
+type ContentEdit struct {
+	Version   int       `json:"version"`
+	Target    string    `json:"target"`
+	TargetID  int       `json:"targetId,omitempty"`
+	Field     string    `json:"field"`
+	OldValue  *string   `json:"oldValue"`
+	NewValue  *string   `json:"newValue"`
+	AuthorID  int       `json:"authorId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WordContentRequest - тело запроса на исправление слова; незаданные поля не меняются
+type WordContentRequest struct {
+	Transcription *string `json:"transcription"`
+	Translation   *string `json:"translation"`
+	Description   *string `json:"description"`
+}
+
+// ExampleContentRequest - тело запроса на исправление или отклонение примера
+type ExampleContentRequest struct {
+	Sentence    *string `json:"sentence"`
+	Translation *string `json:"translation"`
+	Hidden      *bool   `json:"hidden"`
+}
+
+// SynonymRequest - тело запроса на скрытие или возврат синонима
+type SynonymRequest struct {
+	Synonym string `json:"synonym"`
+	Hidden  bool   `json:"hidden"`
+}
+
+// ModerationSettings - настройки модерации
+type ModerationSettings struct {
+	ReviewGeneratedContent bool `json:"reviewGeneratedContent"` // Новый контент от GPT ждёт проверки репетитором
+}
+
+// QueuedWord - слово в очереди проверки
+type QueuedWord struct {
+	ID            int    `json:"id"`
+	Word          string `json:"word"`
+	Transcription string `json:"transcription"`
+	Translation   string `json:"translation"`
+	Description   string `json:"description"`
+}
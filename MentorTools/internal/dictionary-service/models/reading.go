@@ -0,0 +1,57 @@
+package models
+
+// Статусы слов текста относительно словаря ученика
+const (
+	TokenKnown    = "known"    // Слово выучено
+	TokenLearning = "learning" // Слово в словаре ученика, но ещё не выучено
+	TokenUnknown  = "unknown"  // Слова нет в словаре ученика
+)
+
+// ReadingRequest - тело запроса на разбор текста
+type ReadingRequest struct {
+	Text string `json:"text"`
+}
+
+// ReadingToken - слово текста с его статусом; Start и End - позиции в символах от начала текста
+type ReadingToken struct {
+	Text     string `json:"text"`
+	Lemma    string `json:"lemma"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Sentence int    `json:"sentence"` // Номер предложения в Sentences
+	Status   string `json:"status"`
+	WordID   *int   `json:"wordId,omitempty"` // Слово из словаря ученика, если оно найдено
+}
+
+// UnknownWord - незнакомое ученику слово текста и первое предложение, в котором оно встретилось
+type UnknownWord struct {
+	Lemma       string `json:"lemma"`
+	Occurrences int    `json:"occurrences"`
+	Sentence    string `json:"sentence"`
+}
+
+// ReadingAnalysis - результат разбора текста по словарю ученика
+type ReadingAnalysis struct {
+	Languages            LanguagePair   `json:"languages"`
+	Sentences            []string       `json:"sentences"`
+	Tokens               []ReadingToken `json:"tokens"`
+	Unknown              []UnknownWord  `json:"unknown"` // В порядке первого появления в тексте
+	TotalWords           int            `json:"totalWords"`
+	KnownWords           int            `json:"knownWords"`
+	LearningWords        int            `json:"learningWords"`
+	UnknownWords         int            `json:"unknownWords"`
+	Coverage             float64        `json:"coverage"`             // Доля выученных слов текста, %
+	CoverageWithLearning float64        `json:"coverageWithLearning"` // Доля слов из словаря ученика, %
+}
+
+// ReadingWordRequest - тело запроса на добавление незнакомого слова из текста
+type ReadingWordRequest struct {
+	Word     string `json:"word"`
+	Sentence string `json:"sentence"` // Предложение, в котором встретилось слово; сохраняется как пример
+}
+
+// VocabularyMatch - слово из словаря ученика, найденное по написанию или форме
+type VocabularyMatch struct {
+	WordID int
+	Status string
+}
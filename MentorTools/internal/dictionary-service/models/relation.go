@@ -0,0 +1,77 @@
+package models
+
+// Типы связей между словами
+const (
+	RelationSynonym     = "synonym"     // Синоним
+	RelationAntonym     = "antonym"     // Антоним
+	RelationCollocation = "collocation" // Устойчивое сочетание или фразовый глагол
+	RelationDerivative  = "derivative"  // Однокоренное слово другой части речи
+	RelationHypernym    = "hypernym"    // Более общее понятие
+)
+
+// RelationTypes - все типы связей
+var RelationTypes = []string{RelationSynonym, RelationAntonym, RelationCollocation, RelationDerivative, RelationHypernym}
+
+// IsRelationType проверяет, что строка - один из типов связей
+func IsRelationType(relationType string) bool {
+	for _, known := range RelationTypes {
+		if relationType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDirectedRelation сообщает, действует ли связь только в одну сторону.
+// Направленная связь хранится от слова к более общему понятию.
+func IsDirectedRelation(relationType string) bool {
+	return relationType == RelationHypernym
+}
+
+// Направление связи относительно слова карточки
+const (
+	DirectionBoth     = "both"     // Связь действует в обе стороны
+	DirectionOutgoing = "outgoing" // Связанное слово - более общее понятие для слова карточки
+	DirectionIncoming = "incoming" // Слово карточки - более общее понятие для связанного слова
+)
+
+// WordRelation - связанное слово в карточке слова
+type WordRelation struct {
+	WordID     int     `json:"wordId"`
+	Word       string  `json:"word"`
+	Type       string  `json:"type"`
+	Direction  string  `json:"direction"`
+	Confidence float64 `json:"confidence"`
+}
+
+// RelationNode - слово в графе связей
+type RelationNode struct {
+	ID    int    `json:"id"`
+	Word  string `json:"word"`
+	Level string `json:"level,omitempty"`
+	Depth int    `json:"depth"` // Число шагов от исходного слова
+}
+
+// RelationEdge - связь в графе; у направленной связи To - более общее понятие
+type RelationEdge struct {
+	From       int     `json:"from"`
+	To         int     `json:"to"`
+	Type       string  `json:"type"`
+	Directed   bool    `json:"directed"`
+	Confidence float64 `json:"confidence"`
+}
+
+// RelationGraphQuery - параметры обхода графа связей
+type RelationGraphQuery struct {
+	WordID        int
+	Depth         int      // Сколько шагов от исходного слова обходить
+	Types         []string // Типы связей; пусто - все типы
+	MinConfidence float64  // Связи с меньшей уверенностью пропускаются
+}
+
+// RelationGraph - слова, связанные с исходным словом, и связи между ними
+type RelationGraph struct {
+	Nodes     []RelationNode `json:"nodes"`
+	Edges     []RelationEdge `json:"edges"`
+	Truncated bool           `json:"truncated"` // true - обход остановлен на ограничении числа слов
+}
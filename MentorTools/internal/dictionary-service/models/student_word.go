@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// StudentWord - слово в словаре ученика
+type StudentWord struct {
+	ID            int       `json:"id"`
+	Word          string    `json:"word"`
+	TypedForm     string    `json:"typedForm"` // Форма, в которой ученик ввёл слово
+	Transcription string    `json:"transcription"`
+	Translation   string    `json:"translation"`
+	Level         string    `json:"level,omitempty"`
+	Status        string    `json:"status"`
+	Deck          string    `json:"deck,omitempty"`
+	AddedAt       time.Time `json:"addedAt"`
+	NextReviewAt  time.Time `json:"nextReviewAt"`
+}
+
+// WordFilter - условия отбора слов ученика; пустое поле не ограничивает выборку
+type WordFilter struct {
+	Status    string     // Статус слова у ученика ("need to learn", "learned")
+	Deck      string     // Колода
+	TopicID   int        // Тема, в контексте которой есть хотя бы один пример слова
+	AddedFrom *time.Time // Добавлено не раньше
+	AddedTo   *time.Time // Добавлено раньше
+	DueOnly   bool       // Только слова, которые пора повторить
+	Levels    []string   // Уровни CEFR слова
+}
+
+// Варианты сортировки словаря ученика
+const (
+	WordSortAdded  = "added"  // По дате добавления
+	WordSortWord   = "word"   // По алфавиту
+	WordSortReview = "review" // По дате следующего повторения
+)
+
+// WordSort - сортировка словаря ученика
+type WordSort struct {
+	Field string // Одно из WordSort*
+	Desc  bool
+}
+
+// WordCursor - позиция последнего слова страницы для курсорной пагинации.
+// Сортировка входит в курсор, чтобы курсор нельзя было применить к другой сортировке.
+type WordCursor struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d"`
+	Key  string `json:"k"` // Значение поля сортировки: слово или время в RFC 3339
+	ID   int    `json:"i"`
+}
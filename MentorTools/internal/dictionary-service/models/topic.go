@@ -0,0 +1,18 @@
+package models
+
+// Topic - тема (контекст), на которую GPT генерирует примеры
+type Topic struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TopicRequest - тело запроса на создание или переименование темы
+type TopicRequest struct {
+	Name string `json:"name"`
+}
+
+// StudentTopicsRequest - тело запроса на установку интересов ученика
+type StudentTopicsRequest struct {
+	StudentID int   `json:"studentId"`
+	TopicIDs  []int `json:"topicIds"`
+}
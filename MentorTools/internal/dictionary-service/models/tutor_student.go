@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// TutorStudent - привязка ученика к репетитору из user-service
+type TutorStudent struct {
+	TutorID     int
+	StudentID   int
+	StudentName string
+	LinkedAt    time.Time
+}
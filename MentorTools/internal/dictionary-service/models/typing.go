@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Что показывается ученику в тренировке написания
+const (
+	TypingModeTranslation = "translation" // Перевод слова
+	TypingModeAudio       = "audio"       // Аудио произношения; если его нет, показывается перевод
+	TypingModeBoth        = "both"        // Перевод и аудио
+)
+
+// TypingSessionRequest - тело запроса на начало тренировки написания
+type TypingSessionRequest struct {
+	Mode  string `json:"mode"`  // Один из TypingMode*, по умолчанию TypingModeBoth
+	Limit int    `json:"limit"` // Сколько слов в тренировке, по умолчанию 10
+	Deck  string `json:"deck"`  // Только слова колоды
+}
+
+// TypingPrompt - задание тренировки: что показать ученику вместо слова
+type TypingPrompt struct {
+	WordID      int    `json:"wordId"`
+	Translation string `json:"translation,omitempty"`
+	AudioKey    string `json:"-"`
+	AudioURL    string `json:"audioUrl,omitempty"`
+	Length      int    `json:"length"`             // Число символов в слове - подсказка для поля ввода
+	Answered    bool   `json:"answered"`           // Ученик уже ответил на задание
+	Correct     *bool  `json:"correct,omitempty"`  // Засчитан ли ответ
+	Typed       string `json:"typed,omitempty"`    // Набранный ответ
+	Expected    string `json:"expected,omitempty"` // Правильное написание; показывается после ответа
+}
+
+// TypingSession - тренировка написания и её прогресс
+type TypingSession struct {
+	ID        string         `json:"id"`
+	Mode      string         `json:"mode"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Prompts   []TypingPrompt `json:"prompts"`
+	Answered  int            `json:"answered"`
+	Correct   int            `json:"correct"`
+}
+
+// TypingAnswerRequest - тело запроса с набранным словом
+type TypingAnswerRequest struct {
+	SessionID string `json:"sessionId"`
+	WordID    int    `json:"wordId"`
+	Typed     string `json:"typed"`
+}
+
+// SpellingMistake - ошибка в одной букве набранного слова
+type SpellingMistake struct {
+	Kind     string `json:"kind"`               // "wrong", "missing" или "extra"
+	Position int    `json:"position"`           // Позиция в правильном написании
+	Expected string `json:"expected,omitempty"` // Нужная буква
+	Typed    string `json:"typed,omitempty"`    // Набранная буква
+}
+
+// TypingAnswerResult - проверка набранного слова и новое состояние его повторения
+type TypingAnswerResult struct {
+	WordID         int               `json:"wordId"`
+	Correct        bool              `json:"correct"`    // Ответ засчитан, в том числе с опечатками в пределах допуска
+	Exact          bool              `json:"exact"`      // Написано без ошибок
+	Normalized     bool              `json:"normalized"` // Отличаются только регистр, диакритика или апострофы
+	Expected       string            `json:"expected"`
+	Typed          string            `json:"typed"`
+	Distance       int               `json:"distance"`                 // Число ошибок в буквах
+	Tolerance      int               `json:"tolerance"`                // Допустимое число опечаток для слова такой длины
+	ConfusedWordID int               `json:"confusedWordId,omitempty"` // Вместо слова набрано это слово словаря (affect вместо effect)
+	Mistakes       []SpellingMistake `json:"mistakes"`
+	Review         AnswerResult      `json:"review"`
+}
@@ -2,16 +2,33 @@ package models
 
 // WordDetails содержит полную информацию о слове, включая синонимы и примеры
 type WordDetails struct {
-	Word          string    `json:"word"`
-	Transcription string    `json:"transcription"`
-	Translation   string    `json:"translation"`
-	Description   string    `json:"description"`
-	Synonyms      []string  `json:"synonyms"`
-	Examples      []Example `json:"examples"`
+	ID            int            `json:"id"`
+	Word          string         `json:"word"`
+	Languages     LanguagePair   `json:"languages"`
+	Transcription string         `json:"transcription"`
+	Translation   string         `json:"translation"`
+	Description   string         `json:"description"`
+	Level         string         `json:"level,omitempty"`         // Уровень CEFR; пусто, пока уровень не определён
+	FrequencyRank *int           `json:"frequencyRank,omitempty"` // Ранг в частотном списке
+	FrequencyBand string         `json:"frequencyBand,omitempty"` // Частотная полоса по рангу
+	Synonyms      []string       `json:"synonyms"`
+	Relations     []WordRelation `json:"relations"` // Все связи слова, включая синонимы
+	Examples      []Example      `json:"examples"`
+	Published     bool           `json:"published"`          // false - контент ждёт проверки репетитором
+	AudioKey      string         `json:"-"`                  // Ключ аудио произношения в хранилище
+	AudioURL      string         `json:"audioUrl,omitempty"` // Адрес аудио произношения; пусто, пока аудио не сгенерировано
+	ImageKey      string         `json:"-"`                  // Ключ картинки в хранилище
+	ThumbnailKey  string         `json:"-"`                  // Ключ миниатюры картинки в хранилище
+	ImageURL      string         `json:"imageUrl,omitempty"` // Адрес картинки; пусто, если картинки нет
+	ThumbnailURL  string         `json:"thumbnailUrl,omitempty"`
 }
 
 // Example содержит пример с предложением и переводом
 type Example struct {
+	ID          int    `json:"id,omitempty"`
 	Sentence    string `json:"sentence"`
 	Translation string `json:"translation"`
+	Source      string `json:"source,omitempty"` // Кто написал пример: gpt, reading или student
+	AudioKey    string `json:"-"`
+	AudioURL    string `json:"audioUrl,omitempty"`
 }
@@ -0,0 +1,18 @@
+package models
+
+// ExportWord - слово ученика со всеми данными карточки
+type ExportWord struct {
+	WordID        int
+	Word          string
+	TypedForm     string
+	Transcription string
+	Translation   string
+	Description   string
+	Status        string
+	Deck          string
+	Examples      []Example
+	ImageKey      string // Ключ картинки слова в хранилище; пусто, если картинки нет
+	ThumbnailKey  string
+	ImageURL      string // Адрес картинки для CSV
+	Thumbnail     []byte // Миниатюра для колоды Anki; загружается только при выгрузке в Anki
+}
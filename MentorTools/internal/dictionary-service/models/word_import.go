@@ -0,0 +1,56 @@
+package models
+
+// Результат обработки строки импорта
+const (
+	ImportRowLinked    = "linked"    // Слово уже заполнено в словаре и добавлено ученику
+	ImportRowQueued    = "queued"    // Слово добавлено ученику и поставлено в очередь обогащения
+	ImportRowDuplicate = "duplicate" // Слово уже есть у ученика или повторяется в файле
+	ImportRowInvalid   = "invalid"   // В строке нет слова
+	ImportRowError     = "error"     // Строку не удалось сохранить
+)
+
+// ImportEntry - слово, прочитанное из файла импорта
+type ImportEntry struct {
+	Row         int    // Номер строки CSV или заметки Anki, начиная с 1
+	Word        string // Слово или выражение
+	Translation string // Перевод из файла, если он есть
+}
+
+// ImportRowResult - результат импорта одной строки
+type ImportRowResult struct {
+	Row     int    `json:"row"`
+	Word    string `json:"word"`
+	Lemma   string `json:"lemma,omitempty"`
+	WordID  int    `json:"wordId,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImportReport - отчёт об импорте словаря
+type ImportReport struct {
+	Total     int               `json:"total"`
+	Linked    int               `json:"linked"`
+	Queued    int               `json:"queued"`
+	Duplicate int               `json:"duplicate"`
+	Invalid   int               `json:"invalid"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// Add добавляет результат строки в отчёт и обновляет счётчики
+func (r *ImportReport) Add(row ImportRowResult) {
+	r.Total++
+	switch row.Status {
+	case ImportRowLinked:
+		r.Linked++
+	case ImportRowQueued:
+		r.Queued++
+	case ImportRowDuplicate:
+		r.Duplicate++
+	case ImportRowInvalid:
+		r.Invalid++
+	default:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
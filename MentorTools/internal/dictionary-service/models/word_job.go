@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Статусы задачи на добавление слова
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+)
+
+// WordJob - задача на асинхронное добавление слова ученику
+type WordJob struct {
+	ID             string       `json:"id"`
+	StudentID      int          `json:"-"`
+	Word           string       `json:"word"`
+	SourceSentence string       `json:"sourceSentence,omitempty"` // Предложение из текста, в котором встретилось слово
+	Status         string       `json:"status"`
+	Stage          string       `json:"stage"`
+	WordID         *int         `json:"wordId,omitempty"`
+	Error          *string      `json:"error,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt"`
+	Result         *WordDetails `json:"result,omitempty"` // Карточка слова, заполняется для завершённой задачи
+}
+
+// Finished - задача завершена (успешно или с ошибкой) и больше не изменится
+func (j WordJob) Finished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+}
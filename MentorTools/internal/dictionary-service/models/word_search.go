@@ -0,0 +1,27 @@
+package models
+
+// SearchResult - слово, найденное поиском по словарю
+type SearchResult struct {
+	WordID        int     `json:"wordId"`
+	Word          string  `json:"word"`
+	Transcription string  `json:"transcription"`
+	Translation   string  `json:"translation"`
+	Status        string  `json:"status"`
+	Example       string  `json:"example,omitempty"` // Лучший найденный пример, если совпадение нашлось в примерах
+	Rank          float64 `json:"rank"`
+}
+
+// SearchPage - страница результатов поиска
+type SearchPage struct {
+	Items  []SearchResult `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// Suggestion - вариант автодополнения
+type Suggestion struct {
+	WordID      int    `json:"wordId"`
+	Word        string `json:"word"`
+	Translation string `json:"translation"`
+}
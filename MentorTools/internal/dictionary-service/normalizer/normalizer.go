@@ -0,0 +1,226 @@
+package normalizer
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"bufio"
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+//go:embed irregular.txt
+var irregularList string
+
+// irregular - неправильные формы из irregular.txt: форма -> лемма
+var irregular = parseIrregular(irregularList)
+
+// particles - частицы фразовых глаголов ("give up", "run out of")
+var particles = map[string]bool{
+	"up": true, "down": true, "out": true, "off": true, "in": true, "on": true,
+	"away": true, "back": true, "over": true, "through": true, "around": true,
+	"about": true, "along": true, "by": true, "into": true, "after": true,
+	"across": true, "apart": true, "aside": true, "forward": true, "together": true,
+}
+
+// leadingWords - служебные слова, которые ученики пишут перед словом ("to run", "the cats")
+var leadingWords = map[string]bool{"to": true, "a": true, "an": true, "the": true}
+
+// Result - результат нормализации введённого слова или выражения
+type Result struct {
+	Typed      string   // Введённая форма после очистки: нижний регистр, без лишних пробелов и знаков
+	Lemma      string   // Известная лемма: из irregular.txt или частотного списка; если её нет - введённая форма
+	Candidates []string // Возможные леммы в порядке предпочтения, первая совпадает с Lemma, последняя - с Typed
+	IsPhrase   bool     // Выражение из нескольких слов (фразовый глагол, устойчивое сочетание)
+}
+
+// Normalize приводит введённое учеником слово или выражение к словарной форме.
+//
+// Неправильные формы ищутся в списке irregular.txt, правильные (-s, -es, -ed, -ing)
+// разбираются правилами. Правила не знают словаря и ошибаются ("news" -> "new", "morning" -> "morn"),
+// поэтому их догадки возвращаются только кандидатами: вызывающий код выбирает первый, который уже есть
+// в словаре. Lemma заменяет введённую форму только известной леммой из irregular.txt или частотного списка.
+// Слова, которые сами есть в этих списках ("news", "something"), правилами не разбираются.
+// В фразовом глаголе лемматизируется глагол ("gave up" -> "give up"),
+// в остальных выражениях - последнее слово ("credit cards" -> "credit card").
+func Normalize(text string) Result {
+	tokens := tokenize(text)
+	for len(tokens) > 1 && leadingWords[tokens[0]] {
+		tokens = tokens[1:]
+	}
+
+	typed := strings.Join(tokens, " ")
+	result := Result{Typed: typed, IsPhrase: len(tokens) > 1}
+	if len(tokens) == 0 {
+		return result
+	}
+
+	// Индекс слова, которое приводим к лемме
+	head := len(tokens) - 1
+	if len(tokens) > 1 && particles[tokens[1]] {
+		head = 0
+	}
+
+	result.Lemma = typed
+	for _, lemma := range lemmaCandidates(tokens[head]) {
+		phrase := make([]string, len(tokens))
+		copy(phrase, tokens)
+		phrase[head] = lemma
+		candidate := strings.Join(phrase, " ")
+		if result.Lemma == typed && lemma != tokens[head] && (irregular[tokens[head]] == lemma || isKnownLemma(lemma)) {
+			result.Lemma = candidate
+		}
+		result.Candidates = appendUnique(result.Candidates, candidate)
+	}
+	result.Candidates = appendUnique(result.Candidates, typed)
+	return result
+}
+
+// NormalizeFor приводит слово к словарной форме с учётом языка. Правила лемматизации есть только
+// для английского, слова остальных языков лишь очищаются и остаются в введённой форме.
+func NormalizeFor(text, language string) Result {
+	if language == "en" {
+		return Normalize(text)
+	}
+
+	tokens := tokenize(text)
+	typed := strings.Join(tokens, " ")
+	result := Result{Typed: typed, IsPhrase: len(tokens) > 1}
+	if typed != "" {
+		result.Lemma = typed
+		result.Candidates = []string{typed}
+	}
+	return result
+}
+
+// isKnownLemma - слово само является леммой: есть в частотном списке или отмечено в irregular.txt как неизменяемое
+func isKnownLemma(word string) bool {
+	if lemma, ok := irregular[word]; ok {
+		return lemma == word
+	}
+	_, ok := frequency.Lookup(word, "en")
+	return ok
+}
+
+// lemmaCandidates возвращает возможные леммы одного слова в порядке предпочтения.
+// Известная лемма не разбирается правилами и остаётся единственным кандидатом.
+func lemmaCandidates(word string) []string {
+	if isKnownLemma(word) {
+		return []string{word}
+	}
+
+	var candidates []string
+	if lemma, ok := irregular[word]; ok {
+		candidates = append(candidates, lemma)
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ing") && len(word) > 4 && hasVowel(strings.TrimSuffix(word, "ing")):
+		candidates = append(candidates, verbStemCandidates(strings.TrimSuffix(word, "ing"))...)
+	case strings.HasSuffix(word, "ied") && len(word) > 4:
+		candidates = append(candidates, strings.TrimSuffix(word, "ied")+"y")
+	case strings.HasSuffix(word, "ed") && len(word) > 3 && !strings.HasSuffix(word, "eed") && hasVowel(strings.TrimSuffix(word, "ed")):
+		candidates = append(candidates, verbStemCandidates(strings.TrimSuffix(word, "ed"))...)
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		candidates = append(candidates, strings.TrimSuffix(word, "ies")+"y")
+	case strings.HasSuffix(word, "oes"), hasAnySuffix(word, "uses", "ises") && len(word) > 4:
+		candidates = append(candidates, strings.TrimSuffix(word, "s"), strings.TrimSuffix(word, "es"))
+	case hasAnySuffix(word, "sses", "xes", "zes", "ches", "shes"):
+		candidates = append(candidates, strings.TrimSuffix(word, "es"), strings.TrimSuffix(word, "s"))
+	case strings.HasSuffix(word, "s") && len(word) > 3 && !hasAnySuffix(word, "ss", "us", "is"):
+		candidates = append(candidates, strings.TrimSuffix(word, "s"))
+	}
+
+	return append(candidates, word)
+}
+
+// verbStemCandidates восстанавливает основу глагола после отбрасывания -ed/-ing:
+// удвоенная согласная ("stopp" -> "stop"), немое "e" ("mak" -> "make") или основа как есть ("play")
+func verbStemCandidates(stem string) []string {
+	n := len(stem)
+	if n >= 3 && stem[n-1] == stem[n-2] && isConsonant(stem[n-1]) && !strings.ContainsRune("lsfz", rune(stem[n-1])) {
+		return []string{stem[:n-1], stem}
+	}
+	if needsSilentE(stem) {
+		return []string{stem + "e", stem}
+	}
+	if strings.ContainsRune("aeiouwxy", rune(stem[n-1])) {
+		return []string{stem}
+	}
+	return []string{stem, stem + "e"}
+}
+
+// needsSilentE - эвристика: основа, скорее всего, заканчивалась на немое "e"
+func needsSilentE(stem string) bool {
+	n := len(stem)
+	if n < 2 {
+		return false
+	}
+	if hasAnySuffix(stem, "v", "c", "z", "us", "at", "ur", "ng", "rg", "dg", "iz", "ys") {
+		return !hasAnySuffix(stem, "eng", "ing", "ong", "ung")
+	}
+	// Короткая основа вида согласная-гласная-согласная: "mak", "hop", "us"
+	return n == 3 && isConsonant(stem[0]) && !isConsonant(stem[1]) && isConsonant(stem[2]) && !strings.ContainsRune("wxy", rune(stem[2]))
+}
+
+// tokenize приводит текст к нижнему регистру и разбивает на слова, отбрасывая знаки препинания по краям
+func tokenize(text string) []string {
+	text = strings.NewReplacer("’", "'", "‘", "'", "`", "'").Replace(strings.ToLower(text))
+
+	var tokens []string
+	for _, field := range strings.Fields(text) {
+		token := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// parseIrregular разбирает список неправильных форм, пропуская комментарии и пустые строки
+func parseIrregular(list string) map[string]string {
+	forms := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// Для неоднозначных форм побеждает первая строка списка
+		if _, exists := forms[fields[0]]; !exists {
+			forms[fields[0]] = fields[1]
+		}
+	}
+	return forms
+}
+
+func hasVowel(word string) bool {
+	return strings.ContainsAny(word, "aeiouy")
+}
+
+func isConsonant(c byte) bool {
+	return c >= 'a' && c <= 'z' && !strings.ContainsRune("aeiou", rune(c))
+}
+
+func hasAnySuffix(word string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
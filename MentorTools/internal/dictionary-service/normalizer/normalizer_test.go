@@ -0,0 +1,66 @@
+package normalizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeLemma(t *testing.T) {
+	tests := []struct {
+		typed, lemma string
+	}{
+		// Слова, которые похожи на формы, но сами являются леммами
+		{"something", "something"},
+		{"morning", "morning"},
+		{"ceiling", "ceiling"},
+		{"news", "news"},
+		{"series", "series"},
+		{"physics", "physics"},
+		{"mathematics", "mathematics"},
+		// Догадка правил, которой нет в списках, не заменяет введённую форму
+		{"buses", "buses"},
+		{"focused", "focused"},
+		// Известные леммы
+		{"running", "run"},
+		{"makes", "make"},
+		{"went", "go"},
+		{"Stories", "story"},
+		{"the cats", "cats"},
+		{"gave up", "give up"},
+		{"to run out of", "run out of"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.typed).Lemma; got != tt.lemma {
+			t.Errorf("Normalize(%q).Lemma = %q, want %q", tt.typed, got, tt.lemma)
+		}
+	}
+}
+
+func TestNormalizeCandidates(t *testing.T) {
+	tests := []struct {
+		typed      string
+		candidates []string
+	}{
+		// Известная лемма - единственный кандидат, поэтому "news" не превратится в "new" из словаря
+		{"news", []string{"news"}},
+		{"morning", []string{"morning"}},
+		// Догадки правил остаются кандидатами для поиска в словаре
+		{"buses", []string{"buse", "bus", "buses"}},
+		{"focused", []string{"focuse", "focus", "focused"}},
+		{"stopped", []string{"stop", "stopp", "stopped"}},
+		{"went", []string{"go", "went"}},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.typed).Candidates; !reflect.DeepEqual(got, tt.candidates) {
+			t.Errorf("Normalize(%q).Candidates = %q, want %q", tt.typed, got, tt.candidates)
+		}
+	}
+}
+
+func TestNormalizeForOtherLanguages(t *testing.T) {
+	got := NormalizeFor("  Häuser! ", "de")
+	want := Result{Typed: "häuser", Lemma: "häuser", Candidates: []string{"häuser"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeFor(de) = %+v, want %+v", got, want)
+	}
+}
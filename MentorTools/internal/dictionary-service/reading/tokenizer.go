@@ -0,0 +1,124 @@
+package reading
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations - сокращения, после точки в которых предложение не заканчивается.
+// Сокращения из однобуквенных частей ("e.g.", "i.e.") распознаются как инициалы, см. isAbbreviation;
+// "no" сюда не входит: "He said no." - конец предложения, а не номер.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "st": true, "jr": true, "sr": true,
+	"vs": true, "etc": true, "approx": true,
+}
+
+// Sentence - предложение текста и слова, из которых оно состоит
+type Sentence struct {
+	Text  string
+	Words []Word
+}
+
+// Word - слово текста; Start и End - позиции в символах (рунах) от начала текста
+type Word struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Split разбивает текст на предложения и слова.
+//
+// Предложение заканчивается на ".", "!", "?" или "…", за которыми идёт пробел или конец текста,
+// а также на пустой строке (между абзацами). Точка после сокращения ("Mr.", "e.g.") или инициала
+// предложение не заканчивает. Слово - последовательность букв, внутри которой
+// допускаются апостроф и дефис ("don't", "well-known"); числа и знаки препинания словами не считаются.
+func Split(text string) []Sentence {
+	runes := []rune(strings.NewReplacer("’", "'", "‘", "'").Replace(text))
+
+	var sentences []Sentence
+	var current Sentence
+	sentenceStart := 0
+
+	flush := func(end int) {
+		current.Text = strings.TrimSpace(string(runes[sentenceStart:end]))
+		if len(current.Words) > 0 {
+			sentences = append(sentences, current)
+		}
+		current = Sentence{}
+		sentenceStart = end
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if unicode.IsLetter(r) {
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || isJoiner(runes, i)) {
+				i++
+			}
+			current.Words = append(current.Words, Word{Text: string(runes[start:i]), Start: start, End: i})
+			continue
+		}
+
+		i++
+		switch {
+		case r == '.' && isAbbreviation(current.Words, i-1):
+		case isSentenceEnd(r) && (i == len(runes) || unicode.IsSpace(runes[i]) || isClosingQuote(runes[i])):
+			for i < len(runes) && isClosingQuote(runes[i]) {
+				i++
+			}
+			flush(i)
+		case r == '\n' && i < len(runes) && isBlankLineAhead(runes, i):
+			flush(i)
+		}
+	}
+	flush(len(runes))
+
+	return sentences
+}
+
+// isJoiner - апостроф или дефис между двумя буквами ("don't", "well-known")
+func isJoiner(runes []rune, i int) bool {
+	if runes[i] != '\'' && runes[i] != '-' {
+		return false
+	}
+	return i > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1])
+}
+
+// isAbbreviation - точка в позиции dot стоит сразу после сокращения, инициала ("J.")
+// или буквы сокращения вроде "e.g."; местоимение "I" и артикль "a" сокращениями не считаются
+func isAbbreviation(words []Word, dot int) bool {
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	if last.End != dot {
+		return false
+	}
+	if abbreviations[strings.ToLower(last.Text)] {
+		return true
+	}
+	runes := []rune(last.Text)
+	return len(runes) == 1 && runes[0] != 'I' && runes[0] != 'a'
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isClosingQuote(r rune) bool {
+	return r == '"' || r == '”' || r == '»' || r == ')'
+}
+
+// isBlankLineAhead - после перевода строки до следующего перевода строки нет ничего, кроме пробелов
+func isBlankLineAhead(runes []rune, i int) bool {
+	for ; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			return true
+		}
+		if !unicode.IsSpace(runes[i]) {
+			return false
+		}
+	}
+	return false
+}
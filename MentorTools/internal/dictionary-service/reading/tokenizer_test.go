@@ -0,0 +1,58 @@
+package reading
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"He said no. She left the room.", []string{"He said no.", "She left the room."}},
+		{"Is it here? Yes! It is…  Good.", []string{"Is it here?", "Yes!", "It is…", "Good."}},
+		{"Mr. Smith met Dr. Brown.", []string{"Mr. Smith met Dr. Brown."}},
+		{"Bring fruit, e.g. apples. Then cook.", []string{"Bring fruit, e.g. apples.", "Then cook."}},
+		{"Use a pen, i.e. not a pencil.", []string{"Use a pen, i.e. not a pencil."}},
+		{"J. K. Rowling wrote it.", []string{"J. K. Rowling wrote it."}},
+		{"I saw I. Then I left.", []string{"I saw I.", "Then I left."}},
+		{"Take plan a. Then go.", []string{"Take plan a.", "Then go."}},
+		{`"Stop." He stopped.`, []string{`"Stop."`, "He stopped."}},
+		{"Version 2.5 is out. 42.", []string{"Version 2.5 is out."}},
+		{"First paragraph\n\nSecond paragraph", []string{"First paragraph", "Second paragraph"}},
+		{"One line\nstill the same sentence", []string{"One line\nstill the same sentence"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, sentence := range Split(tt.text) {
+			got = append(got, sentence.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Word
+	}{
+		{"Don't stop", []Word{{"Don't", 0, 5}, {"stop", 6, 10}}},
+		{"a well-known fact", []Word{{"a", 0, 1}, {"well-known", 2, 12}, {"fact", 13, 17}}},
+		// Позиции считаются в рунах, а не в байтах; типографский апостроф заменяется обычным
+		{"Ёж и don’t", []Word{{"Ёж", 0, 2}, {"и", 3, 4}, {"don't", 5, 10}}},
+		{"-dash- 'quoted' 3rd", []Word{{"dash", 1, 5}, {"quoted", 8, 14}, {"rd", 17, 19}}},
+	}
+	for _, tt := range tests {
+		var got []Word
+		for _, sentence := range Split(tt.text) {
+			got = append(got, sentence.Words...)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%q) words = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
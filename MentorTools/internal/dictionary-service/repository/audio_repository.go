@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// audioTables - таблицы, в которых хранится ключ аудио, по тому, что озвучивается
+var audioTables = map[string]string{
+	models.AudioWord:    "words",
+	models.AudioExample: "examples",
+}
+
+// NextAudioTask возвращает слово или пример без аудио, у которых меньше maxAttempts неудачных попыток.
+// Первыми идут задачи с меньшим числом попыток, среди них - слова, затем примеры.
+// Язык примера берётся у слова, с которым он связан. Если озвучивать нечего, возвращает ErrQueueEmpty.
+func NextAudioTask(ctx context.Context, dbpool *pgxpool.Pool, maxAttempts int) (models.AudioTask, error) {
+	var task models.AudioTask
+	err := dbpool.QueryRow(ctx, `
+        SELECT kind, id, text, language FROM (
+            (SELECT 'word' AS kind, 0 AS priority, id, word AS text, source_lang AS language, audio_attempts AS attempts
+             FROM words
+             WHERE audio_key IS NULL AND status = 'completed' AND audio_attempts < $1
+             ORDER BY audio_attempts, id
+             LIMIT 1)
+            UNION ALL
+            (SELECT 'example', 1, e.id, e.example,
+                    (SELECT w.source_lang FROM word_example we JOIN words w ON w.id = we.word_id
+                     WHERE we.example_id = e.id ORDER BY w.id LIMIT 1),
+                    e.audio_attempts
+             FROM examples e
+             WHERE e.audio_key IS NULL AND NOT e.hidden AND e.audio_attempts < $1
+               AND EXISTS (SELECT 1 FROM word_example we WHERE we.example_id = e.id)
+             ORDER BY e.audio_attempts, e.id
+             LIMIT 1)
+        ) queue
+        ORDER BY attempts, priority
+        LIMIT 1`, maxAttempts).Scan(&task.Kind, &task.ID, &task.Text, &task.Language)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return task, ErrQueueEmpty
+	}
+	return task, err
+}
+
+// SetAudioKey сохраняет ключ сгенерированного аудио слова или примера
+func SetAudioKey(ctx context.Context, dbpool *pgxpool.Pool, task models.AudioTask, key string) error {
+	table, ok := audioTables[task.Kind]
+	if !ok {
+		return fmt.Errorf("unknown audio kind %q", task.Kind)
+	}
+	_, err := dbpool.Exec(ctx, fmt.Sprintf("UPDATE %s SET audio_key = $2, audio_error = NULL WHERE id = $1", table), task.ID, key)
+	return err
+}
+
+// FailAudioAttempt фиксирует неудачную попытку озвучивания
+func FailAudioAttempt(ctx context.Context, dbpool *pgxpool.Pool, task models.AudioTask, cause error) error {
+	table, ok := audioTables[task.Kind]
+	if !ok {
+		return fmt.Errorf("unknown audio kind %q", task.Kind)
+	}
+	_, err := dbpool.Exec(ctx, fmt.Sprintf(`
+        UPDATE %s SET audio_attempts = audio_attempts + 1, audio_error = $2
+        WHERE id = $1`, table), task.ID, cause.Error())
+	return err
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RecordAnswerMistake сохраняет неправильный ответ ученика. Слово, с которым ученик перепутал request.WordID,
+// ищется в той же языковой паре: request.ChosenWordID, а если клиент его не передал - слово или перевод,
+// совпадающие с ответом. Если такого слова нет, ошибка сохраняется без него.
+func RecordAnswerMistake(ctx context.Context, tx pgx.Tx, studentID int, eventType string, request models.AnswerRequest) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO answer_mistakes (student_id, word_id, event_type, answer, confused_word_id)
+        SELECT $1, w.id, $3, NULLIF(btrim($4::text), ''), (
+            SELECT c.id FROM words c
+            WHERE c.source_lang = w.source_lang AND c.target_lang = w.target_lang AND c.id <> w.id
+              AND (c.id = $5 OR ($5 = 0 AND btrim($4::text) <> ''
+                   AND (lower(c.word) = lower(btrim($4::text)) OR lower(c.translation) = lower(btrim($4::text)))))
+            ORDER BY c.id = $5 DESC, lower(c.word) = lower(btrim($4::text)) DESC, c.frequency_rank NULLS LAST, c.id
+            LIMIT 1)
+        FROM words w
+        WHERE w.id = $2`, studentID, request.WordID, eventType, request.Answer, request.ChosenWordID)
+	return err
+}
+
+// FindConfusedWord возвращает ID другого слова языковой пары слова wordID с написанием typed
+// (нормализованным, см. spelling.Normalize); если такого слова нет, возвращает 0
+func FindConfusedWord(ctx context.Context, tx pgx.Tx, wordID int, typed string) (int, error) {
+	var confusedID int
+	err := tx.QueryRow(ctx, `
+        SELECT c.id FROM words c
+        JOIN words w ON w.id = $1
+        WHERE c.source_lang = w.source_lang AND c.target_lang = w.target_lang AND c.id <> w.id
+          AND lower(btrim(c.word)) = $2
+        ORDER BY c.frequency_rank NULLS LAST, c.id
+        LIMIT 1`, wordID, typed).Scan(&confusedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return confusedID, err
+}
+
+// confusionPairsQuery - пары слов, которые путает ученик $1: пара упорядочена по ID слов, ошибки считаются в обе стороны
+const confusionPairsQuery = `
+        SELECT p.word_id, a.word, p.other_word_id, b.word, p.mistakes, p.last_mistake_at
+        FROM (SELECT least(word_id, confused_word_id) AS word_id, greatest(word_id, confused_word_id) AS other_word_id,
+                     count(*) AS mistakes, max(created_at) AS last_mistake_at
+              FROM answer_mistakes
+              WHERE student_id = $1 AND confused_word_id IS NOT NULL
+              GROUP BY 1, 2) p
+        JOIN words a ON a.id = p.word_id
+        JOIN words b ON b.id = p.other_word_id`
+
+// GetConfusionPairs возвращает до limit пар слов, которые ученик путает чаще всего
+func GetConfusionPairs(ctx context.Context, dbpool *pgxpool.Pool, studentID, limit int) ([]models.ConfusionPair, error) {
+	rows, err := dbpool.Query(ctx, confusionPairsQuery+`
+        ORDER BY p.mistakes DESC, p.last_mistake_at DESC, p.word_id, p.other_word_id
+        LIMIT $2`, studentID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pairs := []models.ConfusionPair{}
+	for rows.Next() {
+		pair, err := scanConfusionPair(rows)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, rows.Err()
+}
+
+// GetConfusionPair возвращает пару слов wordID < otherWordID, если ученик их путал; иначе возвращает ErrNotFound
+func GetConfusionPair(ctx context.Context, dbpool *pgxpool.Pool, studentID, wordID, otherWordID int) (models.ConfusionPair, error) {
+	pair, err := scanConfusionPair(dbpool.QueryRow(ctx, confusionPairsQuery+`
+        WHERE p.word_id = $2 AND p.other_word_id = $3`, studentID, wordID, otherWordID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pair, ErrNotFound
+	}
+	return pair, err
+}
+
+// scanConfusionPair читает строку confusionPairsQuery
+func scanConfusionPair(row pgx.Row) (models.ConfusionPair, error) {
+	var pair models.ConfusionPair
+	err := row.Scan(&pair.WordID, &pair.Word, &pair.OtherWordID, &pair.OtherWord, &pair.Mistakes, &pair.LastMistakeAt)
+	return pair, err
+}
+
+// GetContrastiveExercise возвращает упражнение на различение слов wordID < otherWordID;
+// если его ещё нет, возвращает ErrNotFound. Слова упражнения не заполняются.
+func GetContrastiveExercise(ctx context.Context, dbpool *pgxpool.Pool, wordID, otherWordID int) (models.ContrastiveExercise, error) {
+	exercise := models.ContrastiveExercise{WordID: wordID, OtherWordID: otherWordID}
+	var items []byte
+	err := dbpool.QueryRow(ctx, `
+        SELECT id, explanation, items, created_at FROM contrastive_exercises
+        WHERE word_id = $1 AND other_word_id = $2`, wordID, otherWordID).
+		Scan(&exercise.ID, &exercise.Explanation, &items, &exercise.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return exercise, ErrNotFound
+	}
+	if err != nil {
+		return exercise, err
+	}
+	return exercise, json.Unmarshal(items, &exercise.Items)
+}
+
+// SaveContrastiveExercise сохраняет упражнение на различение пары слов. Если упражнение для пары
+// уже сохранено параллельным запросом, exercise заменяется сохранённым, чтобы все ученики получали одно упражнение.
+func SaveContrastiveExercise(ctx context.Context, dbpool *pgxpool.Pool, exercise *models.ContrastiveExercise) error {
+	items, err := json.Marshal(exercise.Items)
+	if err != nil {
+		return err
+	}
+
+	err = dbpool.QueryRow(ctx, `
+        INSERT INTO contrastive_exercises (word_id, other_word_id, explanation, items) VALUES ($1, $2, $3, $4)
+        ON CONFLICT (word_id, other_word_id) DO UPDATE SET word_id = EXCLUDED.word_id
+        RETURNING id, explanation, items, created_at`, exercise.WordID, exercise.OtherWordID, exercise.Explanation, items).
+		Scan(&exercise.ID, &exercise.Explanation, &items, &exercise.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(items, &exercise.Items)
+}
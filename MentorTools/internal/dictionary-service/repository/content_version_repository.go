@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/gpt-service/services"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrWordNotCompleted возвращается, если контент слова ещё не сгенерирован
+var ErrWordNotCompleted = errors.New("word is not completed")
+
+// GetWordForUpdate возвращает слово и его статус по ID, блокируя строку до конца транзакции.
+// Если слова нет, возвращает ErrNotFound.
+func GetWordForUpdate(ctx context.Context, tx pgx.Tx, wordID int) (string, string, error) {
+	var word, status string
+	err := tx.QueryRow(ctx, "SELECT word, status FROM words WHERE id = $1 FOR UPDATE", wordID).Scan(&word, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", "", ErrNotFound
+	}
+	return word, status, err
+}
+
+// CreateContentVersion сохраняет ответ GPT как следующую версию контента слова и возвращает её номер.
+// Примеры версии сохраняются сразу, но связываются со словом только при активации версии.
+// Синонимы и ключевые слова, которых ещё нет в словаре, попадают в очередь со статусом "pending"
+// в той же языковой паре, что и слово.
+// Строка слова должна быть заблокирована вызывающим, чтобы версии получали последовательные номера.
+func CreateContentVersion(ctx context.Context, tx pgx.Tx, wordID int, details services.WordDetails, generation models.ContentGeneration) (int, error) {
+	pair, err := GetWordLanguagePair(ctx, tx, wordID)
+	if err != nil {
+		return 0, err
+	}
+
+	content, err := json.Marshal(details)
+	if err != nil {
+		return 0, err
+	}
+
+	var versionID, version int
+	err = tx.QueryRow(ctx, `
+        INSERT INTO word_content_versions (word_id, version, model, prompt_version, content, created_by)
+        VALUES ($1, (SELECT COALESCE(max(version), 0) + 1 FROM word_content_versions WHERE word_id = $1), $2, $3, $4, $5)
+        RETURNING id, version`, wordID, generation.Model, generation.PromptVersion, content, generation.CreatedBy).Scan(&versionID, &version)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, synonym := range details.Synonyms {
+		if _, err := UpsertPendingWord(ctx, tx, synonym, pair); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, example := range details.Examples {
+		var exampleID int
+		err := tx.QueryRow(ctx, `
+            INSERT INTO examples (example, context, translation, content_version_id, source_lang, target_lang)
+            VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id`, example.Text, example.Context, example.Translation, versionID, pair.Source, pair.Target).Scan(&exampleID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, keyword := range example.Keywords {
+			keywordID, err := UpsertPendingWord(ctx, tx, keyword, pair)
+			if err != nil {
+				return 0, err
+			}
+			if err := linkWordExample(ctx, tx, keywordID, exampleID); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return version, nil
+}
+
+// ActivateContentVersion делает версию контента активной: переносит транскрипцию, перевод и описание
+// в слово, заменяет примеры предыдущих версий примерами этой версии и пересобирает синонимы и другие связи слова.
+// Правки репетиторов не теряются: исправленные поля слова берутся из истории правок поверх версии,
+// исправленные примеры остаются у слова, а скрытые синонимы и связи остаются скрытыми.
+// Если включена очередь проверки, контент остаётся неопубликованным до одобрения репетитором.
+// Если у слова нет такой версии, возвращает ErrNotFound.
+func ActivateContentVersion(ctx context.Context, tx pgx.Tx, wordID, version int) error {
+	var versionID int
+	var content []byte
+	err := tx.QueryRow(ctx, "SELECT id, content FROM word_content_versions WHERE word_id = $1 AND version = $2",
+		wordID, version).Scan(&versionID, &content)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var details services.WordDetails
+	if err := json.Unmarshal(content, &details); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE words
+        SET transcription = $1, translation = $2, definition = $3, status = 'completed',
+            enrichment_error = NULL, next_attempt_at = NULL, active_version_id = $5,
+            published = NOT COALESCE((SELECT value = 'true' FROM moderation_settings WHERE key = $6), false)
+        WHERE id = $4`, details.Transcription, details.Translation, details.Description, wordID, versionID, reviewSettingKey)
+	if err != nil {
+		return err
+	}
+	if err := applyWordEdits(ctx, tx, wordID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM word_example we
+        USING examples e, word_content_versions v
+        WHERE we.word_id = $1 AND we.example_id = e.id
+          AND e.content_version_id = v.id AND v.word_id = $1 AND v.id <> $2
+          AND NOT EXISTS (SELECT 1 FROM content_edits ce
+                          WHERE ce.word_id = $1 AND ce.target = $3 AND ce.target_id = e.id AND ce.field IN ('sentence', 'translation'))`,
+		wordID, versionID, models.EditTargetExample)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO word_example (word_id, example_id)
+        SELECT $1, id FROM examples WHERE content_version_id = $2
+        ON CONFLICT (word_id, example_id) DO NOTHING`, wordID, versionID)
+	if err != nil {
+		return err
+	}
+
+	pair, err := GetWordLanguagePair(ctx, tx, wordID)
+	if err != nil {
+		return err
+	}
+	return replaceWordLinks(ctx, tx, wordID, pair, details)
+}
+
+// applyWordEdits возвращает в слово последние правки репетиторов транскрипции, перевода и описания
+func applyWordEdits(ctx context.Context, tx pgx.Tx, wordID int) error {
+	_, err := tx.Exec(ctx, `
+        WITH latest AS (
+            SELECT DISTINCT ON (field) field, new_value
+            FROM content_edits
+            WHERE word_id = $1 AND target = $2 AND field IN ('transcription', 'translation', 'definition')
+            ORDER BY field, version DESC)
+        UPDATE words
+        SET transcription = COALESCE((SELECT new_value FROM latest WHERE field = 'transcription'), transcription),
+            translation = COALESCE((SELECT new_value FROM latest WHERE field = 'translation'), translation),
+            definition = COALESCE((SELECT new_value FROM latest WHERE field = 'definition'), definition)
+        WHERE id = $1`, wordID, models.EditTargetWord)
+	return err
+}
+
+// SnapshotLegacyContent сохраняет контент слова, сгенерированный до появления версий, как версию
+// с нулевой версией промпта, чтобы к нему можно было вернуться после перегенерации.
+// В версию попадают все ещё не привязанные к версиям примеры слова, кроме личных примеров учеников, поэтому примеры, где слово
+// было лишь ключевым словом, тоже отвяжутся от него при активации другой версии.
+// Для слов, у которых уже есть активная версия или нет контента, ничего не делает.
+func SnapshotLegacyContent(ctx context.Context, tx pgx.Tx, wordID int) error {
+	var status string
+	var activeVersionID *int
+	var transcription, translation, description sql.NullString
+	err := tx.QueryRow(ctx, `
+        SELECT status, active_version_id, transcription, translation, definition
+        FROM words WHERE id = $1`, wordID).Scan(&status, &activeVersionID, &transcription, &translation, &description)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil || activeVersionID != nil || status != "completed" {
+		return err
+	}
+
+	details := services.WordDetails{
+		Transcription: transcription.String,
+		Translation:   translation.String,
+		Description:   description.String,
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT s.word FROM word_links l
+        JOIN words s ON s.id = l.linked_word_id
+        WHERE l.word_id = $1 AND l.relation_type = 'synonym' AND NOT l.hidden
+        ORDER BY s.word`, wordID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var synonym string
+		if err := rows.Scan(&synonym); err != nil {
+			rows.Close()
+			return err
+		}
+		details.Synonyms = append(details.Synonyms, synonym)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var exampleIDs []int
+	rows, err = tx.Query(ctx, `
+        SELECT e.id, e.example, COALESCE(e.translation, ''), COALESCE(e.context, '')
+        FROM examples e
+        JOIN word_example we ON we.example_id = e.id
+        WHERE we.word_id = $1 AND e.content_version_id IS NULL AND e.student_id IS NULL AND e.source = 'gpt'
+        ORDER BY e.id`, wordID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var exampleID int
+		var example services.Examples
+		if err := rows.Scan(&exampleID, &example.Text, &example.Translation, &example.Context); err != nil {
+			rows.Close()
+			return err
+		}
+		exampleIDs = append(exampleIDs, exampleID)
+		details.Examples = append(details.Examples, example)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	var versionID int
+	err = tx.QueryRow(ctx, `
+        INSERT INTO word_content_versions (word_id, version, content)
+        VALUES ($1, (SELECT COALESCE(max(version), 0) + 1 FROM word_content_versions WHERE word_id = $1), $2)
+        RETURNING id`, wordID, content).Scan(&versionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE examples SET content_version_id = $2 WHERE id = ANY($1)", exampleIDs, versionID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, "UPDATE words SET active_version_id = $2 WHERE id = $1", wordID, versionID)
+	return err
+}
+
+// GetContentVersions возвращает все версии контента слова, начиная с последней
+func GetContentVersions(ctx context.Context, dbpool *pgxpool.Pool, wordID int) ([]models.ContentVersion, error) {
+	rows, err := dbpool.Query(ctx, contentVersionQuery+`
+        WHERE v.word_id = $1
+        ORDER BY v.version DESC`, wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []models.ContentVersion{}
+	for rows.Next() {
+		version, err := scanContentVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// GetContentVersion возвращает версию контента слова по номеру; если её нет, возвращает ErrNotFound
+func GetContentVersion(ctx context.Context, dbpool *pgxpool.Pool, wordID, version int) (models.ContentVersion, error) {
+	result, err := scanContentVersion(dbpool.QueryRow(ctx, contentVersionQuery+`
+        WHERE v.word_id = $1 AND v.version = $2`, wordID, version))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return result, ErrNotFound
+	}
+	return result, err
+}
+
+// FindOutdatedWords возвращает ID заполненных слов, активная версия которых создана промптом
+// старше promptVersion (или до появления версий), по возрастанию ID после afterID
+func FindOutdatedWords(ctx context.Context, dbpool *pgxpool.Pool, promptVersion, afterID, limit int) ([]int, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT w.id
+        FROM words w
+        LEFT JOIN word_content_versions v ON v.id = w.active_version_id
+        WHERE w.status = 'completed' AND COALESCE(v.prompt_version, 0) < $1 AND w.id > $2
+        ORDER BY w.id
+        LIMIT $3`, promptVersion, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// contentVersionQuery - общая часть запросов версий контента
+const contentVersionQuery = `
+        SELECT v.id, v.word_id, v.version, v.model, v.prompt_version, v.created_by, v.created_at,
+               COALESCE(w.active_version_id = v.id, false), v.content
+        FROM word_content_versions v
+        JOIN words w ON w.id = v.word_id`
+
+// scanContentVersion читает строку contentVersionQuery
+func scanContentVersion(row pgx.Row) (models.ContentVersion, error) {
+	var version models.ContentVersion
+	var content []byte
+	err := row.Scan(&version.ID, &version.WordID, &version.Version, &version.Model, &version.PromptVersion,
+		&version.CreatedBy, &version.CreatedAt, &version.Active, &content)
+	if err != nil {
+		return version, err
+	}
+	err = json.Unmarshal(content, &version.Content)
+	return version, err
+}
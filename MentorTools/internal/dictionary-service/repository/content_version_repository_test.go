@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Перегенерация контента (RegenerateWord, RegenerateOutdated) создаёт новую версию и активирует её;
+// правки репетитора, сделанные до этого, должны остаться в силе
+func TestActivateContentVersionKeepsTutorEdits(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	suffix := dbtest.Suffix()
+	wordID := dbtest.CreateWord(t, pool, "large"+suffix, "большой")
+	synonym, otherSynonym := "big"+suffix, "huge"+suffix
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM words WHERE word IN ($1, $2)", synonym, otherSynonym)
+	})
+
+	saveVersion(t, pool, wordID, gpt.WordDetails{
+		Transcription: "lɑːdʒ", Translation: "большой", Description: "of considerable size",
+		Synonyms: []string{synonym, otherSynonym},
+		Examples: []gpt.Examples{{Text: "A large house.", Translation: "Большой дом."}},
+	})
+
+	tutorID := dbtest.NewID()
+	translation := "крупный"
+	if err := services.EditWordContent(ctx, pool, tutorID, wordID, models.WordContentRequest{Translation: &translation}); err != nil {
+		t.Fatalf("EditWordContent: %v", err)
+	}
+	var exampleID int
+	err := pool.QueryRow(ctx, "SELECT example_id FROM word_example WHERE word_id = $1", wordID).Scan(&exampleID)
+	if err != nil {
+		t.Fatalf("find example: %v", err)
+	}
+	sentence := "A large city."
+	if err := services.EditExample(ctx, pool, tutorID, wordID, exampleID, models.ExampleContentRequest{Sentence: &sentence}); err != nil {
+		t.Fatalf("EditExample: %v", err)
+	}
+	if err := services.SetSynonymHidden(ctx, pool, tutorID, wordID, synonym, true); err != nil {
+		t.Fatalf("SetSynonymHidden: %v", err)
+	}
+
+	// Новая версия снова предлагает скрытый синоним и другой перевод
+	saveVersion(t, pool, wordID, gpt.WordDetails{
+		Transcription: "lɑːrdʒ", Translation: "большой", Description: "big in size",
+		Synonyms: []string{synonym, otherSynonym},
+		Examples: []gpt.Examples{{Text: "Large amounts of data.", Translation: "Большие объёмы данных."}},
+	})
+
+	var gotTranslation, gotTranscription string
+	err = pool.QueryRow(ctx, "SELECT translation, transcription FROM words WHERE id = $1", wordID).Scan(&gotTranslation, &gotTranscription)
+	if err != nil {
+		t.Fatalf("read word: %v", err)
+	}
+	if gotTranslation != translation {
+		t.Errorf("translation = %q, want tutor edit %q", gotTranslation, translation)
+	}
+	if gotTranscription != "lɑːrdʒ" {
+		t.Errorf("transcription = %q, want the new version's value", gotTranscription)
+	}
+
+	var edited bool
+	err = pool.QueryRow(ctx, `
+        SELECT EXISTS(SELECT 1 FROM word_example we JOIN examples e ON e.id = we.example_id
+                      WHERE we.word_id = $1 AND e.id = $2 AND e.example = $3)`, wordID, exampleID, sentence).Scan(&edited)
+	if err != nil {
+		t.Fatalf("read examples: %v", err)
+	}
+	if !edited {
+		t.Errorf("edited example %d was unlinked from the word", exampleID)
+	}
+
+	hidden := map[string]bool{}
+	rows, err := pool.Query(ctx, `
+        SELECT s.word, l.hidden FROM word_links l JOIN words s ON s.id = l.linked_word_id
+        WHERE l.word_id = $1 AND l.relation_type = 'synonym'`, wordID)
+	if err != nil {
+		t.Fatalf("read synonyms: %v", err)
+	}
+	for rows.Next() {
+		var linked string
+		var isHidden bool
+		if err := rows.Scan(&linked, &isHidden); err != nil {
+			t.Fatalf("scan synonym: %v", err)
+		}
+		hidden[linked] = isHidden
+	}
+	rows.Close()
+	if isHidden, ok := hidden[synonym]; !ok || !isHidden {
+		t.Errorf("synonym %q hidden = %v (present %v), want it to stay hidden", synonym, isHidden, ok)
+	}
+	if isHidden, ok := hidden[otherSynonym]; !ok || isHidden {
+		t.Errorf("synonym %q hidden = %v (present %v), want it visible", otherSynonym, isHidden, ok)
+	}
+}
+
+// saveVersion сохраняет версию контента слова и активирует её так же, как это делают обогащение и перегенерация
+func saveVersion(t *testing.T, pool *pgxpool.Pool, wordID int, details gpt.WordDetails) {
+	t.Helper()
+	ctx := context.Background()
+	generation := models.ContentGeneration{Model: "test", PromptVersion: gpt.PromptVersion}
+	err := repository.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		return repository.SaveWordDetails(ctx, tx, wordID, details, generation)
+	})
+	if err != nil {
+		t.Fatalf("save content version: %v", err)
+	}
+}
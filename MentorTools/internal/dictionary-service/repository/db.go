@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"MentorTools/dictionary-service/migrations"
+	"MentorTools/pkg/config"
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 func ConnectDB() *pgx.Conn {
@@ -14,3 +18,60 @@ func ConnectDB() *pgx.Conn {
 	}
 	return conn
 }
+
+// InitDB создаёт пул соединений с базой словаря по настройкам dictionary_db из файла конфигурации.
+// Если в настройках включён auto_migrate, перед возвратом пула применяются недостающие миграции схемы.
+func InitDB(ctx context.Context, configPath string) (*pgxpool.Pool, error) {
+	pool, dbConfig, err := ConnectDictionaryDB(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbConfig.AutoMigrate {
+		applied, err := migrations.Apply(ctx, pool)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		for _, migration := range applied {
+			log.Printf("Applied migration %s", migration.Name)
+		}
+	}
+	return pool, nil
+}
+
+// ConnectDictionaryDB создаёт пул соединений с базой словаря, не применяя миграции,
+// и возвращает настройки dictionary_db
+func ConnectDictionaryDB(ctx context.Context, configPath string) (*pgxpool.Pool, config.DBConfig, error) {
+	return connect(ctx, configPath, "dictionary_db")
+}
+
+// ConnectAuthDB создаёт пул соединений с базой auth_db user-service. Словарь только читает из неё
+// привязки учеников к репетиторам (см. worker.LinkSyncWorker).
+func ConnectAuthDB(ctx context.Context, configPath string) (*pgxpool.Pool, error) {
+	pool, _, err := connect(ctx, configPath, "auth_db")
+	return pool, err
+}
+
+// connect создаёт пул соединений с базой name из файла конфигурации и возвращает её настройки
+func connect(ctx context.Context, configPath, name string) (*pgxpool.Pool, config.DBConfig, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, config.DBConfig{}, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	dbConfig, ok := cfg.Databases[name]
+	if !ok {
+		return nil, dbConfig, fmt.Errorf("%s is not configured", name)
+	}
+	databaseURL := fmt.Sprintf(
+		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DBName, dbConfig.SSLMode,
+	)
+
+	pool, err := pgxpool.Connect(ctx, databaseURL)
+	if err != nil {
+		return nil, dbConfig, fmt.Errorf("unable to connect to %s: %w", name, err)
+	}
+	return pool, dbConfig, nil
+}
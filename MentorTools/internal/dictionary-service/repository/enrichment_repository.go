@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrQueueEmpty возвращается, когда в очереди обогащения нет готовых к обработке слов
+var ErrQueueEmpty = errors.New("enrichment queue is empty")
+
+// ClaimPendingWord забирает из очереди одно слово в статусе "pending" и переводит его в "processing".
+// FOR UPDATE SKIP LOCKED позволяет нескольким worker-ам разбирать очередь, не мешая друг другу.
+// Слова, зависшие в "processing" дольше staleAfter (например, после падения worker-а), забираются повторно.
+func ClaimPendingWord(ctx context.Context, dbpool *pgxpool.Pool, staleAfter time.Duration) (int, string, error) {
+	var wordID int
+	var word string
+	err := dbpool.QueryRow(ctx, `
+        UPDATE words SET status = 'processing', enrichment_started_at = now()
+        WHERE id = (
+            SELECT id FROM words
+            WHERE (status = 'pending' AND (next_attempt_at IS NULL OR next_attempt_at <= now()))
+               OR (status = 'processing' AND enrichment_started_at < now() - make_interval(secs => $1))
+            ORDER BY id
+            FOR UPDATE SKIP LOCKED
+            LIMIT 1
+        )
+        RETURNING id, word`, staleAfter.Seconds()).Scan(&wordID, &word)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", ErrQueueEmpty
+	}
+	return wordID, word, err
+}
+
+// FailWordAttempt фиксирует неудачную попытку обогащения.
+// Пока попытки не исчерпаны, слово возвращается в "pending" с экспоненциально растущей
+// задержкой (backoff, 2*backoff, 4*backoff, ...), иначе получает статус "failed".
+func FailWordAttempt(ctx context.Context, dbpool *pgxpool.Pool, wordID int, cause error, maxAttempts int, backoff time.Duration) error {
+	_, err := dbpool.Exec(ctx, `
+        UPDATE words
+        SET enrichment_attempts = enrichment_attempts + 1,
+            enrichment_error = $2,
+            status = CASE WHEN enrichment_attempts + 1 >= $3 THEN 'failed' ELSE 'pending' END,
+            next_attempt_at = now() + make_interval(secs => $4 * power(2, enrichment_attempts))
+        WHERE id = $1`, wordID, cause.Error(), maxAttempts, backoff.Seconds())
+	return err
+}
+
+// GetEnrichmentQueueStats возвращает количество слов в каждом статусе очереди обогащения
+func GetEnrichmentQueueStats(ctx context.Context, dbpool *pgxpool.Pool) (models.EnrichmentStats, error) {
+	var stats models.EnrichmentStats
+	err := dbpool.QueryRow(ctx, `
+        SELECT
+            count(*) FILTER (WHERE status = 'pending'),
+            count(*) FILTER (WHERE status = 'processing'),
+            count(*) FILTER (WHERE status = 'completed'),
+            count(*) FILTER (WHERE status = 'failed')
+        FROM words`).Scan(&stats.Pending, &stats.Processing, &stats.Completed, &stats.Failed)
+	return stats, err
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetWordsForExport возвращает слова ученика, подходящие под фильтр, вместе с примерами.
+// Примеры загружаются одним запросом для всех слов. Отклонённые примеры и контент,
+// ожидающий проверки репетитором, не выгружаются; личные примеры ученика из текстов выгружаются всегда.
+// Вместо недостающего перевода выгружается перевод из файла импорта ученика.
+func GetWordsForExport(ctx context.Context, dbpool *pgxpool.Pool, studentID int, filter models.WordFilter) ([]models.ExportWord, error) {
+	var args []interface{}
+	conditions := studentWordConditions(studentID, filter, &args)
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT w.id, w.word, COALESCE(sw.typed_form, w.word),
+               CASE WHEN w.published THEN COALESCE(w.transcription, '') ELSE '' END,
+               COALESCE(NULLIF(CASE WHEN w.published THEN w.translation END, ''), sw.translation_hint, ''),
+               CASE WHEN w.published THEN COALESCE(w.definition, '') ELSE '' END,
+               sw.status, COALESCE(sw.deck, ''), COALESCE(w.image_key, ''), COALESCE(w.image_thumb_key, '')
+        FROM words w
+        JOIN student_words sw ON w.id = sw.word_id
+        WHERE `+strings.Join(conditions, " AND ")+`
+        ORDER BY w.word`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	words := []models.ExportWord{}
+	index := make(map[int]int)
+	var ids []int
+	for rows.Next() {
+		var word models.ExportWord
+		err := rows.Scan(&word.WordID, &word.Word, &word.TypedForm, &word.Transcription,
+			&word.Translation, &word.Description, &word.Status, &word.Deck, &word.ImageKey, &word.ThumbnailKey)
+		if err != nil {
+			return nil, err
+		}
+		index[word.WordID] = len(words)
+		ids = append(ids, word.WordID)
+		words = append(words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return words, nil
+	}
+
+	exampleRows, err := dbpool.Query(ctx, `
+        SELECT we.word_id, COALESCE(e.example, ''), COALESCE(e.translation, '')
+        FROM word_example we
+        JOIN examples e ON e.id = we.example_id
+        JOIN words w ON w.id = we.word_id
+        WHERE we.word_id = ANY($1) AND NOT e.hidden
+          AND (e.student_id = $2 OR (e.student_id IS NULL AND w.published))
+        ORDER BY we.word_id, e.id`, ids, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer exampleRows.Close()
+
+	for exampleRows.Next() {
+		var wordID int
+		var example models.Example
+		if err := exampleRows.Scan(&wordID, &example.Sentence, &example.Translation); err != nil {
+			return nil, err
+		}
+		i := index[wordID]
+		words[i].Examples = append(words[i].Examples, example)
+	}
+	return words, exampleRows.Err()
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Начисление очков опыта
+const (
+	xpCorrectAnswer     = 10 // За правильный ответ
+	xpWrongAnswer       = 2  // За неправильный ответ: попытка тоже считается
+	xpStreakDay         = 5  // За день серии занятий, умноженные на номер дня в серии
+	maxStreakMultiplier = 7  // С седьмого дня серии каждый день приносит одинаково
+)
+
+// GetXP считает очки опыта учеников за период [since, until) по событиям обучения.
+// За ответы на одно слово в одном упражнении очки начисляются раз в день - по лучшему ответу,
+// чтобы повторные ответы подряд не накручивали очки. Дни серии считаются по календарю сервера
+// базы данных, как в GetLearningStats; номер дня в серии учитывает занятия и до since.
+func GetXP(ctx context.Context, dbpool *pgxpool.Pool, studentIDs []int, since, until time.Time) (map[int]models.XP, error) {
+	if studentIDs == nil {
+		studentIDs = []int{}
+	}
+	rows, err := dbpool.Query(ctx, `
+        WITH answers AS (
+            SELECT student_id, event_type, COALESCE(bool_or(correct), false) AS correct
+            FROM learning_events
+            WHERE student_id = ANY($1) AND created_at >= $2 AND created_at < $3 AND word_id IS NOT NULL
+              AND event_type IN ('reviewed', 'typed', 'quiz_answered')
+            GROUP BY student_id, word_id, event_type, created_at::date
+        ),
+        answer_xp AS (
+            SELECT student_id,
+                   sum(CASE WHEN correct THEN $4::int ELSE $5::int END) FILTER (WHERE event_type <> 'quiz_answered') AS review,
+                   sum(CASE WHEN correct THEN $4::int ELSE $5::int END) FILTER (WHERE event_type = 'quiz_answered') AS quiz
+            FROM answers
+            GROUP BY student_id
+        ),
+        days AS (
+            SELECT DISTINCT student_id, created_at::date AS day
+            FROM learning_events
+            WHERE student_id = ANY($1) AND created_at < $3
+        ),
+        streak_days AS (
+            SELECT student_id, day,
+                   row_number() OVER (PARTITION BY student_id, grp ORDER BY day) AS position
+            FROM (SELECT student_id, day, day - (row_number() OVER (PARTITION BY student_id ORDER BY day))::int AS grp
+                  FROM days) grouped
+        ),
+        streak_xp AS (
+            SELECT student_id, sum(least(position, $7::int) * $6::int) AS streak
+            FROM streak_days
+            WHERE day >= $2::timestamp::date
+            GROUP BY student_id
+        )
+        SELECT s.id, COALESCE(a.review, 0), COALESCE(a.quiz, 0), COALESCE(st.streak, 0)
+        FROM unnest($1::int[]) AS s(id)
+        LEFT JOIN answer_xp a ON a.student_id = s.id
+        LEFT JOIN streak_xp st ON st.student_id = s.id`,
+		studentIDs, since, until, xpCorrectAnswer, xpWrongAnswer, xpStreakDay, maxStreakMultiplier)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	xp := make(map[int]models.XP, len(studentIDs))
+	for rows.Next() {
+		var studentID int
+		var points models.XP
+		if err := rows.Scan(&studentID, &points.Review, &points.Quiz, &points.Streak); err != nil {
+			return nil, err
+		}
+		points.Total = points.Review + points.Quiz + points.Streak
+		xp[studentID] = points
+	}
+	return xp, rows.Err()
+}
+
+// GetLeaderboardMembers возвращает учеников, привязанных к репетитору, с их настройкой приватности.
+// Привязки и имена берутся из копии привязок user-service в таблице tutor_students.
+func GetLeaderboardMembers(ctx context.Context, dbpool *pgxpool.Pool, tutorID int) ([]models.LeaderboardMember, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT ts.student_id, ts.student_name, COALESCE(gs.show_on_leaderboard, true)
+        FROM tutor_students ts
+        LEFT JOIN student_gamification_settings gs ON gs.student_id = ts.student_id
+        WHERE ts.tutor_id = $1
+        ORDER BY ts.student_id`, tutorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.LeaderboardMember{}
+	for rows.Next() {
+		var member models.LeaderboardMember
+		if err := rows.Scan(&member.StudentID, &member.Name, &member.ShowOnLeaderboard); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// GetStudentTutor возвращает репетитора ученика; если репетиторов несколько - привязанного первым.
+// Если ученик не привязан к репетитору, возвращает ErrNotFound.
+func GetStudentTutor(ctx context.Context, dbpool *pgxpool.Pool, studentID int) (int, error) {
+	var tutorID int
+	err := dbpool.QueryRow(ctx, `
+        SELECT tutor_id FROM tutor_students WHERE student_id = $1
+        ORDER BY linked_at, tutor_id
+        LIMIT 1`, studentID).Scan(&tutorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return tutorID, err
+}
+
+// GetGamificationSettings возвращает настройки приватности ученика; если они не заданы - настройки по умолчанию
+func GetGamificationSettings(ctx context.Context, dbpool *pgxpool.Pool, studentID int) (models.GamificationSettings, error) {
+	settings := models.GamificationSettings{ShowOnLeaderboard: true}
+	err := dbpool.QueryRow(ctx, "SELECT show_on_leaderboard FROM student_gamification_settings WHERE student_id = $1", studentID).
+		Scan(&settings.ShowOnLeaderboard)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return settings, nil
+	}
+	return settings, err
+}
+
+// SaveGamificationSettings сохраняет настройки приватности ученика
+func SaveGamificationSettings(ctx context.Context, dbpool *pgxpool.Pool, studentID int, settings models.GamificationSettings) error {
+	_, err := dbpool.Exec(ctx, `
+        INSERT INTO student_gamification_settings (student_id, show_on_leaderboard) VALUES ($1, $2)
+        ON CONFLICT (student_id) DO UPDATE SET show_on_leaderboard = EXCLUDED.show_on_leaderboard, updated_at = CURRENT_TIMESTAMP`,
+		studentID, settings.ShowOnLeaderboard)
+	return err
+}
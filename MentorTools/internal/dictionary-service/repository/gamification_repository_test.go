@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Схема тестовой базы создаётся только миграциями словаря: таблиц user_links и users в ней нет,
+// поэтому тесты проверяют, что лидерборд и привязки читаются из tutor_students
+
+func TestGetLeaderboardMembers(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	tutorID, otherTutorID := dbtest.NewID(), dbtest.NewID()
+	alice, bob, carol := dbtest.NewID(), dbtest.NewID(), dbtest.NewID()
+	dbtest.LinkStudent(t, pool, tutorID, alice, "Alice")
+	dbtest.LinkStudent(t, pool, tutorID, bob, "Bob")
+	dbtest.LinkStudent(t, pool, otherTutorID, carol, "Carol")
+
+	if err := repository.SaveGamificationSettings(ctx, pool, bob, models.GamificationSettings{ShowOnLeaderboard: false}); err != nil {
+		t.Fatalf("SaveGamificationSettings: %v", err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM student_gamification_settings WHERE student_id = $1", bob) })
+
+	members, err := repository.GetLeaderboardMembers(ctx, pool, tutorID)
+	if err != nil {
+		t.Fatalf("GetLeaderboardMembers: %v", err)
+	}
+	want := []models.LeaderboardMember{
+		{StudentID: alice, Name: "Alice", ShowOnLeaderboard: true},
+		{StudentID: bob, Name: "Bob", ShowOnLeaderboard: false},
+	}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("GetLeaderboardMembers = %+v, want %+v", members, want)
+	}
+}
+
+func TestGetStudentTutor(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	firstTutor, secondTutor, studentID := dbtest.NewID(), dbtest.NewID(), dbtest.NewID()
+	dbtest.LinkStudent(t, pool, secondTutor, studentID, "Student")
+	dbtest.LinkStudent(t, pool, firstTutor, studentID, "Student")
+	dbtest.Exec(t, pool, "UPDATE tutor_students SET linked_at = linked_at - interval '1 day' WHERE tutor_id = $1", firstTutor)
+
+	tutorID, err := repository.GetStudentTutor(ctx, pool, studentID)
+	if err != nil {
+		t.Fatalf("GetStudentTutor: %v", err)
+	}
+	if tutorID != firstTutor {
+		t.Errorf("GetStudentTutor = %d, want first linked tutor %d", tutorID, firstTutor)
+	}
+
+	if _, err := repository.GetStudentTutor(ctx, pool, dbtest.NewID()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetStudentTutor for unlinked student: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCanAccessStudent(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	tutorID, otherTutorID, studentID := dbtest.NewID(), dbtest.NewID(), dbtest.NewID()
+	dbtest.LinkStudent(t, pool, tutorID, studentID, "Student")
+
+	tests := []struct {
+		name   string
+		userID int
+		want   bool
+	}{
+		{"student", studentID, true},
+		{"linked tutor", tutorID, true},
+		{"other tutor", otherTutorID, false},
+	}
+	for _, tt := range tests {
+		got, err := repository.CanAccessStudent(ctx, pool, tt.userID, studentID)
+		if err != nil {
+			t.Fatalf("%s: CanAccessStudent: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: CanAccessStudent = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
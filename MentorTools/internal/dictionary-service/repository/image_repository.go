@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetWordForImage возвращает слово, его перевод и язык слова - всё, что нужно для описания картинки.
+// Если слова нет, возвращает ErrNotFound.
+func GetWordForImage(ctx context.Context, dbpool *pgxpool.Pool, wordID int) (word, translation, language string, err error) {
+	err = dbpool.QueryRow(ctx, "SELECT word, COALESCE(translation, ''), source_lang FROM words WHERE id = $1", wordID).
+		Scan(&word, &translation, &language)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrNotFound
+	}
+	return word, translation, language, err
+}
+
+// SetWordImage сохраняет картинку слова, заменяя прежнюю; если слова нет, возвращает ErrNotFound.
+// Файлы прежней картинки остаются в хранилище: по ключу из содержимого на них могут ссылаться другие слова.
+func SetWordImage(ctx context.Context, dbpool *pgxpool.Pool, image models.WordImage, updatedBy int) error {
+	tag, err := dbpool.Exec(ctx, `
+        UPDATE words
+        SET image_key = $2, image_thumb_key = $3, image_source = $4, image_updated_by = $5, image_updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, image.WordID, image.Key, image.ThumbnailKey, image.Source, updatedBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWordImage убирает картинку у слова; если слова нет, возвращает ErrNotFound
+func DeleteWordImage(ctx context.Context, dbpool *pgxpool.Pool, wordID, updatedBy int) error {
+	tag, err := dbpool.Exec(ctx, `
+        UPDATE words
+        SET image_key = NULL, image_thumb_key = NULL, image_source = NULL, image_updated_by = $2, image_updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, wordID, updatedBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// rowQuerier - пул соединений или транзакция, из которых читается одна строка
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// GetStudentLanguagePair возвращает языковую пару ученика; если она не задана, возвращает пару по умолчанию
+func GetStudentLanguagePair(ctx context.Context, dbpool *pgxpool.Pool, studentID int) (models.LanguagePair, error) {
+	var pair models.LanguagePair
+	err := dbpool.QueryRow(ctx, "SELECT source_lang, target_lang FROM student_languages WHERE student_id = $1",
+		studentID).Scan(&pair.Source, &pair.Target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DefaultLanguagePair, nil
+	}
+	return pair, err
+}
+
+// SetStudentLanguagePair сохраняет языковую пару ученика
+func SetStudentLanguagePair(ctx context.Context, dbpool *pgxpool.Pool, studentID int, pair models.LanguagePair) error {
+	_, err := dbpool.Exec(ctx, `
+        INSERT INTO student_languages (student_id, source_lang, target_lang, updated_at) VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+        ON CONFLICT (student_id) DO UPDATE
+        SET source_lang = EXCLUDED.source_lang, target_lang = EXCLUDED.target_lang, updated_at = EXCLUDED.updated_at`,
+		studentID, pair.Source, pair.Target)
+	return err
+}
+
+// GetWordLanguagePair возвращает языковую пару слова; если слова нет, возвращает ErrNotFound
+func GetWordLanguagePair(ctx context.Context, db rowQuerier, wordID int) (models.LanguagePair, error) {
+	var pair models.LanguagePair
+	err := db.QueryRow(ctx, "SELECT source_lang, target_lang FROM words WHERE id = $1", wordID).Scan(&pair.Source, &pair.Target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pair, ErrNotFound
+	}
+	return pair, err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RecordLearningEvent записывает событие обучения ученика
+func RecordLearningEvent(ctx context.Context, tx pgx.Tx, event models.LearningEvent) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO learning_events (student_id, word_id, event_type, correct, old_status, new_status)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))`,
+		event.StudentID, event.WordID, event.Type, event.Correct, event.OldStatus, event.NewStatus)
+	return err
+}
+
+// GetReviewStateForUpdate возвращает статус слова ученика, текущий интервал повторения
+// и серию правильных ответов, блокируя строку до конца транзакции
+func GetReviewStateForUpdate(ctx context.Context, tx pgx.Tx, studentID, wordID int) (string, int, int, error) {
+	var status string
+	var interval, streak int
+	err := tx.QueryRow(ctx, `
+        SELECT status, review_interval_days, correct_streak FROM student_words
+        WHERE student_id = $1 AND word_id = $2 FOR UPDATE`, studentID, wordID).Scan(&status, &interval, &streak)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", 0, 0, ErrNotFound
+	}
+	return status, interval, streak, err
+}
+
+// SaveReviewState сохраняет результат повторения и назначает следующее повторение через delay
+func SaveReviewState(ctx context.Context, tx pgx.Tx, studentID, wordID int, status string, interval, streak int, delay time.Duration) (time.Time, error) {
+	var nextReviewAt time.Time
+	err := tx.QueryRow(ctx, `
+        UPDATE student_words
+        SET status = $3, review_interval_days = $4, correct_streak = $5, next_review_at = now() + $6::interval
+        WHERE student_id = $1 AND word_id = $2
+        RETURNING next_review_at`, studentID, wordID, status, interval, streak, delay).Scan(&nextReviewAt)
+	return nextReviewAt, err
+}
+
+// SetStudentWordStatus меняет статус слова ученика
+func SetStudentWordStatus(ctx context.Context, tx pgx.Tx, studentID, wordID int, status string) error {
+	_, err := tx.Exec(ctx, "UPDATE student_words SET status = $3 WHERE student_id = $1 AND word_id = $2", studentID, wordID, status)
+	return err
+}
+
+// GetLearningStats считает сводную статистику ученика по словарю и событиям обучения.
+// Дни для серий считаются по календарю сервера базы данных.
+func GetLearningStats(ctx context.Context, dbpool *pgxpool.Pool, studentID int) (models.LearningStats, error) {
+	var stats models.LearningStats
+
+	err := dbpool.QueryRow(ctx, `
+        SELECT count(*),
+               count(*) FILTER (WHERE status = 'learned'),
+               count(*) FILTER (WHERE next_review_at <= now())
+        FROM student_words
+        WHERE student_id = $1`, studentID).Scan(&stats.TotalWords, &stats.LearnedWords, &stats.DueWords)
+	if err != nil {
+		return stats, err
+	}
+
+	// Серии: дни подряд, в которые было хотя бы одно событие
+	err = dbpool.QueryRow(ctx, `
+        WITH days AS (
+            SELECT DISTINCT created_at::date AS day FROM learning_events WHERE student_id = $1
+        ),
+        groups AS (
+            SELECT day, day - (row_number() OVER (ORDER BY day))::int AS grp FROM days
+        ),
+        streaks AS (
+            SELECT max(day) AS last_day, count(*) AS length FROM groups GROUP BY grp
+        )
+        SELECT COALESCE(max(length) FILTER (WHERE last_day >= current_date - 1), 0),
+               COALESCE(max(length), 0)
+        FROM streaks`, studentID).Scan(&stats.CurrentStreak, &stats.LongestStreak)
+	if err != nil {
+		return stats, err
+	}
+
+	err = dbpool.QueryRow(ctx, `
+        SELECT (count(*) FILTER (WHERE correct))::float8 / NULLIF(count(*), 0)
+        FROM learning_events
+        WHERE student_id = $1 AND event_type IN ('reviewed', 'quiz_answered', 'typed') AND correct IS NOT NULL
+          AND created_at >= now() - interval '30 days'`, studentID).Scan(&stats.RetentionRate)
+	if err != nil {
+		return stats, err
+	}
+
+	// Время до освоения: от добавления слова до первого перехода в статус "learned"
+	err = dbpool.QueryRow(ctx, `
+        WITH mastered AS (
+            SELECT greatest(EXTRACT(EPOCH FROM min(e.created_at) - sw.added_at) / 3600, 0) AS hours
+            FROM student_words sw
+            JOIN learning_events e ON e.student_id = sw.student_id AND e.word_id = sw.word_id
+            WHERE sw.student_id = $1 AND e.event_type = 'status_changed' AND e.new_status = 'learned'
+            GROUP BY sw.word_id, sw.added_at
+        )
+        SELECT avg(hours)::float8, (percentile_cont(0.5) WITHIN GROUP (ORDER BY hours))::float8
+        FROM mastered`, studentID).Scan(&stats.AvgHoursToMastery, &stats.MedianHoursToMastery)
+	return stats, err
+}
+
+// GetLearningActivity возвращает количество добавленных, повторённых и выученных слов
+// по дням или неделям (period - "day" или "week"), начиная с since
+func GetLearningActivity(ctx context.Context, dbpool *pgxpool.Pool, studentID int, period string, since time.Time) ([]models.ActivityPoint, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT date_trunc($2::text, created_at) AS period,
+               count(*) FILTER (WHERE event_type = 'status_changed' AND new_status = 'learned'),
+               count(*) FILTER (WHERE event_type IN ('reviewed', 'quiz_answered', 'typed')),
+               count(*) FILTER (WHERE event_type = 'added')
+        FROM learning_events
+        WHERE student_id = $1 AND created_at >= $3
+        GROUP BY 1
+        ORDER BY 1`, studentID, period, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []models.ActivityPoint{}
+	for rows.Next() {
+		var point models.ActivityPoint
+		if err := rows.Scan(&point.Period, &point.Learned, &point.Reviewed, &point.Added); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// GetTopicStats возвращает прогресс ученика по темам: слово относится к теме,
+// если хотя бы один его пример сгенерирован в контексте этой темы
+func GetTopicStats(ctx context.Context, dbpool *pgxpool.Pool, studentID int) ([]models.TopicStats, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT t.id, t.topic_name,
+               count(DISTINCT sw.word_id),
+               count(DISTINCT sw.word_id) FILTER (WHERE sw.status = 'learned')
+        FROM topics t
+        JOIN examples e ON lower(e.context) = lower(t.topic_name)
+        JOIN word_example we ON we.example_id = e.id
+        JOIN student_words sw ON sw.word_id = we.word_id AND sw.student_id = $1
+        GROUP BY t.id, t.topic_name
+        ORDER BY 3 DESC, t.topic_name`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []models.TopicStats{}
+	for rows.Next() {
+		var topic models.TopicStats
+		if err := rows.Scan(&topic.TopicID, &topic.Topic, &topic.Words, &topic.Learned); err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	return topics, rows.Err()
+}
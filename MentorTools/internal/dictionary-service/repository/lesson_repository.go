@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// lessonDateLayout - формат дня урока
+const lessonDateLayout = "2006-01-02"
+
+// GetLessonSettings возвращает настройки урока ученика или настройки по умолчанию, если ученик их не менял
+func GetLessonSettings(ctx context.Context, db rowQuerier, studentID int) (models.LessonSettings, error) {
+	var settings models.LessonSettings
+	err := db.QueryRow(ctx, `
+        SELECT timezone, daily_goal, new_words, lesson_hour, decks
+        FROM student_lesson_settings WHERE student_id = $1`, studentID).Scan(
+		&settings.Timezone, &settings.DailyGoal, &settings.NewWords, &settings.LessonHour, &settings.Decks)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DefaultLessonSettings, nil
+	}
+	if settings.Decks == nil {
+		settings.Decks = []string{}
+	}
+	return settings, err
+}
+
+// SaveLessonSettings сохраняет настройки урока ученика; updatedBy - ученик или репетитор, изменивший их
+func SaveLessonSettings(ctx context.Context, tx pgx.Tx, studentID int, settings models.LessonSettings, updatedBy int) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO student_lesson_settings (student_id, timezone, daily_goal, new_words, lesson_hour, decks, updated_by, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+        ON CONFLICT (student_id) DO UPDATE
+        SET timezone = EXCLUDED.timezone, daily_goal = EXCLUDED.daily_goal, new_words = EXCLUDED.new_words,
+            lesson_hour = EXCLUDED.lesson_hour, decks = EXCLUDED.decks,
+            updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`,
+		studentID, settings.Timezone, settings.DailyGoal, settings.NewWords, settings.LessonHour, settings.Decks, updatedBy)
+	return err
+}
+
+// StudentsWithoutLesson возвращает до limit учеников, у которых в момент now по их местному времени уже наступил
+// час урока, а урока на этот день ещё нет. Ученики без настроек считаются по настройкам по умолчанию.
+// Момент передаётся из Go, чтобы день урока считался так же, как в services.GenerateDailyLesson,
+// а не по часам и часовому поясу сервера базы.
+func StudentsWithoutLesson(ctx context.Context, dbpool *pgxpool.Pool, now time.Time, limit int) ([]int, error) {
+	defaults := models.DefaultLessonSettings
+	rows, err := dbpool.Query(ctx, `
+        SELECT s.student_id
+        FROM (SELECT sw.student_id,
+                     $4::timestamptz AT TIME ZONE COALESCE(ls.timezone, $1) AS local_now,
+                     COALESCE(ls.lesson_hour, $2) AS lesson_hour
+              FROM (SELECT DISTINCT student_id FROM student_words) sw
+              LEFT JOIN student_lesson_settings ls ON ls.student_id = sw.student_id) s
+        WHERE EXTRACT(HOUR FROM s.local_now) >= s.lesson_hour
+          AND NOT EXISTS (SELECT 1 FROM daily_lessons l
+                          WHERE l.student_id = s.student_id AND l.lesson_date = s.local_now::date)
+        ORDER BY s.student_id
+        LIMIT $3`, defaults.Timezone, defaults.LessonHour, limit, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []int
+	for rows.Next() {
+		var studentID int
+		if err := rows.Scan(&studentID); err != nil {
+			return nil, err
+		}
+		students = append(students, studentID)
+	}
+	return students, rows.Err()
+}
+
+// PickNewLessonWords выбирает слова ученика, на которые он ещё ни разу не отвечал, в порядке добавления.
+// decks ограничивает выбор колодами, если не пуст. Берутся только слова с опубликованным переводом.
+func PickNewLessonWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, decks []string, limit int) ([]int, error) {
+	if decks == nil {
+		decks = []string{}
+	}
+	return queryWordIDs(ctx, dbpool, `
+        SELECT w.id
+        FROM student_words sw
+        JOIN words w ON w.id = sw.word_id
+        WHERE sw.student_id = $1 AND sw.status = 'need to learn' AND (cardinality($2::text[]) = 0 OR sw.deck = ANY($2))
+          AND w.status = 'completed' AND w.published AND COALESCE(w.translation, '') <> ''
+          AND NOT EXISTS (SELECT 1 FROM learning_events e
+                          WHERE e.student_id = sw.student_id AND e.word_id = sw.word_id
+                            AND e.event_type IN ('reviewed', 'quiz_answered', 'typed'))
+        ORDER BY sw.added_at, w.id
+        LIMIT $3`, studentID, decks, limit)
+}
+
+// PickDueLessonWords выбирает слова ученика, которые пора повторить до before, начиная с самых просроченных.
+// Слова exclude уже есть в уроке и не выбираются.
+func PickDueLessonWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, before time.Time, exclude []int, limit int) ([]int, error) {
+	if exclude == nil {
+		exclude = []int{}
+	}
+	return queryWordIDs(ctx, dbpool, `
+        SELECT w.id
+        FROM student_words sw
+        JOIN words w ON w.id = sw.word_id
+        WHERE sw.student_id = $1 AND sw.next_review_at < $2 AND NOT (w.id = ANY($3::int[]))
+          AND w.status = 'completed' AND w.published AND COALESCE(w.translation, '') <> ''
+        ORDER BY sw.next_review_at, w.id
+        LIMIT $4`, studentID, before, exclude, limit)
+}
+
+// PickQuizLessonWords выбирает случайные слова ученика для вопросов теста, кроме слов exclude
+func PickQuizLessonWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, exclude []int, limit int) ([]int, error) {
+	if exclude == nil {
+		exclude = []int{}
+	}
+	return queryWordIDs(ctx, dbpool, `
+        SELECT w.id
+        FROM student_words sw
+        JOIN words w ON w.id = sw.word_id
+        WHERE sw.student_id = $1 AND NOT (w.id = ANY($2::int[]))
+          AND w.status = 'completed' AND w.published AND COALESCE(w.translation, '') <> ''
+        ORDER BY random()
+        LIMIT $3`, studentID, exclude, limit)
+}
+
+// GetQuizOptions возвращает перевод слова и до count случайных переводов других слов той же языковой пары
+// для вариантов ответа
+func GetQuizOptions(ctx context.Context, dbpool *pgxpool.Pool, wordID, count int) (string, []string, error) {
+	var translation string
+	err := dbpool.QueryRow(ctx, "SELECT COALESCE(translation, '') FROM words WHERE id = $1", wordID).Scan(&translation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil, ErrNotFound
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT d.translation
+        FROM (SELECT DISTINCT o.translation
+              FROM words w
+              JOIN words o ON o.source_lang = w.source_lang AND o.target_lang = w.target_lang
+              WHERE w.id = $1 AND o.id <> w.id AND o.status = 'completed' AND o.published
+                AND COALESCE(o.translation, '') NOT IN ('', $2)) d
+        ORDER BY random()
+        LIMIT $3`, wordID, translation, count)
+	if err != nil {
+		return "", nil, err
+	}
+	defer rows.Close()
+
+	var distractors []string
+	for rows.Next() {
+		var option string
+		if err := rows.Scan(&option); err != nil {
+			return "", nil, err
+		}
+		distractors = append(distractors, option)
+	}
+	return translation, distractors, rows.Err()
+}
+
+// PickWordOfTheDay возвращает самое частотное опубликованное слово языковой пары, которого ещё нет у ученика
+// и которое ещё не было у него словом дня; если такого слова нет, возвращает ErrNotFound
+func PickWordOfTheDay(ctx context.Context, dbpool *pgxpool.Pool, studentID int, pair models.LanguagePair) (int, error) {
+	var wordID int
+	err := dbpool.QueryRow(ctx, `
+        SELECT w.id
+        FROM words w
+        WHERE w.source_lang = $2 AND w.target_lang = $3
+          AND w.status = 'completed' AND w.published AND COALESCE(w.translation, '') <> ''
+          AND NOT EXISTS (SELECT 1 FROM student_words sw WHERE sw.student_id = $1 AND sw.word_id = w.id)
+          AND NOT EXISTS (SELECT 1 FROM daily_lessons l WHERE l.student_id = $1 AND l.word_of_the_day_id = w.id)
+        ORDER BY w.frequency_rank NULLS LAST, w.id
+        LIMIT 1`, studentID, pair.Source, pair.Target).Scan(&wordID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return wordID, err
+}
+
+// CreateDailyLesson сохраняет урок на день. Если урок на этот день уже есть (например, его одновременно
+// составили планировщик и запрос ученика), ничего не меняет и возвращает false.
+func CreateDailyLesson(ctx context.Context, tx pgx.Tx, plan models.LessonPlan) (bool, error) {
+	var lessonID int
+	err := tx.QueryRow(ctx, `
+        INSERT INTO daily_lessons (student_id, lesson_date, timezone, daily_goal, word_of_the_day_id)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (student_id, lesson_date) DO NOTHING
+        RETURNING id`, plan.StudentID, plan.Date.Format(lessonDateLayout), plan.Timezone, plan.DailyGoal, plan.WordOfTheDayID).Scan(&lessonID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for i, item := range plan.Items {
+		var answerIndex *int
+		if item.Kind == models.LessonItemQuiz {
+			answerIndex = &plan.Items[i].AnswerIndex
+		}
+		_, err := tx.Exec(ctx, `
+            INSERT INTO daily_lesson_items (lesson_id, position, kind, word_id, options, answer_index)
+            VALUES ($1, $2, $3, $4, $5, $6)`, lessonID, i+1, item.Kind, item.WordID, item.Options, answerIndex)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// GetDailyLesson возвращает урок ученика на день date; если урока нет, возвращает ErrNotFound.
+// Задание считается выполненным, если после составления урока ученик ответил на слово:
+// вопрос теста - в тесте, остальные задания - в любом упражнении.
+func GetDailyLesson(ctx context.Context, dbpool *pgxpool.Pool, studentID int, date time.Time) (models.DailyLesson, error) {
+	lesson := models.DailyLesson{Items: []models.LessonItem{}}
+	var lessonDate time.Time
+	var wordOfTheDayID *int
+	err := dbpool.QueryRow(ctx, `
+        SELECT id, lesson_date, timezone, daily_goal, word_of_the_day_id, created_at
+        FROM daily_lessons WHERE student_id = $1 AND lesson_date = $2`, studentID, date.Format(lessonDateLayout)).Scan(
+		&lesson.ID, &lessonDate, &lesson.Timezone, &lesson.DailyGoal, &wordOfTheDayID, &lesson.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return lesson, ErrNotFound
+	}
+	if err != nil {
+		return lesson, err
+	}
+	lesson.Date = lessonDate.Format(lessonDateLayout)
+
+	if wordOfTheDayID != nil {
+		word, err := getLessonWord(ctx, dbpool, *wordOfTheDayID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return lesson, err
+		}
+		if err == nil {
+			lesson.WordOfTheDay = &word
+		}
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT i.kind, w.id, w.word, COALESCE(w.transcription, ''), COALESCE(w.translation, ''), COALESCE(w.audio_key, ''),
+               i.options, i.answer_index,
+               EXISTS (SELECT 1 FROM learning_events e
+                       WHERE e.student_id = l.student_id AND e.word_id = i.word_id AND e.created_at >= l.created_at
+                         AND e.event_type = ANY(CASE WHEN i.kind = 'quiz' THEN ARRAY['quiz_answered']
+                                                     ELSE ARRAY['reviewed', 'quiz_answered', 'typed'] END))
+        FROM daily_lesson_items i
+        JOIN daily_lessons l ON l.id = i.lesson_id
+        JOIN words w ON w.id = i.word_id
+        WHERE i.lesson_id = $1
+        ORDER BY i.position`, lesson.ID)
+	if err != nil {
+		return lesson, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.LessonItem
+		var options []string
+		var answerIndex *int
+		err := rows.Scan(&item.Kind, &item.Word.ID, &item.Word.Word, &item.Word.Transcription, &item.Word.Translation,
+			&item.Word.AudioKey, &options, &answerIndex, &item.Done)
+		if err != nil {
+			return lesson, err
+		}
+		if answerIndex != nil {
+			item.Quiz = &models.LessonQuiz{Options: options, AnswerIndex: *answerIndex}
+		}
+		if item.Done {
+			lesson.Done++
+		}
+		lesson.Items = append(lesson.Items, item)
+	}
+	return lesson, rows.Err()
+}
+
+// getLessonWord возвращает слово урока; если слова нет, возвращает ErrNotFound
+func getLessonWord(ctx context.Context, db rowQuerier, wordID int) (models.LessonWord, error) {
+	var word models.LessonWord
+	err := db.QueryRow(ctx, `
+        SELECT id, word, COALESCE(transcription, ''), COALESCE(translation, ''), COALESCE(audio_key, '')
+        FROM words WHERE id = $1`, wordID).Scan(&word.ID, &word.Word, &word.Transcription, &word.Translation, &word.AudioKey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return word, ErrNotFound
+	}
+	return word, err
+}
+
+// queryWordIDs выполняет запрос, который возвращает ID слов
+func queryWordIDs(ctx context.Context, dbpool *pgxpool.Pool, sql string, args ...interface{}) ([]int, error) {
+	rows, err := dbpool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wordIDs []int
+	for rows.Next() {
+		var wordID int
+		if err := rows.Scan(&wordID); err != nil {
+			return nil, err
+		}
+		wordIDs = append(wordIDs, wordID)
+	}
+	return wordIDs, rows.Err()
+}
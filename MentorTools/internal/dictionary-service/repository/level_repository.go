@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Группы профиля словаря для слов без уровня и без ранга
+const (
+	levelUnrated = "unrated"
+	bandUnranked = "unranked"
+)
+
+// profileBands - частотные полосы профиля словаря от частых слов к редким
+var profileBands = []string{
+	frequency.BandTop1000, frequency.BandTop3000, frequency.BandTop5000, frequency.BandTop10000, frequency.BandRare, bandUnranked,
+}
+
+// SetWordLevel сохраняет уровень CEFR и частотный ранг слова
+func SetWordLevel(ctx context.Context, tx pgx.Tx, wordID int, level models.WordLevel) error {
+	_, err := tx.Exec(ctx, "UPDATE words SET cefr_level = $2, frequency_rank = $3, level_source = $4, level_error = NULL WHERE id = $1",
+		wordID, level.Level, level.FrequencyRank, level.Source)
+	return err
+}
+
+// NextUntaggedWord возвращает заполненное слово без уровня с наименьшим числом неудачных попыток;
+// слова, у которых попыток maxAttempts или больше, пропускаются. Если таких слов нет, возвращает ErrQueueEmpty.
+func NextUntaggedWord(ctx context.Context, dbpool *pgxpool.Pool, maxAttempts int) (models.UntaggedWord, error) {
+	var word models.UntaggedWord
+	err := dbpool.QueryRow(ctx, `
+        SELECT id, word, source_lang, target_lang
+        FROM words
+        WHERE status = 'completed' AND cefr_level IS NULL AND level_attempts < $1
+        ORDER BY level_attempts, id
+        LIMIT 1`, maxAttempts).Scan(&word.ID, &word.Word, &word.Languages.Source, &word.Languages.Target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return word, ErrQueueEmpty
+	}
+	return word, err
+}
+
+// FailLevelAttempt фиксирует неудачную попытку определить уровень слова
+func FailLevelAttempt(ctx context.Context, dbpool *pgxpool.Pool, wordID int, cause error) error {
+	_, err := dbpool.Exec(ctx, "UPDATE words SET level_attempts = level_attempts + 1, level_error = $2 WHERE id = $1",
+		wordID, cause.Error())
+	return err
+}
+
+// FindUntaggedWords возвращает заполненные слова без уровня по возрастанию ID после afterID
+func FindUntaggedWords(ctx context.Context, dbpool *pgxpool.Pool, afterID, limit int) ([]models.UntaggedWord, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT id, word, source_lang, target_lang
+        FROM words
+        WHERE status = 'completed' AND cefr_level IS NULL AND id > $1
+        ORDER BY id
+        LIMIT $2`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []models.UntaggedWord
+	for rows.Next() {
+		var word models.UntaggedWord
+		if err := rows.Scan(&word.ID, &word.Word, &word.Languages.Source, &word.Languages.Target); err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// GetVocabularyProfile распределяет словарь ученика по уровням CEFR и частотным полосам.
+// В профиль попадают все уровни и полосы, в том числе пустые, от простых к сложным.
+func GetVocabularyProfile(ctx context.Context, dbpool *pgxpool.Pool, studentID int) (models.VocabularyProfile, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT COALESCE(w.cefr_level, ''), w.frequency_rank, count(*), count(*) FILTER (WHERE sw.status = 'learned')
+        FROM student_words sw
+        JOIN words w ON w.id = sw.word_id
+        WHERE sw.student_id = $1
+        GROUP BY 1, 2`, studentID)
+	if err != nil {
+		return models.VocabularyProfile{}, err
+	}
+	defer rows.Close()
+
+	levels := make(map[string]*models.LevelStats)
+	bands := make(map[string]*models.LevelStats)
+	profile := models.VocabularyProfile{}
+	for _, level := range append(append([]string{}, models.CEFRLevels...), levelUnrated) {
+		profile.Levels = append(profile.Levels, models.LevelStats{Level: level})
+	}
+	for _, band := range profileBands {
+		profile.Bands = append(profile.Bands, models.LevelStats{Level: band})
+	}
+	for i := range profile.Levels {
+		levels[profile.Levels[i].Level] = &profile.Levels[i]
+	}
+	for i := range profile.Bands {
+		bands[profile.Bands[i].Level] = &profile.Bands[i]
+	}
+
+	for rows.Next() {
+		var level string
+		var rank *int
+		var total, learned int
+		if err := rows.Scan(&level, &rank, &total, &learned); err != nil {
+			return profile, err
+		}
+
+		if level == "" {
+			level = levelUnrated
+		}
+		band := bandUnranked
+		if rank != nil {
+			band = frequency.Band(*rank)
+		}
+		for _, stats := range []*models.LevelStats{levels[level], bands[band]} {
+			if stats != nil {
+				stats.TotalWords += total
+				stats.LearnedWords += learned
+			}
+		}
+	}
+	return profile, rows.Err()
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// CanAccessStudent проверяет доступ пользователя к данным ученика: ученик имеет доступ к своим данным,
+// репетитор - к данным привязанных к нему учеников
+func CanAccessStudent(ctx context.Context, db rowQuerier, userID, studentID int) (bool, error) {
+	if userID == studentID {
+		return true, nil
+	}
+
+	var linked bool
+	err := db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tutor_students WHERE tutor_id = $1 AND student_id = $2)", userID, studentID).
+		Scan(&linked)
+	return linked, err
+}
+
+// ListUserLinks читает все привязки учеников к репетиторам и имена учеников из базы auth_db user-service
+func ListUserLinks(ctx context.Context, authdb *pgxpool.Pool) ([]models.TutorStudent, error) {
+	rows, err := authdb.Query(ctx, `
+        SELECT ul.teacher_id, ul.student_id, COALESCE(u.name, ''), COALESCE(min(ul.created_at), now())
+        FROM user_links ul
+        JOIN users u ON u.id = ul.student_id
+        GROUP BY ul.teacher_id, ul.student_id, u.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []models.TutorStudent
+	for rows.Next() {
+		var link models.TutorStudent
+		if err := rows.Scan(&link.TutorID, &link.StudentID, &link.StudentName, &link.LinkedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// ReplaceTutorStudents заменяет копию привязок в базе словаря на links: новые привязки добавляет,
+// имена учеников обновляет, а привязки, которых больше нет в user-service, удаляет
+func ReplaceTutorStudents(ctx context.Context, tx pgx.Tx, links []models.TutorStudent) error {
+	tutorIDs := make([]int, len(links))
+	studentIDs := make([]int, len(links))
+	names := make([]string, len(links))
+	linkedAt := make([]time.Time, len(links))
+	for i, link := range links {
+		tutorIDs[i], studentIDs[i], names[i], linkedAt[i] = link.TutorID, link.StudentID, link.StudentName, link.LinkedAt
+	}
+
+	_, err := tx.Exec(ctx, `
+        INSERT INTO tutor_students (tutor_id, student_id, student_name, linked_at, synced_at)
+        SELECT l.tutor_id, l.student_id, l.student_name, l.linked_at, CURRENT_TIMESTAMP
+        FROM unnest($1::int[], $2::int[], $3::text[], $4::timestamp[]) AS l(tutor_id, student_id, student_name, linked_at)
+        ON CONFLICT (tutor_id, student_id) DO UPDATE
+        SET student_name = EXCLUDED.student_name, linked_at = EXCLUDED.linked_at, synced_at = EXCLUDED.synced_at`,
+		tutorIDs, studentIDs, names, linkedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM tutor_students t
+        WHERE NOT EXISTS (SELECT 1 FROM unnest($1::int[], $2::int[]) AS l(tutor_id, student_id)
+                          WHERE l.tutor_id = t.tutor_id AND l.student_id = t.student_id)`, tutorIDs, studentIDs)
+	return err
+}
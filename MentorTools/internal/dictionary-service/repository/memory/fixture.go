@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"MentorTools/dictionary-service/models"
+	"time"
+)
+
+// Ученик и репетитор словаря NewFixtureStore
+const (
+	FixtureStudentID = 1
+	FixtureTutorID   = 2
+)
+
+// NewFixtureStore создаёт словарь из двух слов ученика FixtureStudentID в колоде "fruit":
+//   - 10 "apple" (A1, нужно выучить) - опубликовано, с аудио, синонимом "pome", общим примером 100,
+//     примером 101 темы 5 "Food", личным примером ученика 102 и примером 103 другого ученика;
+//   - 11 "pear" (A2, выучено, добавлено на час позже) - ждёт проверки репетитором, с синонимом "pyrus".
+//
+// Ученик привязан к репетитору FixtureTutorID.
+func NewFixtureStore() *Store {
+	store := NewStore()
+	added := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store.AddWord(models.WordDetails{
+		ID: 10, Word: "apple", Translation: "яблоко", Published: true, AudioKey: "audio/apple.mp3",
+		Synonyms: []string{"pome"},
+		Examples: []models.Example{{ID: 100, Sentence: "An apple a day."}},
+	})
+	store.AddWord(models.WordDetails{ID: 11, Word: "pear", Translation: "груша", Synonyms: []string{"pyrus"}})
+	store.AddTopic(5, "Food")
+	store.AddTopicExample(10, "Food", models.Example{ID: 101, Sentence: "I eat an apple."})
+	store.AddStudentExample(10, FixtureStudentID, models.Example{ID: 102, Sentence: "My apple."})
+	store.AddStudentExample(10, 99, models.Example{ID: 103, Sentence: "Someone else's apple."})
+
+	store.AddStudentWord(FixtureStudentID, models.StudentWord{
+		ID: 10, Word: "apple", Status: models.WordStatusNeedToLearn, Deck: "fruit", Level: "A1", AddedAt: added,
+	})
+	store.AddStudentWord(FixtureStudentID, models.StudentWord{
+		ID: 11, Word: "pear", Status: models.WordStatusLearned, Deck: "fruit", Level: "A2", AddedAt: added.Add(time.Hour),
+	})
+	store.LinkStudent(FixtureTutorID, FixtureStudentID)
+	return store
+}
@@ -0,0 +1,324 @@
+// Package memory - репозитории словаря в памяти для тестов обработчиков и сервисов без PostgreSQL.
+package memory
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// example - пример слова; studentID = 0 у общих примеров, context - тема, для которой пример написан
+type example struct {
+	models.Example
+	studentID int
+	context   string
+	hidden    bool
+}
+
+// Store реализует WordRepository, ExampleRepository и StudentWordRepository в памяти.
+// Фильтры словаря ученика работают так же, как в repository.ListStudentWords: тема слова (WordFilter.TopicID)
+// ищется по темам примеров, добавленных через AddTopicExample.
+type Store struct {
+	mu           sync.Mutex
+	now          func() time.Time
+	words        map[int]models.WordDetails
+	synonyms     map[int][]string
+	relations    map[int][]models.WordRelation
+	examples     map[int][]example
+	studentWords map[int]map[int]models.StudentWord
+	links        map[[2]int]bool // Пары (репетитор, ученик)
+	topics       map[int]string  // Названия тем по ID
+	events       []models.LearningEvent
+}
+
+var (
+	_ repository.WordRepository        = (*Store)(nil)
+	_ repository.ExampleRepository     = (*Store)(nil)
+	_ repository.StudentWordRepository = (*Store)(nil)
+)
+
+// NewStore создаёт пустое хранилище
+func NewStore() *Store {
+	return &Store{
+		now:          time.Now,
+		words:        make(map[int]models.WordDetails),
+		synonyms:     make(map[int][]string),
+		relations:    make(map[int][]models.WordRelation),
+		examples:     make(map[int][]example),
+		studentWords: make(map[int]map[int]models.StudentWord),
+		links:        make(map[[2]int]bool),
+		topics:       make(map[int]string),
+	}
+}
+
+// AddWord добавляет слово в общий словарь; синонимы, связи и примеры из word сохраняются отдельно
+func (s *Store) AddWord(word models.WordDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.synonyms[word.ID] = append([]string(nil), word.Synonyms...)
+	s.relations[word.ID] = append([]models.WordRelation(nil), word.Relations...)
+	for _, e := range word.Examples {
+		s.examples[word.ID] = append(s.examples[word.ID], example{Example: e})
+	}
+	word.Synonyms, word.Relations, word.Examples = nil, nil, nil
+	s.words[word.ID] = word
+}
+
+// AddStudentExample добавляет личный пример ученика к слову
+func (s *Store) AddStudentExample(wordID, studentID int, e models.Example) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.examples[wordID] = append(s.examples[wordID], example{Example: e, studentID: studentID})
+}
+
+// AddTopic добавляет тему примеров
+func (s *Store) AddTopic(topicID int, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topics[topicID] = name
+}
+
+// AddTopicExample добавляет общий пример слова, написанный для темы с названием topic
+func (s *Store) AddTopicExample(wordID int, topic string, e models.Example) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.examples[wordID] = append(s.examples[wordID], example{Example: e, context: topic})
+}
+
+// AddStudentWord добавляет слово в словарь ученика; word.ID - ID слова в общем словаре
+func (s *Store) AddStudentWord(studentID int, word models.StudentWord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.studentWords[studentID] == nil {
+		s.studentWords[studentID] = make(map[int]models.StudentWord)
+	}
+	s.studentWords[studentID][word.ID] = word
+}
+
+// LinkStudent привязывает ученика к репетитору
+func (s *Store) LinkStudent(tutorID, studentID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links[[2]int{tutorID, studentID}] = true
+}
+
+// Events возвращает записанные события обучения
+func (s *Store) Events() []models.LearningEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.LearningEvent(nil), s.events...)
+}
+
+// GetWord возвращает слово без синонимов, связей и примеров; если слова нет, возвращает repository.ErrNotFound
+func (s *Store) GetWord(_ context.Context, wordID int) (models.WordDetails, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	word, ok := s.words[wordID]
+	if !ok {
+		return models.WordDetails{}, repository.ErrNotFound
+	}
+	return word, nil
+}
+
+// GetSynonyms возвращает синонимы слов wordIDs
+func (s *Store) GetSynonyms(_ context.Context, wordIDs []int) (map[int][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	synonyms := make(map[int][]string, len(wordIDs))
+	for _, wordID := range wordIDs {
+		if len(s.synonyms[wordID]) > 0 {
+			synonyms[wordID] = append([]string(nil), s.synonyms[wordID]...)
+		}
+	}
+	return synonyms, nil
+}
+
+// GetRelations возвращает связи слова
+func (s *Store) GetRelations(_ context.Context, wordID int) ([]models.WordRelation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.relations[wordID]) == 0 {
+		return nil, nil
+	}
+	return append([]models.WordRelation(nil), s.relations[wordID]...), nil
+}
+
+// GetExamples возвращает нескрытые примеры слов wordIDs: общие и личные примеры ученика studentID
+func (s *Store) GetExamples(_ context.Context, wordIDs []int, studentID int) (map[int][]models.Example, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	examples := make(map[int][]models.Example, len(wordIDs))
+	for _, wordID := range wordIDs {
+		for _, e := range s.examples[wordID] {
+			if !e.hidden && (e.studentID == 0 || e.studentID == studentID) {
+				examples[wordID] = append(examples[wordID], e.Example)
+			}
+		}
+	}
+	return examples, nil
+}
+
+// List возвращает страницу словаря ученика с той же сортировкой и пагинацией, что и repository.ListStudentWords
+func (s *Store) List(_ context.Context, studentID int, filter models.WordFilter, sort models.WordSort,
+	after *models.WordCursor, limit int) ([]models.StudentWord, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := sortKeys[sort.Field]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+
+	var words []models.StudentWord
+	for _, word := range s.studentWords[studentID] {
+		if s.matches(word, filter) {
+			words = append(words, word)
+		}
+	}
+	total := len(words)
+
+	less := func(a, b models.StudentWord) bool {
+		if cmp := key.compare(a, b); cmp != 0 {
+			return cmp < 0
+		}
+		return a.ID < b.ID
+	}
+	if sort.Desc {
+		ascending := less
+		less = func(a, b models.StudentWord) bool { return ascending(b, a) }
+	}
+	sortWords(words, less)
+
+	if after != nil {
+		cursor, err := key.fromCursor(*after)
+		if err != nil {
+			return nil, 0, err
+		}
+		start := 0
+		for start < len(words) && !less(cursor, words[start]) {
+			start++
+		}
+		words = words[start:]
+	}
+
+	if len(words) > limit+1 {
+		words = words[:limit+1]
+	}
+	return words, total, nil
+}
+
+// matches проверяет слово ученика по фильтру
+func (s *Store) matches(word models.StudentWord, filter models.WordFilter) bool {
+	switch {
+	case filter.Status != "" && word.Status != filter.Status,
+		filter.Deck != "" && word.Deck != filter.Deck,
+		filter.AddedFrom != nil && word.AddedAt.Before(*filter.AddedFrom),
+		filter.AddedTo != nil && !word.AddedAt.Before(*filter.AddedTo),
+		filter.DueOnly && word.NextReviewAt.After(s.now()),
+		filter.TopicID != 0 && !s.hasTopicExample(word.ID, filter.TopicID):
+		return false
+	}
+	if len(filter.Levels) == 0 {
+		return true
+	}
+	for _, level := range filter.Levels {
+		if word.Level == level {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTopicExample проверяет, что у слова есть пример темы topicID; тема примера сравнивается по названию без учёта регистра
+func (s *Store) hasTopicExample(wordID, topicID int) bool {
+	name, ok := s.topics[topicID]
+	if !ok {
+		return false
+	}
+	for _, e := range s.examples[wordID] {
+		if strings.EqualFold(e.context, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateStatus меняет статус слова ученика и записывает изменение в события обучения
+func (s *Store) UpdateStatus(_ context.Context, studentID, wordID int, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, ok := s.studentWords[studentID][wordID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if word.Status == status {
+		return nil
+	}
+
+	s.events = append(s.events, models.LearningEvent{
+		StudentID: studentID, WordID: wordID, Type: models.EventStatusChanged, OldStatus: word.Status, NewStatus: status,
+	})
+	word.Status = status
+	s.studentWords[studentID][wordID] = word
+	return nil
+}
+
+// CanAccess проверяет, что пользователь - сам ученик или привязанный к нему репетитор
+func (s *Store) CanAccess(_ context.Context, userID, studentID int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return userID == studentID || s.links[[2]int{userID, studentID}], nil
+}
+
+// sortKey - поле сортировки словаря ученика
+type sortKey struct {
+	compare    func(a, b models.StudentWord) int
+	fromCursor func(cursor models.WordCursor) (models.StudentWord, error) // Слово в позиции курсора
+}
+
+// sortKeys - поля сортировки по models.WordSort*
+var sortKeys = map[string]sortKey{
+	models.WordSortAdded: {
+		compare: func(a, b models.StudentWord) int { return compareTime(a.AddedAt, b.AddedAt) },
+		fromCursor: func(cursor models.WordCursor) (models.StudentWord, error) {
+			added, err := time.Parse(time.RFC3339Nano, cursor.Key)
+			return models.StudentWord{ID: cursor.ID, AddedAt: added}, err
+		},
+	},
+	models.WordSortWord: {
+		compare: func(a, b models.StudentWord) int { return strings.Compare(a.Word, b.Word) },
+		fromCursor: func(cursor models.WordCursor) (models.StudentWord, error) {
+			return models.StudentWord{ID: cursor.ID, Word: cursor.Key}, nil
+		},
+	},
+	models.WordSortReview: {
+		compare: func(a, b models.StudentWord) int { return compareTime(a.NextReviewAt, b.NextReviewAt) },
+		fromCursor: func(cursor models.WordCursor) (models.StudentWord, error) {
+			nextReview, err := time.Parse(time.RFC3339Nano, cursor.Key)
+			return models.StudentWord{ID: cursor.ID, NextReviewAt: nextReview}, err
+		},
+	},
+}
+
+// compareTime сравнивает моменты времени как strings.Compare
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// sortWords сортирует слова по less
+func sortWords(words []models.StudentWord, less func(a, b models.StudentWord) bool) {
+	sort.Slice(words, func(i, j int) bool { return less(words[i], words[j]) })
+}
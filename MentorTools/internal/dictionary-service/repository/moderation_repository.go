@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// reviewSettingKey - настройка, включающая очередь проверки нового контента от GPT
+const reviewSettingKey = "review_generated_content"
+
+// wordEditColumns и exampleEditColumns - поля, которые может исправить репетитор, и их колонки
+var (
+	wordEditColumns    = map[string]string{"transcription": "transcription", "translation": "translation", "definition": "definition"}
+	exampleEditColumns = map[string]string{"sentence": "example", "translation": "translation"}
+)
+
+// GetModerationSettings возвращает настройки модерации
+func GetModerationSettings(ctx context.Context, dbpool *pgxpool.Pool) (models.ModerationSettings, error) {
+	var settings models.ModerationSettings
+	err := dbpool.QueryRow(ctx, `
+        SELECT COALESCE((SELECT value = 'true' FROM moderation_settings WHERE key = $1), false)`,
+		reviewSettingKey).Scan(&settings.ReviewGeneratedContent)
+	return settings, err
+}
+
+// SetModerationSettings сохраняет настройки модерации
+func SetModerationSettings(ctx context.Context, dbpool *pgxpool.Pool, settings models.ModerationSettings, authorID int) error {
+	_, err := dbpool.Exec(ctx, `
+        INSERT INTO moderation_settings (key, value, updated_by, updated_at) VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+        ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`,
+		reviewSettingKey, strconv.FormatBool(settings.ReviewGeneratedContent), authorID)
+	return err
+}
+
+// LockWordForEdit блокирует слово до конца транзакции, чтобы правки получали последовательные версии.
+// Если слова нет, возвращает ErrNotFound.
+func LockWordForEdit(ctx context.Context, tx pgx.Tx, wordID int) error {
+	var id int
+	err := tx.QueryRow(ctx, "SELECT id FROM words WHERE id = $1 FOR UPDATE", wordID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// RecordContentEdit записывает правку в историю слова под следующим номером версии
+func RecordContentEdit(ctx context.Context, tx pgx.Tx, wordID int, edit models.ContentEdit) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO content_edits (word_id, version, target, target_id, field, old_value, new_value, author_id)
+        VALUES ($1, (SELECT COALESCE(max(version), 0) + 1 FROM content_edits WHERE word_id = $1), $2, $3, $4, $5, $6, $7)`,
+		wordID, edit.Target, edit.TargetID, edit.Field, edit.OldValue, edit.NewValue, edit.AuthorID)
+	return err
+}
+
+// UpdateWordField меняет поле слова и возвращает прежнее значение
+func UpdateWordField(ctx context.Context, tx pgx.Tx, wordID int, field, value string) (*string, error) {
+	column, ok := wordEditColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("field %q cannot be edited", field)
+	}
+
+	var old *string
+	err := tx.QueryRow(ctx, fmt.Sprintf(`
+        UPDATE words w SET %[1]s = $2
+        FROM (SELECT id, %[1]s FROM words WHERE id = $1) prev
+        WHERE w.id = prev.id
+        RETURNING prev.%[1]s`, column), wordID, value).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return old, err
+}
+
+// UpdateExampleField меняет поле примера, связанного со словом, и возвращает прежнее значение.
+// Если пример не связан со словом, возвращает ErrNotFound.
+func UpdateExampleField(ctx context.Context, tx pgx.Tx, wordID, exampleID int, field, value string) (*string, error) {
+	column, ok := exampleEditColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("field %q cannot be edited", field)
+	}
+
+	var old *string
+	err := tx.QueryRow(ctx, fmt.Sprintf(`
+        UPDATE examples e SET %[1]s = $3
+        FROM (SELECT ex.id, ex.%[1]s FROM examples ex
+              JOIN word_example we ON we.example_id = ex.id
+              WHERE ex.id = $2 AND we.word_id = $1) prev
+        WHERE e.id = prev.id
+        RETURNING prev.%[1]s`, column), wordID, exampleID, value).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil || column != "example" {
+		return old, err
+	}
+
+	// Озвучка относится к прежнему тексту предложения, новую сгенерирует worker
+	_, err = tx.Exec(ctx, "UPDATE examples SET audio_key = NULL, audio_attempts = 0, audio_error = NULL WHERE id = $1", exampleID)
+	return old, err
+}
+
+// SetExampleHidden отклоняет пример слова или возвращает его; возвращает прежнее значение
+func SetExampleHidden(ctx context.Context, tx pgx.Tx, wordID, exampleID int, hidden bool) (bool, error) {
+	var old bool
+	err := tx.QueryRow(ctx, `
+        UPDATE examples e SET hidden = $3
+        FROM (SELECT ex.id, ex.hidden FROM examples ex
+              JOIN word_example we ON we.example_id = ex.id
+              WHERE ex.id = $2 AND we.word_id = $1) prev
+        WHERE e.id = prev.id
+        RETURNING prev.hidden`, wordID, exampleID, hidden).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrNotFound
+	}
+	return old, err
+}
+
+// SetSynonymHidden скрывает синоним слова или возвращает его.
+// Возвращает ID синонима и прежнее значение; если такого синонима у слова нет, возвращает ErrNotFound.
+func SetSynonymHidden(ctx context.Context, tx pgx.Tx, wordID int, synonym string, hidden bool) (int, bool, error) {
+	var linkedID int
+	var old bool
+	err := tx.QueryRow(ctx, `
+        UPDATE word_links wl SET hidden = $3
+        FROM (SELECT l.word_id, l.linked_word_id, l.hidden FROM word_links l
+              JOIN words s ON s.id = l.linked_word_id
+              WHERE l.word_id = $1 AND s.word = $2 AND l.relation_type = 'synonym') prev
+        WHERE wl.word_id = prev.word_id AND wl.linked_word_id = prev.linked_word_id AND wl.relation_type = 'synonym'
+        RETURNING prev.linked_word_id, prev.hidden`, wordID, synonym, hidden).Scan(&linkedID, &old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, ErrNotFound
+	}
+	return linkedID, old, err
+}
+
+// PublishWord публикует контент слова после проверки; возвращает, был ли он опубликован раньше
+func PublishWord(ctx context.Context, tx pgx.Tx, wordID, reviewerID int) (bool, error) {
+	var old bool
+	err := tx.QueryRow(ctx, `
+        UPDATE words w SET published = true, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+        FROM (SELECT id, published FROM words WHERE id = $1) prev
+        WHERE w.id = prev.id
+        RETURNING prev.published`, wordID, reviewerID).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrNotFound
+	}
+	return old, err
+}
+
+// RejectWord снимает контент слова с публикации: примеры, синонимы и другие связи слова скрываются,
+// а слово возвращается в очередь обогащения, чтобы GPT сгенерировал контент заново
+func RejectWord(ctx context.Context, tx pgx.Tx, wordID, reviewerID int) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE words
+        SET published = false, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP,
+            status = 'pending', enrichment_attempts = 0, next_attempt_at = NULL, enrichment_error = NULL
+        WHERE id = $1`, wordID, reviewerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE examples SET hidden = true
+        WHERE id IN (SELECT example_id FROM word_example WHERE word_id = $1)`, wordID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE word_links SET hidden = true WHERE word_id = $1", wordID)
+	return err
+}
+
+// GetModerationQueue возвращает заполненные GPT слова, ожидающие проверки, по возрастанию ID.
+// Возвращается до limit+1 слов после afterID и общее количество слов в очереди.
+func GetModerationQueue(ctx context.Context, dbpool *pgxpool.Pool, afterID, limit int) ([]models.QueuedWord, int, error) {
+	var total int
+	err := dbpool.QueryRow(ctx, "SELECT count(*) FROM words WHERE NOT published AND status = 'completed'").Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT id, word, COALESCE(transcription, ''), COALESCE(translation, ''), COALESCE(definition, '')
+        FROM words
+        WHERE NOT published AND status = 'completed' AND id > $1
+        ORDER BY id
+        LIMIT $2`, afterID, limit+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var words []models.QueuedWord
+	for rows.Next() {
+		var word models.QueuedWord
+		if err := rows.Scan(&word.ID, &word.Word, &word.Transcription, &word.Translation, &word.Description); err != nil {
+			return nil, 0, err
+		}
+		words = append(words, word)
+	}
+	return words, total, rows.Err()
+}
+
+// GetContentEdits возвращает историю правок слова, начиная с последней
+func GetContentEdits(ctx context.Context, dbpool *pgxpool.Pool, wordID int) ([]models.ContentEdit, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT version, target, target_id, field, old_value, new_value, author_id, created_at
+        FROM content_edits
+        WHERE word_id = $1
+        ORDER BY version DESC`, wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edits := []models.ContentEdit{}
+	for rows.Next() {
+		var edit models.ContentEdit
+		err := rows.Scan(&edit.Version, &edit.Target, &edit.TargetID, &edit.Field, &edit.OldValue,
+			&edit.NewValue, &edit.AuthorID, &edit.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	return edits, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// readingExampleContext - контекст примеров, взятых из текстов для чтения
+const readingExampleContext = "reading"
+
+// FindStudentVocabulary ищет переданные слова в словаре ученика - по самому слову языковой пары
+// и по ранее введённым формам. Возвращает найденные слова с их статусом изучения.
+func FindStudentVocabulary(ctx context.Context, dbpool *pgxpool.Pool, studentID int, pair models.LanguagePair, words []string) (map[string]models.VocabularyMatch, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT m.text, m.word_id, sw.status
+        FROM (
+            SELECT w.word AS text, w.id AS word_id
+            FROM words w
+            WHERE w.word = ANY($4) AND w.source_lang = $2 AND w.target_lang = $3
+            UNION ALL
+            SELECT f.form, f.word_id
+            FROM word_forms f
+            JOIN words w ON w.id = f.word_id
+            WHERE f.form = ANY($4) AND f.source_lang = $2 AND w.target_lang = $3
+        ) m
+        JOIN student_words sw ON sw.word_id = m.word_id AND sw.student_id = $1`,
+		studentID, pair.Source, pair.Target, words)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make(map[string]models.VocabularyMatch)
+	for rows.Next() {
+		var text string
+		var match models.VocabularyMatch
+		if err := rows.Scan(&text, &match.WordID, &match.Status); err != nil {
+			return nil, err
+		}
+		matches[text] = match
+	}
+	return matches, rows.Err()
+}
+
+// SaveStudentExample сохраняет предложение из текста как личный пример ученика к слову.
+// Такой пример видит только сам ученик; повторное сохранение того же предложения ничего не делает.
+func SaveStudentExample(ctx context.Context, tx pgx.Tx, studentID, wordID int, sentence string) error {
+	var exampleID int
+	err := tx.QueryRow(ctx, `
+        SELECT e.id FROM examples e
+        JOIN word_example we ON we.example_id = e.id
+        WHERE we.word_id = $1 AND e.student_id = $2 AND e.example = $3`, wordID, studentID, sentence).Scan(&exampleID)
+	// Пример уже сохранён (err == nil) или произошла ошибка
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+        INSERT INTO examples (example, context, student_id, source, source_lang, target_lang)
+        SELECT $1, $2, $3, 'reading', source_lang, target_lang FROM words WHERE id = $4
+        RETURNING id`, sentence, readingExampleContext, studentID, wordID).Scan(&exampleID)
+	if err != nil {
+		return err
+	}
+	return linkWordExample(ctx, tx, wordID, exampleID)
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/gpt-service/services"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// replaceWordLinks пересобирает связи слова по контенту версии: синонимы и связи, предложенные GPT.
+// Связанные слова, которых ещё нет в словаре, попадают в очередь со статусом "pending" в языковой паре pair.
+// Связи других слов с этим словом не меняются. Скрытые репетитором связи сохраняются и остаются скрытыми,
+// даже если новая версия предлагает их снова.
+func replaceWordLinks(ctx context.Context, tx pgx.Tx, wordID int, pair models.LanguagePair, details services.WordDetails) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM word_links WHERE word_id = $1 AND NOT hidden", wordID); err != nil {
+		return err
+	}
+
+	relations := make([]services.Relation, 0, len(details.Synonyms)+len(details.Relations))
+	for _, synonym := range details.Synonyms {
+		relations = append(relations, services.Relation{Word: synonym, Type: models.RelationSynonym, Confidence: 1})
+	}
+	relations = append(relations, details.Relations...)
+
+	for _, relation := range relations {
+		if !models.IsRelationType(relation.Type) {
+			continue
+		}
+		linkedID, err := UpsertPendingWord(ctx, tx, relation.Word, pair)
+		if err != nil {
+			return err
+		}
+		if linkedID == wordID {
+			continue
+		}
+
+		direction := models.DirectionBoth
+		if models.IsDirectedRelation(relation.Type) {
+			direction = "forward"
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO word_links (word_id, linked_word_id, relation_type, direction, confidence) VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (word_id, linked_word_id, relation_type) DO UPDATE SET confidence = GREATEST(word_links.confidence, EXCLUDED.confidence)`,
+			wordID, linkedID, relation.Type, direction, relation.Confidence)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetWordRelations возвращает связи слова, кроме скрытых репетитором: связи самого слова
+// и связи других слов с ним. Симметричная связь, сохранённая с обеих сторон, возвращается один раз.
+func GetWordRelations(ctx context.Context, dbpool *pgxpool.Pool, wordID int) ([]models.WordRelation, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT r.id, r.word, r.relation_type, r.direction, r.confidence
+        FROM (SELECT w.id, w.word, l.relation_type, l.confidence, 0 AS side,
+                     CASE WHEN l.direction = 'both' THEN 'both' ELSE 'outgoing' END AS direction
+              FROM word_links l JOIN words w ON w.id = l.linked_word_id
+              WHERE l.word_id = $1 AND NOT l.hidden
+              UNION ALL
+              SELECT w.id, w.word, l.relation_type, l.confidence, 1 AS side,
+                     CASE WHEN l.direction = 'both' THEN 'both' ELSE 'incoming' END AS direction
+              FROM word_links l JOIN words w ON w.id = l.word_id
+              WHERE l.linked_word_id = $1 AND NOT l.hidden) r
+        ORDER BY r.side, r.relation_type, r.confidence DESC, r.word`, wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type relationKey struct {
+		wordID                  int
+		relationType, direction string
+	}
+	seen := make(map[relationKey]bool)
+
+	var relations []models.WordRelation
+	for rows.Next() {
+		var relation models.WordRelation
+		if err := rows.Scan(&relation.WordID, &relation.Word, &relation.Type, &relation.Direction, &relation.Confidence); err != nil {
+			return nil, err
+		}
+		key := relationKey{relation.WordID, relation.Type, relation.Direction}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		relations = append(relations, relation)
+	}
+	return relations, rows.Err()
+}
+
+// GetRelationEdges возвращает нескрытые связи, в которых участвует хотя бы одно из слов wordIDs.
+// Если types не пуст, возвращаются только связи этих типов; связи с уверенностью ниже minConfidence пропускаются.
+// Связи слова, контент которого ждёт проверки репетитором, возвращаются только при includeUnpublished.
+func GetRelationEdges(ctx context.Context, dbpool *pgxpool.Pool, wordIDs []int, types []string, minConfidence float64,
+	includeUnpublished bool) ([]models.RelationEdge, error) {
+	args := []interface{}{wordIDs, minConfidence}
+	conditions := []string{"(l.word_id = ANY($1) OR l.linked_word_id = ANY($1))", "NOT l.hidden", "l.confidence >= $2"}
+	if len(types) > 0 {
+		args = append(args, types)
+		conditions = append(conditions, fmt.Sprintf("l.relation_type = ANY($%d)", len(args)))
+	}
+	if !includeUnpublished {
+		// Связи сгенерированы для слова word_id и публикуются вместе с его контентом
+		conditions = append(conditions, "w.published")
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT l.word_id, l.linked_word_id, l.relation_type, l.direction = 'forward', l.confidence
+        FROM word_links l
+        JOIN words w ON w.id = l.word_id
+        WHERE `+strings.Join(conditions, " AND ")+`
+        ORDER BY l.confidence DESC, l.word_id, l.linked_word_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edges []models.RelationEdge
+	for rows.Next() {
+		var edge models.RelationEdge
+		if err := rows.Scan(&edge.From, &edge.To, &edge.Type, &edge.Directed, &edge.Confidence); err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+	return edges, rows.Err()
+}
+
+// GetRelationNodes возвращает слова графа связей по ID; глубину заполняет вызывающий
+func GetRelationNodes(ctx context.Context, dbpool *pgxpool.Pool, wordIDs []int) (map[int]models.RelationNode, error) {
+	rows, err := dbpool.Query(ctx, "SELECT id, word, COALESCE(cefr_level, '') FROM words WHERE id = ANY($1)", wordIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := make(map[int]models.RelationNode)
+	for rows.Next() {
+		var node models.RelationNode
+		if err := rows.Scan(&node.ID, &node.Word, &node.Level); err != nil {
+			return nil, err
+		}
+		nodes[node.ID] = node
+	}
+	return nodes, rows.Err()
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+)
+
+// WordRepository - общий словарь: слова и связи между ними
+type WordRepository interface {
+	// GetWord возвращает слово без синонимов, связей и примеров; если слова нет, возвращает ErrNotFound
+	GetWord(ctx context.Context, wordID int) (models.WordDetails, error)
+	// GetSynonyms возвращает нескрытые синонимы слов wordIDs одним запросом, по ID слова
+	GetSynonyms(ctx context.Context, wordIDs []int) (map[int][]string, error)
+	// GetRelations возвращает нескрытые связи слова в обе стороны
+	GetRelations(ctx context.Context, wordID int) ([]models.WordRelation, error)
+}
+
+// ExampleRepository - примеры употребления слов
+type ExampleRepository interface {
+	// GetExamples возвращает нескрытые примеры слов wordIDs одним запросом, по ID слова.
+	// Из личных примеров учеников возвращаются только примеры ученика studentID.
+	GetExamples(ctx context.Context, wordIDs []int, studentID int) (map[int][]models.Example, error)
+}
+
+// StudentWordRepository - словари учеников
+type StudentWordRepository interface {
+	// List возвращает страницу словаря ученика и общее количество слов, подходящих под фильтр (см. ListStudentWords)
+	List(ctx context.Context, studentID int, filter models.WordFilter, sort models.WordSort,
+		after *models.WordCursor, limit int) ([]models.StudentWord, int, error)
+	// UpdateStatus меняет статус слова ученика и записывает изменение в события обучения.
+	// Если слова нет у ученика, возвращает ErrNotFound.
+	UpdateStatus(ctx context.Context, studentID, wordID int, status string) error
+	// CanAccess проверяет, что пользователь - сам ученик или привязанный к нему репетитор
+	CanAccess(ctx context.Context, userID, studentID int) (bool, error)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// searchHits - подзапрос поиска по словарю с параметрами $1 - текстом запроса, $2 и $3 - языковой парой.
+// Запрос может быть и на языке слова, и на языке перевода, поэтому он разбирается конфигурациями
+// обоих языков пары (search_config, миграция 0030) и совпадает, если совпал хотя бы один разбор.
+const searchHits = `
+        WITH q AS (
+            SELECT websearch_to_tsquery(search_config($2), $1) || websearch_to_tsquery(search_config($3), $1) AS query,
+                   lower($1) AS text
+        ),
+        example_hits AS (
+            SELECT DISTINCT ON (we.word_id) we.word_id, e.example, ts_rank(e.search_vector, q.query) AS rank
+            FROM examples e
+            JOIN word_example we ON we.example_id = e.id
+            CROSS JOIN q
+            WHERE e.search_vector @@ q.query AND NOT e.hidden AND e.student_id IS NULL
+              AND e.source_lang = $2 AND e.target_lang = $3
+            ORDER BY we.word_id, rank DESC
+        ),
+        hits AS (
+            SELECT w.id, w.word, COALESCE(w.transcription, '') AS transcription,
+                   COALESCE(w.translation, '') AS translation, w.status, COALESCE(eh.example, '') AS example,
+                   ts_rank(w.search_vector, q.query) * 2
+                       + COALESCE(eh.rank, 0) * 0.5
+                       + greatest(similarity(w.word, q.text), similarity(lower(COALESCE(w.translation, '')), q.text)) AS rank
+            FROM words w
+            CROSS JOIN q
+            LEFT JOIN example_hits eh ON eh.word_id = w.id
+            WHERE w.published AND w.source_lang = $2 AND w.target_lang = $3
+              AND (w.search_vector @@ q.query
+               OR eh.word_id IS NOT NULL
+               OR w.word % q.text
+               OR lower(w.translation) % q.text)
+        )
+`
+
+// SearchWords ищет слова по самому слову, переводу, описанию и примерам.
+//
+// Полнотекстовое совпадение в слове или переводе весит больше совпадения в примерах;
+// триграммное сходство находит слова, набранные с опечаткой. Результаты упорядочены
+// по убыванию релевантности, total - общее количество найденных слов.
+// Слова, контент которых ждёт проверки репетитором, и отклонённые примеры не ищутся.
+// Ищутся только слова языковой пары pair.
+func SearchWords(ctx context.Context, dbpool *pgxpool.Pool, query string, pair models.LanguagePair, limit, offset int) (models.SearchPage, error) {
+	page := models.SearchPage{Items: []models.SearchResult{}, Limit: limit, Offset: offset}
+
+	rows, err := dbpool.Query(ctx, searchHits+`
+        SELECT id, word, transcription, translation, status, example, rank::float8, count(*) OVER ()
+        FROM hits
+        ORDER BY rank DESC, word
+        LIMIT $4 OFFSET $5`, query, pair.Source, pair.Target, limit, offset)
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result models.SearchResult
+		err := rows.Scan(&result.WordID, &result.Word, &result.Transcription, &result.Translation,
+			&result.Status, &result.Example, &result.Rank, &page.Total)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, result)
+	}
+	if err := rows.Err(); err != nil {
+		return page, err
+	}
+
+	// Страница за пределами результатов: общее количество считаем отдельно
+	if len(page.Items) == 0 && offset > 0 {
+		return page, dbpool.QueryRow(ctx, searchHits+"SELECT count(*) FROM hits", query, pair.Source, pair.Target).Scan(&page.Total)
+	}
+	return page, nil
+}
+
+// SuggestWords возвращает слова, начинающиеся с prefix, для автодополнения.
+// Префикс на кириллице ищется по переводу. Короткие и уже заполненные слова идут первыми.
+// Предлагаются только слова языковой пары pair. Перевод, который ждёт проверки репетитором,
+// не показывается и не ищется.
+func SuggestWords(ctx context.Context, dbpool *pgxpool.Pool, prefix string, pair models.LanguagePair, limit int) ([]models.Suggestion, error) {
+	column, published := "w.word", ""
+	if hasCyrillic(prefix) {
+		column, published = "lower(w.translation)", " AND w.published"
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT w.id, w.word, CASE WHEN w.published THEN COALESCE(w.translation, '') ELSE '' END
+        FROM words w
+        WHERE `+column+` LIKE $1 AND w.source_lang = $2 AND w.target_lang = $3`+published+`
+        ORDER BY w.status <> 'completed', length(`+column+`), `+column+`
+        LIMIT $4`, escapeLike(strings.ToLower(prefix))+"%", pair.Source, pair.Target, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []models.Suggestion{}
+	for rows.Next() {
+		var suggestion models.Suggestion
+		if err := rows.Scan(&suggestion.WordID, &suggestion.Word, &suggestion.Translation); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func hasCyrillic(value string) bool {
+	for _, r := range value {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"testing"
+)
+
+// Перевод слова, которое ждёт проверки репетитором, не попадает в автодополнение
+func TestSuggestWordsHidesUnpublishedTranslation(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+	pair := models.LanguagePair{Source: "en", Target: "ru"}
+
+	suffix := dbtest.Suffix()
+	word, translation := "zebrafish"+suffix, "данио"+suffix
+	wordID := dbtest.CreateWord(t, pool, word, translation)
+	dbtest.Exec(t, pool, "UPDATE words SET published = false WHERE id = $1", wordID)
+
+	suggest := func(prefix string) []models.Suggestion {
+		t.Helper()
+		suggestions, err := repository.SuggestWords(ctx, pool, prefix, pair, 10)
+		if err != nil {
+			t.Fatalf("SuggestWords(%q): %v", prefix, err)
+		}
+		return suggestions
+	}
+
+	if got := suggest(word); len(got) != 1 || got[0].WordID != wordID || got[0].Translation != "" {
+		t.Errorf("unpublished word suggestions = %+v, want the word without translation", got)
+	}
+	if got := suggest(translation); len(got) != 0 {
+		t.Errorf("search by unpublished translation = %+v, want nothing", got)
+	}
+
+	dbtest.Exec(t, pool, "UPDATE words SET published = true WHERE id = $1", wordID)
+	if got := suggest(translation); len(got) != 1 || got[0].Translation != translation {
+		t.Errorf("published word suggestions = %+v, want the word with translation", got)
+	}
+}
+
+// Слово разбирается конфигурацией своего языка: немецкая форма находится по другой форме того же слова,
+// а слово языка без стеммера разбирается конфигурацией 'simple'
+func TestSearchVectorUsesLanguageConfig(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	suffix := dbtest.Suffix()
+	wordID := dbtest.CreateWord(t, pool, "Katzen "+suffix, "кошки")
+	dbtest.Exec(t, pool, "UPDATE words SET source_lang = 'de' WHERE id = $1", wordID)
+
+	matches := func(language, query string) bool {
+		t.Helper()
+		var ok bool
+		err := pool.QueryRow(ctx, "SELECT search_vector @@ websearch_to_tsquery(search_config($2), $3) FROM words WHERE id = $1",
+			wordID, language, query).Scan(&ok)
+		if err != nil {
+			t.Fatalf("search_vector: %v", err)
+		}
+		return ok
+	}
+
+	if !matches("de", "Katze "+suffix) {
+		t.Errorf("German word is not found by another form with the German configuration")
+	}
+	if !matches("ru", "кошка") {
+		t.Errorf("translation is not found by another form with the Russian configuration")
+	}
+
+	var config string
+	if err := pool.QueryRow(ctx, "SELECT search_config('ja')::text").Scan(&config); err != nil || config != "simple" {
+		t.Errorf("search_config(ja) = %q, %v; want simple", config, err)
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// wordSortColumns - колонки, по которым сортируется словарь ученика
+var wordSortColumns = map[string]string{
+	models.WordSortAdded:  "sw.added_at",
+	models.WordSortWord:   "w.word",
+	models.WordSortReview: "sw.next_review_at",
+}
+
+// studentWordConditions собирает условия WHERE для слов ученика по фильтру,
+// дописывая значения параметров в args. Запрос должен называть таблицы words w и student_words sw.
+func studentWordConditions(studentID int, filter models.WordFilter, args *[]interface{}) []string {
+	*args = append(*args, studentID)
+	conditions := []string{fmt.Sprintf("sw.student_id = $%d", len(*args))}
+
+	add := func(condition string, value interface{}) {
+		*args = append(*args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(*args)))
+	}
+
+	if filter.Status != "" {
+		add("sw.status = $%d", filter.Status)
+	}
+	if filter.Deck != "" {
+		add("sw.deck = $%d", filter.Deck)
+	}
+	if filter.TopicID != 0 {
+		// Тема примера хранится в examples.context под тем же названием, что и в topics
+		add(`EXISTS (
+            SELECT 1 FROM word_example we
+            JOIN examples e ON e.id = we.example_id
+            JOIN topics t ON lower(t.topic_name) = lower(e.context)
+            WHERE we.word_id = w.id AND t.id = $%d)`, filter.TopicID)
+	}
+	if filter.AddedFrom != nil {
+		add("sw.added_at >= $%d", *filter.AddedFrom)
+	}
+	if filter.AddedTo != nil {
+		add("sw.added_at < $%d", *filter.AddedTo)
+	}
+	if len(filter.Levels) > 0 {
+		add("w.cefr_level = ANY($%d)", filter.Levels)
+	}
+	if filter.DueOnly {
+		conditions = append(conditions, "sw.next_review_at <= now()")
+	}
+	return conditions
+}
+
+// ListStudentWords возвращает страницу словаря ученика и общее количество слов, подходящих под фильтр.
+// Пагинация по ключу: after - позиция последнего слова предыдущей страницы или nil для первой.
+// Возвращается до limit+1 слов, лишнее слово означает, что есть следующая страница.
+// Пока у слова нет проверенного перевода, показывается перевод из файла импорта ученика.
+func ListStudentWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, filter models.WordFilter,
+	sort models.WordSort, after *models.WordCursor, limit int) ([]models.StudentWord, int, error) {
+	column, ok := wordSortColumns[sort.Field]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+
+	var args []interface{}
+	conditions := studentWordConditions(studentID, filter, &args)
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	err := dbpool.QueryRow(ctx, `
+        SELECT count(*) FROM words w
+        JOIN student_words sw ON w.id = sw.word_id
+        WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		var key interface{} = after.Key
+		if sort.Field != models.WordSortWord {
+			parsed, err := time.Parse(time.RFC3339Nano, after.Key)
+			if err != nil {
+				return nil, 0, err
+			}
+			key = parsed
+		}
+		args = append(args, key, after.ID)
+		where += fmt.Sprintf(" AND (%s, w.id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args))
+	}
+
+	args = append(args, limit+1)
+	rows, err := dbpool.Query(ctx, fmt.Sprintf(`
+        SELECT w.id, w.word, COALESCE(sw.typed_form, w.word),
+               CASE WHEN w.published THEN COALESCE(w.transcription, '') ELSE '' END,
+               COALESCE(NULLIF(CASE WHEN w.published THEN w.translation END, ''), sw.translation_hint, ''), COALESCE(w.cefr_level, ''),
+               sw.status, COALESCE(sw.deck, ''), sw.added_at, sw.next_review_at
+        FROM words w
+        JOIN student_words sw ON w.id = sw.word_id
+        WHERE %s
+        ORDER BY %s %s, w.id %s
+        LIMIT $%d`, where, column, direction, direction, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var words []models.StudentWord
+	for rows.Next() {
+		var word models.StudentWord
+		err := rows.Scan(&word.ID, &word.Word, &word.TypedForm, &word.Transcription, &word.Translation, &word.Level,
+			&word.Status, &word.Deck, &word.AddedAt, &word.NextReviewAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		words = append(words, word)
+	}
+	return words, total, rows.Err()
+}
+
+// studentWordRepository - StudentWordRepository поверх PostgreSQL
+type studentWordRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+// NewStudentWordRepository создаёт StudentWordRepository поверх пула соединений PostgreSQL
+func NewStudentWordRepository(dbpool *pgxpool.Pool) StudentWordRepository {
+	return &studentWordRepository{dbpool: dbpool}
+}
+
+// List возвращает страницу словаря ученика и общее количество слов, подходящих под фильтр
+func (r *studentWordRepository) List(ctx context.Context, studentID int, filter models.WordFilter, sort models.WordSort,
+	after *models.WordCursor, limit int) ([]models.StudentWord, int, error) {
+	return ListStudentWords(ctx, r.dbpool, studentID, filter, sort, after, limit)
+}
+
+// UpdateStatus меняет статус слова ученика и в той же транзакции записывает изменение в события обучения.
+// Если слова нет у ученика, возвращает ErrNotFound.
+func (r *studentWordRepository) UpdateStatus(ctx context.Context, studentID, wordID int, status string) error {
+	return WithTx(ctx, r.dbpool, func(tx pgx.Tx) error {
+		oldStatus, _, _, err := GetReviewStateForUpdate(ctx, tx, studentID, wordID)
+		if err != nil {
+			return err
+		}
+		if oldStatus == status {
+			return nil
+		}
+
+		if err := SetStudentWordStatus(ctx, tx, studentID, wordID, status); err != nil {
+			return err
+		}
+		return RecordLearningEvent(ctx, tx, models.LearningEvent{
+			StudentID: studentID, WordID: wordID, Type: models.EventStatusChanged, OldStatus: oldStatus, NewStatus: status,
+		})
+	})
+}
+
+// CanAccess проверяет, что пользователь - сам ученик или привязанный к нему репетитор
+func (r *studentWordRepository) CanAccess(ctx context.Context, userID, studentID int) (bool, error) {
+	return CanAccessStudent(ctx, r.dbpool, userID, studentID)
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// submissionColumns - поля примера ученика в порядке scanSubmission
+const submissionColumns = `
+        s.id, s.student_id, s.word_id, w.word, s.sentence, s.accepted, s.grammar_correct, s.usage_correct,
+        COALESCE(s.corrected, ''), COALESCE(s.explanation, ''), COALESCE(s.translation, ''), s.example_id,
+        s.review_status, s.review_comment, s.reviewed_by, s.reviewed_at, s.created_at`
+
+// GetStudentWordText возвращает слово из словаря ученика и его языковую пару.
+// Если слова нет в словаре ученика, возвращает ErrNotFound.
+func GetStudentWordText(ctx context.Context, db rowQuerier, studentID, wordID int) (string, models.LanguagePair, error) {
+	var word string
+	var pair models.LanguagePair
+	err := db.QueryRow(ctx, `
+        SELECT w.word, w.source_lang, w.target_lang
+        FROM words w
+        JOIN student_words sw ON sw.word_id = w.id AND sw.student_id = $1
+        WHERE w.id = $2`, studentID, wordID).Scan(&word, &pair.Source, &pair.Target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return word, pair, ErrNotFound
+	}
+	return word, pair, err
+}
+
+// CreateStudentExample сохраняет предложение ученика как пример к слову.
+// Пока репетитор не одобрил пример, его видит только автор.
+func CreateStudentExample(ctx context.Context, tx pgx.Tx, studentID, wordID int, sentence, translation string) (int, error) {
+	var exampleID int
+	err := tx.QueryRow(ctx, `
+        INSERT INTO examples (example, translation, student_id, source, source_lang, target_lang)
+        SELECT $1, NULLIF($2, ''), $3, 'student', source_lang, target_lang FROM words WHERE id = $4
+        RETURNING id`, sentence, translation, studentID, wordID).Scan(&exampleID)
+	if err != nil {
+		return 0, err
+	}
+	return exampleID, linkWordExample(ctx, tx, wordID, exampleID)
+}
+
+// SetStudentExampleApproved делает пример ученика видимым всем ученикам (approved = true)
+// или скрывает его после отклонения репетитором
+func SetStudentExampleApproved(ctx context.Context, tx pgx.Tx, exampleID int, approved bool) error {
+	query := "UPDATE examples SET student_id = NULL, hidden = false WHERE id = $1 AND source = 'student'"
+	if !approved {
+		query = "UPDATE examples SET hidden = true WHERE id = $1 AND source = 'student'"
+	}
+	_, err := tx.Exec(ctx, query, exampleID)
+	return err
+}
+
+// CreateSubmission сохраняет пример ученика вместе с результатом проверки GPT;
+// заполняет ID и время создания
+func CreateSubmission(ctx context.Context, tx pgx.Tx, submission *models.ExampleSubmission) error {
+	return tx.QueryRow(ctx, `
+        INSERT INTO example_submissions (student_id, word_id, sentence, accepted, grammar_correct, usage_correct,
+                                         corrected, explanation, translation, example_id)
+        VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10)
+        RETURNING id, review_status, created_at`,
+		submission.StudentID, submission.WordID, submission.Sentence, submission.Accepted, submission.GrammarCorrect,
+		submission.UsageCorrect, submission.Corrected, submission.Explanation, submission.Translation, submission.ExampleID,
+	).Scan(&submission.ID, &submission.ReviewStatus, &submission.CreatedAt)
+}
+
+// ListSubmissions возвращает примеры ученика, новые первыми; status - фильтр по отзыву репетитора, пусто - все
+func ListSubmissions(ctx context.Context, dbpool *pgxpool.Pool, studentID int, status string, limit int) ([]models.ExampleSubmission, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT`+submissionColumns+`
+        FROM example_submissions s
+        JOIN words w ON w.id = s.word_id
+        WHERE s.student_id = $1 AND ($2 = '' OR s.review_status = $2)
+        ORDER BY s.created_at DESC, s.id DESC
+        LIMIT $3`, studentID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	submissions := []models.ExampleSubmission{}
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, rows.Err()
+}
+
+// GetSubmission возвращает пример ученика; если примера нет, возвращает ErrNotFound
+func GetSubmission(ctx context.Context, db rowQuerier, submissionID int64) (models.ExampleSubmission, error) {
+	return getSubmission(ctx, db, submissionID, "")
+}
+
+// GetSubmissionForUpdate возвращает пример ученика и блокирует его до конца транзакции.
+// Если примера нет, возвращает ErrNotFound.
+func GetSubmissionForUpdate(ctx context.Context, tx pgx.Tx, submissionID int64) (models.ExampleSubmission, error) {
+	return getSubmission(ctx, tx, submissionID, "FOR UPDATE OF s")
+}
+
+// getSubmission читает пример ученика; lock - необязательная блокировка строки
+func getSubmission(ctx context.Context, db rowQuerier, submissionID int64, lock string) (models.ExampleSubmission, error) {
+	submission, err := scanSubmission(db.QueryRow(ctx, `
+        SELECT`+submissionColumns+`
+        FROM example_submissions s
+        JOIN words w ON w.id = s.word_id
+        WHERE s.id = $1
+        `+lock, submissionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return submission, ErrNotFound
+	}
+	return submission, err
+}
+
+// SaveSubmissionReview сохраняет отзыв репетитора и пример, созданный из предложения ученика;
+// заполняет время отзыва
+func SaveSubmissionReview(ctx context.Context, tx pgx.Tx, submission *models.ExampleSubmission) error {
+	return tx.QueryRow(ctx, `
+        UPDATE example_submissions
+        SET review_status = $2, review_comment = NULLIF($3, ''), reviewed_by = $4, reviewed_at = CURRENT_TIMESTAMP, example_id = $5
+        WHERE id = $1
+        RETURNING reviewed_at`,
+		submission.ID, submission.ReviewStatus, submission.ReviewComment, submission.ReviewedBy, submission.ExampleID,
+	).Scan(&submission.ReviewedAt)
+}
+
+// scanSubmission читает строку с полями submissionColumns
+func scanSubmission(row pgx.Row) (models.ExampleSubmission, error) {
+	var submission models.ExampleSubmission
+	var comment sql.NullString
+	err := row.Scan(&submission.ID, &submission.StudentID, &submission.WordID, &submission.Word, &submission.Sentence,
+		&submission.Accepted, &submission.GrammarCorrect, &submission.UsageCorrect, &submission.Corrected,
+		&submission.Explanation, &submission.Translation, &submission.ExampleID, &submission.ReviewStatus,
+		&comment, &submission.ReviewedBy, &submission.ReviewedAt, &submission.CreatedAt)
+	submission.ReviewComment = comment.String
+	return submission, err
+}
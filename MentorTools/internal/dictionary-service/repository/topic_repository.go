@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrNotFound возвращается, когда запрошенная запись отсутствует в БД
+var ErrNotFound = errors.New("not found")
+
+// GetTopics возвращает все темы, отсортированные по названию
+func GetTopics(ctx context.Context, dbpool *pgxpool.Pool) ([]models.Topic, error) {
+	rows, err := dbpool.Query(ctx, "SELECT id, topic_name FROM topics ORDER BY topic_name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []models.Topic{}
+	for rows.Next() {
+		var topic models.Topic
+		if err := rows.Scan(&topic.ID, &topic.Name); err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	return topics, rows.Err()
+}
+
+// CreateTopic добавляет тему; если тема с таким названием уже есть, возвращает её
+func CreateTopic(ctx context.Context, dbpool *pgxpool.Pool, name string) (models.Topic, error) {
+	topic := models.Topic{Name: name}
+	err := dbpool.QueryRow(ctx, `
+        INSERT INTO topics (topic_name) VALUES ($1)
+        ON CONFLICT (topic_name) DO UPDATE SET topic_name = EXCLUDED.topic_name
+        RETURNING id`, name).Scan(&topic.ID)
+	return topic, err
+}
+
+// UpdateTopic переименовывает тему
+func UpdateTopic(ctx context.Context, dbpool *pgxpool.Pool, topicID int, name string) error {
+	tag, err := dbpool.Exec(ctx, "UPDATE topics SET topic_name = $1 WHERE id = $2", name, topicID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteTopic удаляет тему вместе с её привязками к ученикам
+func DeleteTopic(ctx context.Context, dbpool *pgxpool.Pool, topicID int) error {
+	tag, err := dbpool.Exec(ctx, "DELETE FROM topics WHERE id = $1", topicID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetStudentTopics возвращает интересы ученика
+func GetStudentTopics(ctx context.Context, dbpool *pgxpool.Pool, studentID int) ([]models.Topic, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT t.id, t.topic_name
+        FROM student_topics st
+        JOIN topics t ON st.topic_id = t.id
+        WHERE st.student_id = $1
+        ORDER BY t.topic_name`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []models.Topic{}
+	for rows.Next() {
+		var topic models.Topic
+		if err := rows.Scan(&topic.ID, &topic.Name); err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	return topics, rows.Err()
+}
+
+// SetStudentTopics заменяет интересы ученика переданным набором тем.
+// Статистика использования уже выбранных тем сохраняется.
+func SetStudentTopics(ctx context.Context, dbpool *pgxpool.Pool, studentID int, topicIDs []int) error {
+	if topicIDs == nil {
+		// NULL в ANY() не совпадает ни с чем, поэтому пустой набор передаём явно
+		topicIDs = []int{}
+	}
+
+	tx, err := dbpool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM student_topics WHERE student_id = $1 AND NOT (topic_id = ANY($2))", studentID, topicIDs); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+        INSERT INTO student_topics (student_id, topic_id)
+        SELECT $1, t.id FROM topics t WHERE t.id = ANY($2)
+        ON CONFLICT (student_id, topic_id) DO NOTHING`, studentID, topicIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PickTopicsForExamples выбирает до limit тем ученика для генерации примеров.
+//
+// Выборка взвешенная (алгоритм Efraimidis–Spirakis): вес темы падает с числом
+// её использований и восстанавливается в течение суток после последнего
+// использования, поэтому темы чередуются, а не повторяются подряд.
+// Выбранные темы помечаются как использованные.
+func PickTopicsForExamples(ctx context.Context, dbpool *pgxpool.Pool, studentID int, limit int) ([]string, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT t.id, t.topic_name
+        FROM student_topics st
+        JOIN topics t ON st.topic_id = t.id
+        WHERE st.student_id = $1
+        ORDER BY -ln(1.0 - random()) / (
+            (1.0 / (1 + st.use_count)) *
+            GREATEST(0.05, LEAST(1.0, COALESCE(EXTRACT(EPOCH FROM (now() - st.last_used_at)) / 86400.0, 1.0)))
+        )
+        LIMIT $2`, studentID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var topicIDs []int
+	var topics []string
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		topicIDs = append(topicIDs, id)
+		topics = append(topics, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(topicIDs) > 0 {
+		_, err = dbpool.Exec(ctx, `
+            UPDATE student_topics
+            SET use_count = use_count + 1, last_used_at = now()
+            WHERE student_id = $1 AND topic_id = ANY($2)`, studentID, topicIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return topics, nil
+}
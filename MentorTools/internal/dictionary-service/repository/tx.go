@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// WithTx выполняет fn в одной транзакции: фиксирует её при успехе и откатывает при ошибке
+func WithTx(ctx context.Context, dbpool *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := dbpool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
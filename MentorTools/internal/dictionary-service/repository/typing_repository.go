@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrAlreadyAnswered возвращается при повторном ответе на задание тренировки
+var ErrAlreadyAnswered = errors.New("word is already answered in this session")
+
+// PickTypingWords выбирает слова ученика для тренировки написания: сначала те, которые пора повторить,
+// затем те, чьё повторение ближе всего. Берутся только слова с опубликованным переводом,
+// чтобы ученику было что показать вместо слова. deck ограничивает выбор колодой, если не пуст.
+func PickTypingWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, deck string, limit int) ([]int, error) {
+	rows, err := dbpool.Query(ctx, `
+        SELECT w.id
+        FROM student_words sw
+        JOIN words w ON w.id = sw.word_id
+        WHERE sw.student_id = $1 AND ($2 = '' OR sw.deck = $2)
+          AND w.status = 'completed' AND w.published AND COALESCE(w.translation, '') <> ''
+        ORDER BY sw.next_review_at, w.id
+        LIMIT $3`, studentID, deck, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wordIDs []int
+	for rows.Next() {
+		var wordID int
+		if err := rows.Scan(&wordID); err != nil {
+			return nil, err
+		}
+		wordIDs = append(wordIDs, wordID)
+	}
+	return wordIDs, rows.Err()
+}
+
+// CreateTypingSession создаёт тренировку написания из слов в заданном порядке и возвращает её ID
+func CreateTypingSession(ctx context.Context, tx pgx.Tx, studentID int, mode string, wordIDs []int) (string, error) {
+	var sessionID string
+	err := tx.QueryRow(ctx, "INSERT INTO typing_sessions (student_id, mode) VALUES ($1, $2) RETURNING id::text",
+		studentID, mode).Scan(&sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO typing_session_words (session_id, word_id, position)
+        SELECT $1::uuid, word_id, position FROM unnest($2::int[]) WITH ORDINALITY AS t(word_id, position)`,
+		sessionID, wordIDs)
+	return sessionID, err
+}
+
+// GetTypingSession возвращает тренировку ученика с заданиями и ответами; если её нет, возвращает ErrNotFound.
+// Правильное написание заполняется только для заданий, на которые ученик уже ответил.
+func GetTypingSession(ctx context.Context, dbpool *pgxpool.Pool, sessionID string, studentID int) (models.TypingSession, error) {
+	session := models.TypingSession{Prompts: []models.TypingPrompt{}}
+	err := dbpool.QueryRow(ctx, "SELECT id::text, mode, created_at FROM typing_sessions WHERE id = $1::uuid AND student_id = $2",
+		sessionID, studentID).Scan(&session.ID, &session.Mode, &session.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return session, ErrNotFound
+	}
+	if err != nil {
+		return session, err
+	}
+
+	rows, err := dbpool.Query(ctx, `
+        SELECT w.id, w.word, COALESCE(w.translation, ''), COALESCE(w.audio_key, ''),
+               tw.answered_at IS NOT NULL, tw.correct, COALESCE(tw.typed, '')
+        FROM typing_session_words tw
+        JOIN words w ON w.id = tw.word_id
+        WHERE tw.session_id = $1::uuid
+        ORDER BY tw.position`, sessionID)
+	if err != nil {
+		return session, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prompt models.TypingPrompt
+		var word string
+		err := rows.Scan(&prompt.WordID, &word, &prompt.Translation, &prompt.AudioKey, &prompt.Answered, &prompt.Correct, &prompt.Typed)
+		if err != nil {
+			return session, err
+		}
+
+		prompt.Length = len([]rune(word))
+		if prompt.Answered {
+			prompt.Expected = word
+			session.Answered++
+			if prompt.Correct != nil && *prompt.Correct {
+				session.Correct++
+			}
+		}
+		session.Prompts = append(session.Prompts, prompt)
+	}
+	return session, rows.Err()
+}
+
+// GetTypingWordForUpdate возвращает слово задания тренировки ученика, блокируя задание до конца транзакции.
+// Если задания нет, возвращает ErrNotFound, если на него уже ответили - ErrAlreadyAnswered.
+func GetTypingWordForUpdate(ctx context.Context, tx pgx.Tx, sessionID string, studentID, wordID int) (string, error) {
+	var word string
+	var answered bool
+	err := tx.QueryRow(ctx, `
+        SELECT w.word, tw.answered_at IS NOT NULL
+        FROM typing_session_words tw
+        JOIN typing_sessions s ON s.id = tw.session_id
+        JOIN words w ON w.id = tw.word_id
+        WHERE tw.session_id = $1::uuid AND s.student_id = $2 AND tw.word_id = $3
+        FOR UPDATE OF tw`, sessionID, studentID, wordID).Scan(&word, &answered)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if answered {
+		return word, ErrAlreadyAnswered
+	}
+	return word, nil
+}
+
+// SaveTypingAnswer сохраняет ответ на задание тренировки
+func SaveTypingAnswer(ctx context.Context, tx pgx.Tx, sessionID string, wordID int, typed string, correct bool, distance int) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE typing_session_words SET typed = $3, correct = $4, distance = $5, answered_at = now()
+        WHERE session_id = $1::uuid AND word_id = $2`, sessionID, wordID, typed, correct, distance)
+	return err
+}
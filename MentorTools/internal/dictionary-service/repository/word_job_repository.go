@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const wordJobColumns = "id::text, student_id, word, COALESCE(source_sentence, ''), status, stage, word_id, error, created_at, updated_at"
+
+// ErrIdempotencyKeyReused возвращается, когда Idempotency-Key уже использован для другого слова
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with different word")
+
+// CreateWordJob ставит в очередь задачу на добавление слова ученику.
+// Повторный запрос с тем же idempotencyKey возвращает ранее созданную задачу (created = false).
+// Без ключа повторное добавление слова, задача для которого ещё не завершена, также возвращает существующую задачу.
+// sourceSentence - предложение из текста, в котором встретилось слово; пустая строка - слово добавлено вручную.
+func CreateWordJob(ctx context.Context, dbpool *pgxpool.Pool, studentID int, word, sourceSentence, idempotencyKey string) (job models.WordJob, created bool, err error) {
+	if idempotencyKey != "" {
+		job, err = scanWordJob(dbpool.QueryRow(ctx, "SELECT "+wordJobColumns+" FROM word_jobs WHERE student_id = $1 AND idempotency_key = $2",
+			studentID, idempotencyKey))
+	} else {
+		job, err = scanWordJob(dbpool.QueryRow(ctx, `
+            SELECT `+wordJobColumns+` FROM word_jobs
+            WHERE student_id = $1 AND word = $2 AND status IN ('queued', 'running')
+            ORDER BY created_at DESC
+            LIMIT 1`, studentID, word))
+	}
+	if err == nil {
+		if job.Word != word {
+			return job, false, ErrIdempotencyKeyReused
+		}
+		return job, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return job, false, err
+	}
+
+	job, err = scanWordJob(dbpool.QueryRow(ctx, `
+        INSERT INTO word_jobs (student_id, word, idempotency_key, source_sentence) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+        ON CONFLICT (student_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
+        RETURNING `+wordJobColumns, studentID, word, idempotencyKey, sourceSentence))
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Параллельный запрос с тем же ключом успел создать задачу раньше нас
+		return CreateWordJob(ctx, dbpool, studentID, word, sourceSentence, idempotencyKey)
+	}
+	return job, err == nil, err
+}
+
+// GetWordJob возвращает задачу ученика по ID
+func GetWordJob(ctx context.Context, dbpool *pgxpool.Pool, jobID string, studentID int) (models.WordJob, error) {
+	row := dbpool.QueryRow(ctx, "SELECT "+wordJobColumns+" FROM word_jobs WHERE id = $1::uuid AND student_id = $2", jobID, studentID)
+	job, err := scanWordJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return job, ErrNotFound
+	}
+	return job, err
+}
+
+// ClaimWordJob забирает из очереди самую старую задачу и переводит её в статус "running".
+// Задачи, зависшие в "running" дольше staleAfter, забираются повторно.
+func ClaimWordJob(ctx context.Context, dbpool *pgxpool.Pool, staleAfter time.Duration) (models.WordJob, error) {
+	row := dbpool.QueryRow(ctx, `
+        UPDATE word_jobs SET status = 'running', stage = 'started', started_at = now(), updated_at = now()
+        WHERE id = (
+            SELECT id FROM word_jobs
+            WHERE status = 'queued'
+               OR (status = 'running' AND started_at < now() - make_interval(secs => $1))
+            ORDER BY created_at
+            FOR UPDATE SKIP LOCKED
+            LIMIT 1
+        )
+        RETURNING `+wordJobColumns, staleAfter.Seconds())
+	job, err := scanWordJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return job, ErrQueueEmpty
+	}
+	return job, err
+}
+
+// UpdateWordJobStage сохраняет текущий этап обработки задачи
+func UpdateWordJobStage(ctx context.Context, dbpool *pgxpool.Pool, jobID string, stage string) error {
+	_, err := dbpool.Exec(ctx, "UPDATE word_jobs SET stage = $2, updated_at = now() WHERE id = $1::uuid", jobID, stage)
+	return err
+}
+
+// CompleteWordJob помечает задачу выполненной и сохраняет ID добавленного слова
+func CompleteWordJob(ctx context.Context, dbpool *pgxpool.Pool, jobID string, wordID int) error {
+	_, err := dbpool.Exec(ctx, `
+        UPDATE word_jobs SET status = 'completed', stage = 'done', word_id = $2, error = NULL, updated_at = now()
+        WHERE id = $1::uuid`, jobID, wordID)
+	return err
+}
+
+// FailWordJob помечает задачу завершённой с ошибкой
+func FailWordJob(ctx context.Context, dbpool *pgxpool.Pool, jobID string, cause error) error {
+	_, err := dbpool.Exec(ctx, `
+        UPDATE word_jobs SET status = 'failed', error = $2, updated_at = now()
+        WHERE id = $1::uuid`, jobID, cause.Error())
+	return err
+}
+
+// scanWordJob читает задачу из строки результата с колонками wordJobColumns
+func scanWordJob(row pgx.Row) (models.WordJob, error) {
+	var job models.WordJob
+	err := row.Scan(&job.ID, &job.StudentID, &job.Word, &job.SourceSentence, &job.Status, &job.Stage, &job.WordID, &job.Error, &job.CreatedAt, &job.UpdatedAt)
+	return job, err
+}
@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCreateWordJobIdempotencyKey(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+
+	job, created, err := repository.CreateWordJob(ctx, pool, studentID, "apple", "", "key-1")
+	if err != nil || !created {
+		t.Fatalf("CreateWordJob = created %v, err %v; want a new job", created, err)
+	}
+
+	replayed, created, err := repository.CreateWordJob(ctx, pool, studentID, "apple", "", "key-1")
+	if err != nil {
+		t.Fatalf("replay CreateWordJob: %v", err)
+	}
+	if created || replayed.ID != job.ID {
+		t.Errorf("replay = job %s (created %v), want stored job %s", replayed.ID, created, job.ID)
+	}
+
+	if _, _, err := repository.CreateWordJob(ctx, pool, studentID, "pear", "", "key-1"); !errors.Is(err, repository.ErrIdempotencyKeyReused) {
+		t.Errorf("same key with another word: err = %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// Ключ относится к ученику: другой ученик может использовать тот же ключ
+	otherStudentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, otherStudentID)
+	other, created, err := repository.CreateWordJob(ctx, pool, otherStudentID, "pear", "", "key-1")
+	if err != nil || !created || other.ID == job.ID {
+		t.Errorf("other student's job = %s (created %v, err %v), want a new job", other.ID, created, err)
+	}
+}
+
+func TestCreateWordJobWithoutKeyReturnsPendingJob(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+
+	job, _, err := repository.CreateWordJob(ctx, pool, studentID, "apple", "", "")
+	if err != nil {
+		t.Fatalf("CreateWordJob: %v", err)
+	}
+	duplicate, created, err := repository.CreateWordJob(ctx, pool, studentID, "apple", "", "")
+	if err != nil {
+		t.Fatalf("duplicate CreateWordJob: %v", err)
+	}
+	if created || duplicate.ID != job.ID {
+		t.Errorf("duplicate add = job %s (created %v), want queued job %s", duplicate.ID, created, job.ID)
+	}
+}
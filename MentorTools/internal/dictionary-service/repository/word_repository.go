@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/gpt-service/services"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// wordRepository - WordRepository поверх PostgreSQL
+type wordRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+// NewWordRepository создаёт WordRepository поверх пула соединений PostgreSQL
+func NewWordRepository(dbpool *pgxpool.Pool) WordRepository {
+	return &wordRepository{dbpool: dbpool}
+}
+
+// GetWord возвращает слово без синонимов, связей и примеров; если слова нет, возвращает ErrNotFound
+func (r *wordRepository) GetWord(ctx context.Context, wordID int) (models.WordDetails, error) {
+	var word models.WordDetails
+	err := r.dbpool.QueryRow(ctx, `
+        SELECT w.id, w.word, w.source_lang, w.target_lang, w.transcription, w.translation, w.definition,
+               COALESCE(w.cefr_level, ''), w.frequency_rank, w.published, COALESCE(w.audio_key, ''),
+               COALESCE(w.image_key, ''), COALESCE(w.image_thumb_key, '')
+        FROM words w
+        WHERE w.id = $1`, wordID).Scan(&word.ID, &word.Word, &word.Languages.Source, &word.Languages.Target,
+		&word.Transcription, &word.Translation, &word.Description, &word.Level, &word.FrequencyRank, &word.Published, &word.AudioKey,
+		&word.ImageKey, &word.ThumbnailKey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return word, ErrNotFound
+	}
+	if err != nil {
+		return word, err
+	}
+	if word.FrequencyRank != nil {
+		word.FrequencyBand = frequency.Band(*word.FrequencyRank)
+	}
+	return word, nil
+}
+
+// GetSynonyms возвращает синонимы слов wordIDs, кроме скрытых репетитором, одним запросом
+func (r *wordRepository) GetSynonyms(ctx context.Context, wordIDs []int) (map[int][]string, error) {
+	rows, err := r.dbpool.Query(ctx, `
+        SELECT l.word_id, w.word
+        FROM word_links l
+        JOIN words w ON w.id = l.linked_word_id
+        WHERE l.word_id = ANY($1) AND l.relation_type = 'synonym' AND NOT l.hidden
+        ORDER BY l.word_id, l.id`, wordIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	synonyms := make(map[int][]string, len(wordIDs))
+	for rows.Next() {
+		var wordID int
+		var synonym string
+		if err := rows.Scan(&wordID, &synonym); err != nil {
+			return nil, err
+		}
+		synonyms[wordID] = append(synonyms[wordID], synonym)
+	}
+	return synonyms, rows.Err()
+}
+
+// GetRelations возвращает связи слова: антонимы, сочетания, однокоренные слова и более общие понятия
+func (r *wordRepository) GetRelations(ctx context.Context, wordID int) ([]models.WordRelation, error) {
+	return GetWordRelations(ctx, r.dbpool, wordID)
+}
+
+// exampleRepository - ExampleRepository поверх PostgreSQL
+type exampleRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+// NewExampleRepository создаёт ExampleRepository поверх пула соединений PostgreSQL
+func NewExampleRepository(dbpool *pgxpool.Pool) ExampleRepository {
+	return &exampleRepository{dbpool: dbpool}
+}
+
+// GetExamples возвращает примеры слов wordIDs, кроме отклонённых репетитором, одним запросом.
+// Из личных примеров учеников возвращаются только примеры ученика studentID.
+func (r *exampleRepository) GetExamples(ctx context.Context, wordIDs []int, studentID int) (map[int][]models.Example, error) {
+	rows, err := r.dbpool.Query(ctx, `
+        SELECT we.word_id, e.id, e.example, e.translation, COALESCE(e.audio_key, ''), e.source
+        FROM examples e
+        JOIN word_example we ON e.id = we.example_id
+        WHERE we.word_id = ANY($1) AND NOT e.hidden AND (e.student_id IS NULL OR e.student_id = $2)
+        ORDER BY we.word_id, e.id`, wordIDs, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	examples := make(map[int][]models.Example, len(wordIDs))
+	for rows.Next() {
+		var wordID int
+		var example models.Example
+		var sentence, translation sql.NullString
+		if err := rows.Scan(&wordID, &example.ID, &sentence, &translation, &example.AudioKey, &example.Source); err != nil {
+			return nil, err
+		}
+
+		// NULL в БД превращается в пустую строку
+		example.Sentence = sentence.String
+		example.Translation = translation.String
+		examples[wordID] = append(examples[wordID], example)
+	}
+	return examples, rows.Err()
+}
+
+// GetWordStatusForUpdate возвращает ID и статус слова языковой пары, блокируя строку до конца транзакции.
+// Если слова нет в словаре, возвращает ErrNotFound.
+func GetWordStatusForUpdate(ctx context.Context, tx pgx.Tx, word string, pair models.LanguagePair) (int, string, error) {
+	var wordID int
+	var status string
+	err := tx.QueryRow(ctx, "SELECT id, status FROM words WHERE word = $1 AND source_lang = $2 AND target_lang = $3 FOR UPDATE",
+		word, pair.Source, pair.Target).Scan(&wordID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", ErrNotFound
+	}
+	return wordID, status, err
+}
+
+// GetWordStatus возвращает ID и статус слова языковой пары; если слова нет в словаре, возвращает ErrNotFound
+func GetWordStatus(ctx context.Context, dbpool *pgxpool.Pool, word string, pair models.LanguagePair) (int, string, error) {
+	var wordID int
+	var status string
+	err := dbpool.QueryRow(ctx, "SELECT id, status FROM words WHERE word = $1 AND source_lang = $2 AND target_lang = $3",
+		word, pair.Source, pair.Target).Scan(&wordID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", ErrNotFound
+	}
+	return wordID, status, err
+}
+
+// UpsertPendingWord возвращает ID слова языковой пары, добавляя его со статусом "pending", если его ещё нет в словаре
+func UpsertPendingWord(ctx context.Context, tx pgx.Tx, word string, pair models.LanguagePair) (int, error) {
+	var wordID int
+	err := tx.QueryRow(ctx, `
+        INSERT INTO words (word, source_lang, target_lang, status) VALUES ($1, $2, $3, 'pending')
+        ON CONFLICT (word, source_lang, target_lang) DO UPDATE SET word = EXCLUDED.word
+        RETURNING id`, word, pair.Source, pair.Target).Scan(&wordID)
+	return wordID, err
+}
+
+// SaveWordDetails сохраняет данные, полученные от GPT, как новую версию контента слова,
+// делает её активной и переводит слово в статус "completed".
+// Если включена очередь проверки, контент остаётся неопубликованным до одобрения репетитором.
+// Синонимы и ключевые слова примеров, которых ещё нет в словаре, попадают в очередь со статусом "pending".
+func SaveWordDetails(ctx context.Context, tx pgx.Tx, wordID int, details services.WordDetails, generation models.ContentGeneration) error {
+	version, err := CreateContentVersion(ctx, tx, wordID, details, generation)
+	if err != nil {
+		return err
+	}
+	return ActivateContentVersion(ctx, tx, wordID, version)
+}
+
+// LinkStudentWord добавляет слово ученику; если слово уже есть у ученика, обновляет только введённую форму.
+// Возвращает true, если слово было добавлено, а не обновлено.
+func LinkStudentWord(ctx context.Context, tx pgx.Tx, studentID, wordID int, typedForm string) (bool, error) {
+	var added bool
+	err := tx.QueryRow(ctx, `
+        INSERT INTO student_words (student_id, word_id, status, typed_form) VALUES ($1, $2, 'need to learn', $3)
+        ON CONFLICT (student_id, word_id) DO UPDATE SET typed_form = EXCLUDED.typed_form
+        RETURNING (xmax = 0)`, studentID, wordID, typedForm).Scan(&added)
+	return added, err
+}
+
+// FindLemmaByForm возвращает лемму, с которой ранее была связана форма слова языка language
+func FindLemmaByForm(ctx context.Context, dbpool *pgxpool.Pool, form, language string) (string, error) {
+	var lemma string
+	err := dbpool.QueryRow(ctx, `
+        SELECT w.word FROM word_forms f
+        JOIN words w ON w.id = f.word_id
+        WHERE f.form = $1 AND f.source_lang = $2`, form, language).Scan(&lemma)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return lemma, err
+}
+
+// FindExistingWords возвращает те из переданных слов, которые уже есть в словаре языковой пары
+func FindExistingWords(ctx context.Context, dbpool *pgxpool.Pool, words []string, pair models.LanguagePair) (map[string]bool, error) {
+	rows, err := dbpool.Query(ctx, "SELECT word FROM words WHERE word = ANY($1) AND source_lang = $2 AND target_lang = $3",
+		words, pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		existing[word] = true
+	}
+	return existing, rows.Err()
+}
+
+// SaveWordForm связывает форму слова языка language с леммой
+func SaveWordForm(ctx context.Context, tx pgx.Tx, form, language string, wordID int) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO word_forms (form, source_lang, word_id) VALUES ($1, $2, $3)
+        ON CONFLICT (form, source_lang) DO UPDATE SET word_id = EXCLUDED.word_id`, form, language, wordID)
+	return err
+}
+
+// GetWordsForExamples возвращает слова ученика на изучаемом языке, которые GPT вплетёт в примеры:
+// до 5 случайных выученных слов и до 2 случайных слов, которые нужно выучить
+func GetWordsForExamples(ctx context.Context, dbpool *pgxpool.Pool, studentID int, language string) ([]string, error) {
+	rows, err := dbpool.Query(ctx, `
+        (SELECT w.word FROM words w JOIN student_words sw ON w.id = sw.word_id
+         WHERE sw.student_id = $1 AND w.source_lang = $2 AND sw.status = 'learned' ORDER BY random() LIMIT 5)
+        UNION ALL
+        (SELECT w.word FROM words w JOIN student_words sw ON w.id = sw.word_id
+         WHERE sw.student_id = $1 AND w.source_lang = $2 AND sw.status = 'need to learn' ORDER BY random() LIMIT 2)`,
+		studentID, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// linkWordExample связывает слово с примером, если связи ещё нет
+func linkWordExample(ctx context.Context, tx pgx.Tx, wordID, exampleID int) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO word_example (word_id, example_id) VALUES ($1, $2)
+        ON CONFLICT (word_id, example_id) DO NOTHING`, wordID, exampleID)
+	return err
+}
+
+// StudentHasWord проверяет, есть ли слово в словаре ученика
+func StudentHasWord(ctx context.Context, tx pgx.Tx, studentID, wordID int) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM student_words WHERE student_id = $1 AND word_id = $2)", studentID, wordID).Scan(&exists)
+	return exists, err
+}
+
+// SetStudentTranslationHint сохраняет у слова ученика перевод из файла импорта. Общий словарь он не меняет:
+// перевод показывается только этому ученику, пока у слова нет проверенного перевода
+func SetStudentTranslationHint(ctx context.Context, tx pgx.Tx, studentID, wordID int, translation string) error {
+	_, err := tx.Exec(ctx, "UPDATE student_words SET translation_hint = $3 WHERE student_id = $1 AND word_id = $2",
+		studentID, wordID, translation)
+	return err
+}
+
+// RequeueFailedWord возвращает в очередь обогащения слово, для которого попытки были исчерпаны
+func RequeueFailedWord(ctx context.Context, tx pgx.Tx, wordID int) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE words SET status = 'pending', enrichment_attempts = 0, next_attempt_at = NULL
+        WHERE id = $1 AND status = 'failed'`, wordID)
+	return err
+}
+
+// SetStudentWordDeck помещает слово ученика в колоду
+func SetStudentWordDeck(ctx context.Context, tx pgx.Tx, studentID, wordID int, deck string) error {
+	_, err := tx.Exec(ctx, "UPDATE student_words SET deck = $3 WHERE student_id = $1 AND word_id = $2", studentID, wordID, deck)
+	return err
+}
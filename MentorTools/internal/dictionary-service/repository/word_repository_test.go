@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Добавление слова записывает слово, версию контента, примеры, синонимы и связь с учеником в одной транзакции:
+// сбой на любом шаге не оставляет ни одной строки
+func TestIngestionRollbackLeavesNoRows(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	suffix := dbtest.Suffix()
+	word, synonym, keyword := "river"+suffix, "stream"+suffix, "bank"+suffix
+	sentence := "The river" + suffix + " flows."
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM words WHERE word IN ($1, $2, $3)", word, synonym, keyword)
+		pool.Exec(ctx, "DELETE FROM examples WHERE example = $1", sentence)
+	})
+
+	pair := models.LanguagePair{Source: "en", Target: "ru"}
+	errFailed := errors.New("failed after all writes")
+	err := repository.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		wordID, err := repository.UpsertPendingWord(ctx, tx, word, pair)
+		if err != nil {
+			return err
+		}
+		details := gpt.WordDetails{
+			Translation: "река", Synonyms: []string{synonym},
+			Examples: []gpt.Examples{{Text: sentence, Keywords: []string{keyword}}},
+		}
+		if err := repository.SaveWordDetails(ctx, tx, wordID, details, models.ContentGeneration{Model: "test"}); err != nil {
+			return err
+		}
+		if _, err := repository.LinkStudentWord(ctx, tx, studentID, wordID, word); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("WithTx error = %v, want %v", err, errFailed)
+	}
+
+	var words, examples, studentWords int
+	err = pool.QueryRow(ctx, `
+        SELECT (SELECT count(*) FROM words WHERE word IN ($1, $2, $3)),
+               (SELECT count(*) FROM examples WHERE example = $4),
+               (SELECT count(*) FROM student_words WHERE student_id = $5)`,
+		word, synonym, keyword, sentence, studentID).Scan(&words, &examples, &studentWords)
+	if err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if words != 0 || examples != 0 || studentWords != 0 {
+		t.Errorf("after rollback: %d words, %d examples, %d student words; want none", words, examples, studentWords)
+	}
+}
+
+// Повторное добавление того же слова возвращает существующие строки, а не создаёт дубликаты
+func TestDuplicateAddReturnsExistingRow(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	word := "lake" + dbtest.Suffix()
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM words WHERE word = $1", word) })
+
+	pair := models.LanguagePair{Source: "en", Target: "ru"}
+	var ids []int
+	var added []bool
+	for _, typed := range []string{word, "Lakes"} {
+		err := repository.WithTx(ctx, pool, func(tx pgx.Tx) error {
+			wordID, err := repository.UpsertPendingWord(ctx, tx, word, pair)
+			if err != nil {
+				return err
+			}
+			isNew, err := repository.LinkStudentWord(ctx, tx, studentID, wordID, typed)
+			ids, added = append(ids, wordID), append(added, isNew)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("add %q: %v", typed, err)
+		}
+	}
+
+	if ids[0] != ids[1] {
+		t.Errorf("second add created word %d, want existing %d", ids[1], ids[0])
+	}
+	if !added[0] || added[1] {
+		t.Errorf("LinkStudentWord added = %v, want [true false]", added)
+	}
+
+	var rows int
+	var typedForm string
+	err := pool.QueryRow(ctx, "SELECT count(*), max(typed_form) FROM student_words WHERE student_id = $1", studentID).
+		Scan(&rows, &typedForm)
+	if err != nil {
+		t.Fatalf("count student words: %v", err)
+	}
+	if rows != 1 || typedForm != "Lakes" {
+		t.Errorf("student words: %d rows with typed form %q, want 1 row with the last typed form", rows, typedForm)
+	}
+}
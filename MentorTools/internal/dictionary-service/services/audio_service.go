@@ -0,0 +1,43 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/storage"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GenerateAudio озвучивает слово или пример и сохраняет ключ аудио.
+// Ключ выводится из голоса, языка и текста, поэтому уже озвученный текст (тот же пример у другого
+// слова, повторная попытка после сбоя) берётся из хранилища без обращения к провайдеру.
+func GenerateAudio(ctx context.Context, dbpool *pgxpool.Pool, store storage.BlobStore, tts gpt.SpeechSynthesizer, task models.AudioTask) error {
+	key := audioKey(tts, task.Language, task.Text)
+
+	exists, err := store.Exists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check audio in storage: %w", err)
+	}
+	if !exists {
+		speech, err := tts.Synthesize(ctx, task.Text, task.Language)
+		if err != nil {
+			return fmt.Errorf("failed to synthesize speech: %w", err)
+		}
+		if err := store.Put(ctx, key, speech.Audio, speech.ContentType); err != nil {
+			return fmt.Errorf("failed to store audio: %w", err)
+		}
+	}
+
+	return repository.SetAudioKey(ctx, dbpool, task, key)
+}
+
+// audioKey возвращает ключ аудио в хранилище: "audio/<sha256 голоса, языка и текста><расширение>"
+func audioKey(tts gpt.SpeechSynthesizer, language, text string) string {
+	sum := sha256.Sum256([]byte(tts.ID() + "\n" + language + "\n" + text))
+	return "audio/" + hex.EncodeToString(sum[:]) + tts.Extension()
+}
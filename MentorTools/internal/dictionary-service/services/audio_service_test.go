@@ -0,0 +1,62 @@
+package services_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"io"
+	"testing"
+)
+
+// countingSpeech считает обращения к провайдеру синтеза речи
+type countingSpeech struct {
+	gpt.StubSpeech
+	calls int
+}
+
+func (s *countingSpeech) Synthesize(ctx context.Context, text, language string) (gpt.Speech, error) {
+	s.calls++
+	return s.StubSpeech.Synthesize(ctx, text, language)
+}
+
+func TestGenerateAudio(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+	store := storage.NewFileStore(t.TempDir(), storage.DefaultPublicURL)
+	tts := &countingSpeech{}
+
+	word := "apple" + dbtest.Suffix()
+	wordID := dbtest.CreateWord(t, pool, word, "яблоко")
+	task := models.AudioTask{Kind: models.AudioWord, ID: wordID, Text: word, Language: "en"}
+	if err := services.GenerateAudio(ctx, pool, store, tts, task); err != nil {
+		t.Fatalf("GenerateAudio: %v", err)
+	}
+
+	var key string
+	if err := pool.QueryRow(ctx, "SELECT audio_key FROM words WHERE id = $1", wordID).Scan(&key); err != nil {
+		t.Fatalf("read audio key: %v", err)
+	}
+	body, contentType, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	audio, err := io.ReadAll(body)
+	body.Close()
+	want, _ := gpt.StubSpeech{}.Synthesize(ctx, word, "en")
+	if err != nil || string(audio) != string(want.Audio) || contentType != "audio/wav" {
+		t.Errorf("stored audio: %d bytes of %s (err %v), want the stub WAV", len(audio), contentType, err)
+	}
+
+	// Тот же текст у другого слова берётся из хранилища без обращения к провайдеру
+	otherID := dbtest.CreateWord(t, pool, word+"-copy", "яблоко")
+	other := models.AudioTask{Kind: models.AudioWord, ID: otherID, Text: word, Language: "en"}
+	if err := services.GenerateAudio(ctx, pool, store, tts, other); err != nil {
+		t.Fatalf("GenerateAudio(same text): %v", err)
+	}
+	if tts.calls != 1 {
+		t.Errorf("speech provider called %d times, want 1", tts.calls)
+	}
+}
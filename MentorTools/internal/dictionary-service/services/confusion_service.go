@@ -0,0 +1,86 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// contrastiveGap - пропуск в предложении упражнения на различение слов
+const contrastiveGap = "___"
+
+// ErrEmptyExercise возвращается, если GPT не написал ни одного подходящего предложения для упражнения
+var ErrEmptyExercise = errors.New("generated exercise has no valid sentences")
+
+// GetContrastiveExercise возвращает упражнение на различение слов wordID и otherWordID, которые путает ученик.
+// Упражнение общее для пары слов: при первом запросе его пишет GPT, затем оно берётся из базы.
+// Возвращает repository.ErrNotFound, если ученик эти слова не путал.
+func GetContrastiveExercise(ctx context.Context, dbpool *pgxpool.Pool, studentID, wordID, otherWordID int) (models.ContrastiveExercise, error) {
+	if wordID > otherWordID {
+		wordID, otherWordID = otherWordID, wordID
+	}
+
+	pair, err := repository.GetConfusionPair(ctx, dbpool, studentID, wordID, otherWordID)
+	if err != nil {
+		return models.ContrastiveExercise{}, err
+	}
+
+	exercise, err := repository.GetContrastiveExercise(ctx, dbpool, wordID, otherWordID)
+	if errors.Is(err, repository.ErrNotFound) {
+		exercise, err = generateContrastiveExercise(ctx, dbpool, pair)
+	}
+	if err != nil {
+		return exercise, err
+	}
+	exercise.Word = pair.Word
+	exercise.OtherWord = pair.OtherWord
+	return exercise, nil
+}
+
+// generateContrastiveExercise пишет через GPT упражнение на различение слов пары и сохраняет его.
+// Предложения без пропуска или с ответом, который не совпадает ни с одним словом пары, отбрасываются.
+func generateContrastiveExercise(ctx context.Context, dbpool *pgxpool.Pool, pair models.ConfusionPair) (models.ContrastiveExercise, error) {
+	exercise := models.ContrastiveExercise{WordID: pair.WordID, OtherWordID: pair.OtherWordID}
+
+	languages, err := repository.GetWordLanguagePair(ctx, dbpool, pair.WordID)
+	if err != nil {
+		return exercise, err
+	}
+
+	generated, err := gpt.GenerateContrastiveExerciseWithGPT(pair.Word, pair.OtherWord, languages.Source, languages.Target)
+	if err != nil {
+		return exercise, fmt.Errorf("failed to generate exercise with GPT: %w", err)
+	}
+
+	exercise.Explanation = strings.TrimSpace(generated.Explanation)
+	exercise.Items = []models.ContrastiveItem{}
+	for _, item := range generated.Items {
+		sentence := strings.TrimSpace(item.Sentence)
+		answer := strings.TrimSpace(item.Answer)
+		if strings.Count(sentence, contrastiveGap) != 1 {
+			continue
+		}
+		switch {
+		case strings.EqualFold(answer, pair.Word):
+			answer = pair.Word
+		case strings.EqualFold(answer, pair.OtherWord):
+			answer = pair.OtherWord
+		default:
+			continue
+		}
+		exercise.Items = append(exercise.Items, models.ContrastiveItem{
+			Sentence: sentence, Answer: answer, Translation: strings.TrimSpace(item.Translation),
+		})
+	}
+	if len(exercise.Items) == 0 {
+		return exercise, ErrEmptyExercise
+	}
+
+	return exercise, repository.SaveContrastiveExercise(ctx, dbpool, &exercise)
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// regenerationBatchSize - сколько устаревших слов выбирается из БД за один запрос
+const regenerationBatchSize = 50
+
+// RegenerateWord заново запрашивает у GPT контент заполненного слова и сохраняет его как новую версию.
+// Контент, созданный до появления версий, предварительно сохраняется отдельной версией.
+// Если activate = false, новая версия только сохраняется, и её можно сравнить с активной перед выбором.
+// createdBy - репетитор, запросивший перегенерацию, или nil для пакетной перегенерации.
+// Возвращает repository.ErrNotFound, если слова нет, и repository.ErrWordNotCompleted,
+// если его контент ещё не сгенерирован.
+func RegenerateWord(ctx context.Context, dbpool *pgxpool.Pool, wordID int, createdBy *int, activate bool) (models.ContentVersion, error) {
+	var word string
+	err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		var status string
+		var err error
+		word, status, err = repository.GetWordForUpdate(ctx, tx, wordID)
+		if err == nil && status != "completed" {
+			return repository.ErrWordNotCompleted
+		}
+		return err
+	})
+	if err != nil {
+		return models.ContentVersion{}, err
+	}
+
+	pair, err := repository.GetWordLanguagePair(ctx, dbpool, wordID)
+	if err != nil {
+		return models.ContentVersion{}, err
+	}
+
+	// Запрос к GPT выполняется вне транзакции, чтобы не держать блокировку слова на время сетевого вызова
+	details, err := fetchWordDetails(ctx, dbpool, word, pair, nil, nil)
+	if err != nil {
+		return models.ContentVersion{}, err
+	}
+
+	var version int
+	err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		_, status, err := repository.GetWordForUpdate(ctx, tx, wordID)
+		if err != nil {
+			return err
+		}
+		if status != "completed" {
+			return repository.ErrWordNotCompleted
+		}
+
+		if err := repository.SnapshotLegacyContent(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		version, err = repository.CreateContentVersion(ctx, tx, wordID, details, currentGeneration(createdBy))
+		if err != nil || !activate {
+			return err
+		}
+		return repository.ActivateContentVersion(ctx, tx, wordID, version)
+	})
+	if err != nil {
+		return models.ContentVersion{}, err
+	}
+
+	return repository.GetContentVersion(ctx, dbpool, wordID, version)
+}
+
+// ActivateContentVersion делает выбранную версию контента слова активной.
+// Возвращает repository.ErrNotFound, если нет слова или версии.
+func ActivateContentVersion(ctx context.Context, dbpool *pgxpool.Pool, wordID, version int) error {
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		_, status, err := repository.GetWordForUpdate(ctx, tx, wordID)
+		if err != nil {
+			return err
+		}
+		if status != "completed" {
+			return repository.ErrWordNotCompleted
+		}
+		return repository.ActivateContentVersion(ctx, tx, wordID, version)
+	})
+}
+
+// CompareContentVersions сравнивает две версии контента слова: какие поля изменились,
+// какие синонимы и примеры появились и пропали
+func CompareContentVersions(ctx context.Context, dbpool *pgxpool.Pool, wordID, from, to int) (models.VersionComparison, error) {
+	fromVersion, err := repository.GetContentVersion(ctx, dbpool, wordID, from)
+	if err != nil {
+		return models.VersionComparison{}, err
+	}
+	toVersion, err := repository.GetContentVersion(ctx, dbpool, wordID, to)
+	if err != nil {
+		return models.VersionComparison{}, err
+	}
+
+	comparison := models.VersionComparison{From: fromVersion, To: toVersion, ChangedFields: []string{}}
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"transcription", fromVersion.Content.Transcription, toVersion.Content.Transcription},
+		{"translation", fromVersion.Content.Translation, toVersion.Content.Translation},
+		{"description", fromVersion.Content.Description, toVersion.Content.Description},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			comparison.ChangedFields = append(comparison.ChangedFields, field.name)
+		}
+	}
+
+	comparison.SynonymsAdded, comparison.SynonymsRemoved = diffStrings(fromVersion.Content.Synonyms, toVersion.Content.Synonyms)
+	comparison.ExamplesAdded, comparison.ExamplesRemoved = diffStrings(exampleSentences(fromVersion.Content), exampleSentences(toVersion.Content))
+	return comparison, nil
+}
+
+// RegenerateOutdated перегенерирует контент слов, активная версия которых создана промптом старше
+// promptVersion, и сразу делает новые версии активными. Обрабатывается не больше limit слов
+// (0 - без ограничения), между запросами к GPT выдерживается пауза pause.
+// Ошибка по отдельному слову не прерывает обработку, а попадает в отчёт.
+func RegenerateOutdated(ctx context.Context, dbpool *pgxpool.Pool, promptVersion, limit int, pause time.Duration) (models.RegenerationReport, error) {
+	var report models.RegenerationReport
+	afterID := 0
+	for limit == 0 || report.Regenerated+report.Failed < limit {
+		batchSize := regenerationBatchSize
+		if remaining := limit - report.Regenerated - report.Failed; limit > 0 && remaining < batchSize {
+			batchSize = remaining
+		}
+
+		wordIDs, err := repository.FindOutdatedWords(ctx, dbpool, promptVersion, afterID, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("failed to find outdated words: %w", err)
+		}
+		if len(wordIDs) == 0 {
+			break
+		}
+
+		for _, wordID := range wordIDs {
+			afterID = wordID
+			if _, err := RegenerateWord(ctx, dbpool, wordID, nil, true); err != nil {
+				report.Failed++
+				report.Errors = append(report.Errors, fmt.Sprintf("word %d: %v", wordID, err))
+				log.Printf("Failed to regenerate word %d: %v", wordID, err)
+			} else {
+				report.Regenerated++
+			}
+
+			select {
+			case <-ctx.Done():
+				return report, ctx.Err()
+			case <-time.After(pause):
+			}
+		}
+	}
+	return report, nil
+}
+
+// diffStrings возвращает строки, которые есть только во второй и только в первой выборке
+func diffStrings(from, to []string) ([]string, []string) {
+	fromSet := make(map[string]bool, len(from))
+	for _, value := range from {
+		fromSet[value] = true
+	}
+	toSet := make(map[string]bool, len(to))
+	for _, value := range to {
+		toSet[value] = true
+	}
+
+	added, removed := []string{}, []string{}
+	for _, value := range to {
+		if !fromSet[value] {
+			added = append(added, value)
+		}
+	}
+	for _, value := range from {
+		if !toSet[value] {
+			removed = append(removed, value)
+		}
+	}
+	return added, removed
+}
+
+// exampleSentences возвращает предложения примеров версии
+func exampleSentences(details gpt.WordDetails) []string {
+	sentences := make([]string, 0, len(details.Examples))
+	for _, example := range details.Examples {
+		sentences = append(sentences, example.Text)
+	}
+	return sentences
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// badges - значки за достижения; порядок задаёт порядок значков в ответе
+var badges = []models.Badge{
+	{ID: "xp-100", Name: "First steps", Metric: models.BadgeMetricXP, Goal: 100},
+	{ID: "xp-1000", Name: "Dedicated learner", Metric: models.BadgeMetricXP, Goal: 1000},
+	{ID: "xp-10000", Name: "Word master", Metric: models.BadgeMetricXP, Goal: 10000},
+	{ID: "learned-10", Name: "10 words learned", Metric: models.BadgeMetricLearned, Goal: 10},
+	{ID: "learned-100", Name: "100 words learned", Metric: models.BadgeMetricLearned, Goal: 100},
+	{ID: "learned-500", Name: "500 words learned", Metric: models.BadgeMetricLearned, Goal: 500},
+	{ID: "streak-7", Name: "One week streak", Metric: models.BadgeMetricStreak, Goal: 7},
+	{ID: "streak-30", Name: "One month streak", Metric: models.BadgeMetricStreak, Goal: 30},
+	{ID: "streak-100", Name: "100 days streak", Metric: models.BadgeMetricStreak, Goal: 100},
+}
+
+// GetXPSummary возвращает очки опыта ученика за всё время и за текущую неделю, текущую серию и значки
+func GetXPSummary(ctx context.Context, dbpool *pgxpool.Pool, studentID int, now time.Time) (models.XPSummary, error) {
+	var summary models.XPSummary
+
+	allTime, err := repository.GetXP(ctx, dbpool, []int{studentID}, time.Time{}, now)
+	if err != nil {
+		return summary, err
+	}
+	week, err := repository.GetXP(ctx, dbpool, []int{studentID}, weekStart(now, 0), now)
+	if err != nil {
+		return summary, err
+	}
+	stats, err := repository.GetLearningStats(ctx, dbpool, studentID)
+	if err != nil {
+		return summary, err
+	}
+
+	summary.AllTime = allTime[studentID]
+	summary.Week = week[studentID]
+	summary.CurrentStreak = stats.CurrentStreak
+
+	progress := map[string]int{
+		models.BadgeMetricXP:      summary.AllTime.Total,
+		models.BadgeMetricLearned: stats.LearnedWords,
+		models.BadgeMetricStreak:  stats.LongestStreak,
+	}
+	for _, badge := range badges {
+		badge.Progress = progress[badge.Metric]
+		if badge.Progress >= badge.Goal {
+			badge.Progress = badge.Goal
+			badge.Earned = true
+		}
+		summary.Badges = append(summary.Badges, badge)
+	}
+	return summary, nil
+}
+
+// GetLeaderboard составляет таблицу лидеров учеников репетитора по очкам опыта за неделю;
+// weeksAgo = 0 - текущая неделя. Ученики, скрывшие себя, показываются только репетитору и самим себе.
+func GetLeaderboard(ctx context.Context, dbpool *pgxpool.Pool, tutorID, viewerID, weeksAgo int, now time.Time) (models.Leaderboard, error) {
+	start := weekStart(now, weeksAgo)
+	leaderboard := models.Leaderboard{TutorID: tutorID, WeekStart: start, WeekEnd: start.AddDate(0, 0, 7), Entries: []models.LeaderboardEntry{}}
+
+	members, err := repository.GetLeaderboardMembers(ctx, dbpool, tutorID)
+	if err != nil {
+		return leaderboard, err
+	}
+
+	var studentIDs []int
+	for _, member := range members {
+		if member.ShowOnLeaderboard || member.StudentID == viewerID || viewerID == tutorID {
+			studentIDs = append(studentIDs, member.StudentID)
+			leaderboard.Entries = append(leaderboard.Entries, models.LeaderboardEntry{
+				StudentID: member.StudentID,
+				Name:      member.Name,
+				IsMe:      member.StudentID == viewerID,
+				Hidden:    !member.ShowOnLeaderboard,
+			})
+		}
+	}
+
+	until := leaderboard.WeekEnd
+	if until.After(now) {
+		until = now
+	}
+	xp, err := repository.GetXP(ctx, dbpool, studentIDs, start, until)
+	if err != nil {
+		return leaderboard, err
+	}
+
+	for i := range leaderboard.Entries {
+		leaderboard.Entries[i].XP = xp[leaderboard.Entries[i].StudentID]
+	}
+	sort.SliceStable(leaderboard.Entries, func(i, j int) bool {
+		return leaderboard.Entries[i].XP.Total > leaderboard.Entries[j].XP.Total
+	})
+	for i := range leaderboard.Entries {
+		leaderboard.Entries[i].Rank = i + 1
+		if i > 0 && leaderboard.Entries[i].XP.Total == leaderboard.Entries[i-1].XP.Total {
+			leaderboard.Entries[i].Rank = leaderboard.Entries[i-1].Rank
+		}
+	}
+	return leaderboard, nil
+}
+
+// weekStart возвращает начало (понедельник, 00:00) недели, которая была weeksAgo недель назад
+func weekStart(now time.Time, weeksAgo int) time.Time {
+	year, month, day := now.Date()
+	offset := (int(now.Weekday()) + 6) % 7 // Дней с понедельника
+	return time.Date(year, month, day-offset-7*weeksAgo, 0, 0, 0, 0, now.Location())
+}
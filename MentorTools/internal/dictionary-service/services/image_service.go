@@ -0,0 +1,57 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/imaging"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/storage"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// SetWordImage проверяет картинку, делает миниатюру и сохраняет обе в хранилище под ключами из содержимого,
+// после чего назначает картинку слову. Возвращает imaging.ErrUnsupportedImage или imaging.ErrImageTooLarge
+// для неподходящего файла и repository.ErrNotFound, если слова нет.
+func SetWordImage(ctx context.Context, dbpool *pgxpool.Pool, store *storage.ContentStore, wordID, updatedBy int, data []byte, source string) (models.WordImage, error) {
+	img, contentType, err := imaging.Decode(data)
+	if err != nil {
+		return models.WordImage{}, err
+	}
+	thumbnail, err := imaging.Thumbnail(img, imaging.ThumbnailSize)
+	if err != nil {
+		return models.WordImage{}, fmt.Errorf("failed to make thumbnail: %w", err)
+	}
+
+	image := models.WordImage{WordID: wordID, Source: source}
+	if image.Key, err = store.PutContent(ctx, "images", data, contentType); err != nil {
+		return image, fmt.Errorf("failed to store image: %w", err)
+	}
+	if image.ThumbnailKey, err = store.PutContent(ctx, "thumbnails", thumbnail, "image/jpeg"); err != nil {
+		return image, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if err := repository.SetWordImage(ctx, dbpool, image, updatedBy); err != nil {
+		return image, err
+	}
+	image.URL = store.URL(image.Key)
+	image.ThumbnailURL = store.URL(image.ThumbnailKey)
+	return image, nil
+}
+
+// GenerateWordImage рисует картинку к слову через провайдера картинок и назначает её слову.
+// Возвращает repository.ErrNotFound, если слова нет.
+func GenerateWordImage(ctx context.Context, dbpool *pgxpool.Pool, store *storage.ContentStore, generator gpt.ImageGenerator, wordID, updatedBy int) (models.WordImage, error) {
+	word, translation, language, err := repository.GetWordForImage(ctx, dbpool, wordID)
+	if err != nil {
+		return models.WordImage{}, err
+	}
+
+	generated, err := generator.Generate(ctx, gpt.ImagePrompt(word, translation, language))
+	if err != nil {
+		return models.WordImage{}, fmt.Errorf("failed to generate image: %w", err)
+	}
+	return SetWordImage(ctx, dbpool, store, wordID, updatedBy, generated.Data, models.ImageSourceGenerated)
+}
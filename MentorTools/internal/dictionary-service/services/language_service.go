@@ -0,0 +1,26 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrUnsupportedLanguagePair возвращается для языковой пары, для которой нет инструкции GPT
+var ErrUnsupportedLanguagePair = errors.New("unsupported language pair")
+
+// SetStudentLanguagePair меняет языковую пару ученика. Слова, добавленные раньше, остаются в словаре ученика
+// со своей парой, а новые слова генерируются уже для новой пары.
+func SetStudentLanguagePair(ctx context.Context, dbpool *pgxpool.Pool, studentID int, pair models.LanguagePair) (models.LanguagePair, error) {
+	pair.Source = strings.ToLower(strings.TrimSpace(pair.Source))
+	pair.Target = strings.ToLower(strings.TrimSpace(pair.Target))
+	if !gpt.SupportsLanguagePair(pair.Source, pair.Target) {
+		return pair, ErrUnsupportedLanguagePair
+	}
+	return pair, repository.SetStudentLanguagePair(ctx, dbpool, studentID, pair)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Параметры интервального повторения
+const (
+	masteryStreak   = 5                // Столько правильных ответов подряд переводят слово в "learned"
+	maxIntervalDays = 180              // Максимальный интервал между повторениями
+	retryDelay      = 10 * time.Minute // Через сколько повторить слово после ошибки
+)
+
+// RecordAnswer записывает ответ ученика при повторении (eventType = models.EventReviewed),
+// в тесте (models.EventQuizAnswered) или в тренировке написания (models.EventTyped) и назначает следующее повторение.
+//
+// Правильный ответ удваивает интервал повторения, ошибка сбрасывает его и возвращает
+// слово в повторение через несколько минут. После masteryStreak правильных ответов подряд
+// слово становится выученным, а ошибка в выученном слове возвращает его в изучение.
+// Неправильный ответ сохраняется вместе со словом, с которым ученик перепутал слово, для статистики ошибок.
+// Если слова нет у ученика, возвращает repository.ErrNotFound.
+func RecordAnswer(ctx context.Context, dbpool *pgxpool.Pool, studentID int, eventType string, request models.AnswerRequest) (models.AnswerResult, error) {
+	var result models.AnswerResult
+	err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		var err error
+		result, err = recordAnswer(ctx, tx, studentID, eventType, request)
+		return err
+	})
+	return result, err
+}
+
+// recordAnswer записывает ответ и назначает следующее повторение в транзакции вызывающего,
+// чтобы упражнения могли сохранить свои данные вместе с ответом
+func recordAnswer(ctx context.Context, tx pgx.Tx, studentID int, eventType string, request models.AnswerRequest) (models.AnswerResult, error) {
+	wordID, correct := request.WordID, request.Correct
+	result := models.AnswerResult{WordID: wordID}
+
+	status, interval, streak, err := repository.GetReviewStateForUpdate(ctx, tx, studentID, wordID)
+	if err != nil {
+		return result, err
+	}
+
+	newStatus := status
+	delay := retryDelay
+	if correct {
+		streak++
+		interval *= 2
+		if interval == 0 {
+			interval = 1
+		}
+		if interval > maxIntervalDays {
+			interval = maxIntervalDays
+		}
+		delay = time.Duration(interval) * 24 * time.Hour
+		if streak >= masteryStreak {
+			newStatus = models.WordStatusLearned
+		}
+	} else {
+		streak = 0
+		interval = 0
+		newStatus = models.WordStatusNeedToLearn
+	}
+
+	err = repository.RecordLearningEvent(ctx, tx, models.LearningEvent{
+		StudentID: studentID, WordID: wordID, Type: eventType, Correct: &correct,
+	})
+	if err != nil {
+		return result, err
+	}
+	if !correct {
+		if err := repository.RecordAnswerMistake(ctx, tx, studentID, eventType, request); err != nil {
+			return result, err
+		}
+	}
+
+	if newStatus != status {
+		err := repository.RecordLearningEvent(ctx, tx, models.LearningEvent{
+			StudentID: studentID, WordID: wordID, Type: models.EventStatusChanged, OldStatus: status, NewStatus: newStatus,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	nextReviewAt, err := repository.SaveReviewState(ctx, tx, studentID, wordID, newStatus, interval, streak, delay)
+	if err != nil {
+		return result, err
+	}
+
+	result.Status = newStatus
+	result.CorrectStreak = streak
+	result.ReviewIntervalDays = interval
+	result.NextReviewAt = nextReviewAt
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+	_ "time/tzdata" // Часовые пояса учеников не должны зависеть от tzdata в образе
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Состав ежедневного урока
+const (
+	quizShare      = 5 // Каждое пятое задание урока - вопрос теста
+	maxQuizItems   = 5 // Вопросов теста в уроке не больше
+	quizOptions    = 4 // Вариантов перевода в вопросе теста
+	minQuizOptions = 2 // Без стольких вариантов вопрос не задаётся
+)
+
+// GetTodayLesson возвращает урок ученика на сегодняшний день его часового пояса.
+// Если планировщик ещё не составил урок, урок составляется сразу.
+func GetTodayLesson(ctx context.Context, dbpool *pgxpool.Pool, studentID int, now time.Time) (models.DailyLesson, error) {
+	settings, err := repository.GetLessonSettings(ctx, dbpool, studentID)
+	if err != nil {
+		return models.DailyLesson{}, err
+	}
+	day, err := lessonDay(settings.Timezone, now)
+	if err != nil {
+		return models.DailyLesson{}, err
+	}
+
+	lesson, err := repository.GetDailyLesson(ctx, dbpool, studentID, day)
+	if !errors.Is(err, repository.ErrNotFound) {
+		return lesson, err
+	}
+	if _, err := GenerateDailyLesson(ctx, dbpool, studentID, now); err != nil {
+		return lesson, err
+	}
+	return repository.GetDailyLesson(ctx, dbpool, studentID, day)
+}
+
+// GenerateDailyLesson составляет урок ученика на день, который идёт в его часовом поясе в момент now:
+// новые слова из назначенных колод, слова, которые пора повторить до конца дня, и несколько вопросов теста.
+// Всего заданий не больше дневной цели ученика. Возвращает false, если урок на этот день уже был составлен.
+func GenerateDailyLesson(ctx context.Context, dbpool *pgxpool.Pool, studentID int, now time.Time) (bool, error) {
+	settings, err := repository.GetLessonSettings(ctx, dbpool, studentID)
+	if err != nil {
+		return false, err
+	}
+	day, err := lessonDay(settings.Timezone, now)
+	if err != nil {
+		return false, err
+	}
+
+	plan := models.LessonPlan{StudentID: studentID, Date: day, Timezone: settings.Timezone, DailyGoal: settings.DailyGoal}
+
+	newLimit := settings.NewWords
+	if newLimit > settings.DailyGoal {
+		newLimit = settings.DailyGoal
+	}
+	newIDs, err := repository.PickNewLessonWords(ctx, dbpool, studentID, settings.Decks, newLimit)
+	if err != nil {
+		return false, err
+	}
+
+	quizCount := settings.DailyGoal / quizShare
+	if quizCount > maxQuizItems {
+		quizCount = maxQuizItems
+	}
+	reviewLimit := settings.DailyGoal - len(newIDs) - quizCount
+	if reviewLimit < 0 {
+		reviewLimit = 0
+	}
+	dueIDs, err := repository.PickDueLessonWords(ctx, dbpool, studentID, day.AddDate(0, 0, 1), newIDs, reviewLimit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, wordID := range newIDs {
+		plan.Items = append(plan.Items, models.LessonPlanItem{Kind: models.LessonItemNew, WordID: wordID})
+	}
+	for _, wordID := range dueIDs {
+		plan.Items = append(plan.Items, models.LessonPlanItem{Kind: models.LessonItemReview, WordID: wordID})
+	}
+
+	quizIDs, err := repository.PickQuizLessonWords(ctx, dbpool, studentID, append(newIDs, dueIDs...), quizCount)
+	if err != nil {
+		return false, err
+	}
+	for _, wordID := range quizIDs {
+		item, ok, err := quizItem(ctx, dbpool, wordID)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			plan.Items = append(plan.Items, item)
+		}
+	}
+
+	pair, err := repository.GetStudentLanguagePair(ctx, dbpool, studentID)
+	if err != nil {
+		return false, err
+	}
+	wordOfTheDayID, err := repository.PickWordOfTheDay(ctx, dbpool, studentID, pair)
+	if err == nil {
+		plan.WordOfTheDayID = &wordOfTheDayID
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+
+	var created bool
+	err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		created, err = repository.CreateDailyLesson(ctx, tx, plan)
+		return err
+	})
+	return created, err
+}
+
+// UpdateLessonSettings меняет заполненные поля настроек урока ученика; updatedBy - ученик или репетитор.
+// Урок, уже составленный на сегодня, не меняется.
+func UpdateLessonSettings(ctx context.Context, dbpool *pgxpool.Pool, studentID, updatedBy int, update models.LessonSettingsUpdate) (models.LessonSettings, error) {
+	var settings models.LessonSettings
+	err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		var err error
+		settings, err = repository.GetLessonSettings(ctx, tx, studentID)
+		if err != nil {
+			return err
+		}
+
+		if update.Timezone != nil {
+			settings.Timezone = *update.Timezone
+		}
+		if update.DailyGoal != nil {
+			settings.DailyGoal = *update.DailyGoal
+		}
+		if update.NewWords != nil {
+			settings.NewWords = *update.NewWords
+		}
+		if update.LessonHour != nil {
+			settings.LessonHour = *update.LessonHour
+		}
+		if update.Decks != nil {
+			settings.Decks = *update.Decks
+		}
+		return repository.SaveLessonSettings(ctx, tx, studentID, settings, updatedBy)
+	})
+	return settings, err
+}
+
+// quizItem составляет вопрос теста: перевод слова среди переводов других слов той же языковой пары.
+// Возвращает false, если в словаре слишком мало слов для вариантов ответа.
+func quizItem(ctx context.Context, dbpool *pgxpool.Pool, wordID int) (models.LessonPlanItem, bool, error) {
+	translation, options, err := repository.GetQuizOptions(ctx, dbpool, wordID, quizOptions-1)
+	if err != nil {
+		return models.LessonPlanItem{}, false, err
+	}
+	if len(options)+1 < minQuizOptions {
+		return models.LessonPlanItem{}, false, nil
+	}
+
+	answerIndex := rand.Intn(len(options) + 1)
+	options = append(options, "")
+	copy(options[answerIndex+1:], options[answerIndex:])
+	options[answerIndex] = translation
+
+	return models.LessonPlanItem{Kind: models.LessonItemQuiz, WordID: wordID, Options: options, AnswerIndex: answerIndex}, true, nil
+}
+
+// lessonDay возвращает полночь дня, который идёт в часовом поясе timezone в момент now
+func lessonDay(timezone string, now time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := now.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location), nil
+}
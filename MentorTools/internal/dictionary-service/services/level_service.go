@@ -0,0 +1,116 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/frequency"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// levelTaggingBatchSize - сколько слов без уровня выбирается из БД за один запрос
+const levelTaggingBatchSize = 50
+
+// resolveWordLevel определяет уровень слова: сначала по частотному списку языка,
+// для слов, которых в списке нет, - по оценке GPT.
+// Возвращает флаг, был ли запрос к GPT, чтобы пакетная обработка могла соблюдать лимит запросов.
+func resolveWordLevel(word string, pair models.LanguagePair) (models.WordLevel, bool, error) {
+	if level := listWordLevel(word, pair); level != nil {
+		return *level, false, nil
+	}
+
+	level, err := gpt.GetWordLevelFromGPT(word, pair.Source)
+	if err != nil {
+		return models.WordLevel{}, true, fmt.Errorf("failed to fetch word level from GPT: %w", err)
+	}
+	if !models.IsCEFRLevel(level) {
+		return models.WordLevel{}, true, fmt.Errorf("GPT returned invalid level %q", level)
+	}
+	return models.WordLevel{Level: level, Source: models.LevelSourceGPT}, true, nil
+}
+
+// listWordLevel определяет уровень слова при его заполнении только по частотному списку, без запроса к GPT:
+// добавление слова не ждёт GPT и не расходует лимит запросов. Уровень слов, которых нет в списке,
+// позже определяет worker уровней (см. TagWordLevel).
+func listWordLevel(word string, pair models.LanguagePair) *models.WordLevel {
+	entry, ok := frequency.Lookup(word, pair.Source)
+	if !ok || !models.IsCEFRLevel(entry.Level) {
+		return nil
+	}
+	rank := entry.Rank
+	return &models.WordLevel{Level: entry.Level, FrequencyRank: &rank, Source: models.LevelSourceList}
+}
+
+// TagWordLevel определяет уровень слова без уровня (по списку или по оценке GPT) и сохраняет его
+func TagWordLevel(ctx context.Context, dbpool *pgxpool.Pool, word models.UntaggedWord) error {
+	level, _, err := resolveWordLevel(word.Word, word.Languages)
+	if err != nil {
+		return err
+	}
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		return repository.SetWordLevel(ctx, tx, word.ID, level)
+	})
+}
+
+// saveWordLevel сохраняет уровень слова, если его удалось определить
+func saveWordLevel(ctx context.Context, tx pgx.Tx, wordID int, level *models.WordLevel) error {
+	if level == nil {
+		return nil
+	}
+	return repository.SetWordLevel(ctx, tx, wordID, *level)
+}
+
+// TagUntaggedWords присваивает уровень заполненным словам, у которых его ещё нет.
+// Обрабатывается не больше limit слов (0 - без ограничения); после каждого запроса к GPT
+// выдерживается пауза pause. Ошибка по отдельному слову не прерывает обработку, а попадает в отчёт.
+func TagUntaggedWords(ctx context.Context, dbpool *pgxpool.Pool, limit int, pause time.Duration) (models.LevelTaggingReport, error) {
+	var report models.LevelTaggingReport
+	afterID := 0
+	for limit == 0 || report.Tagged+report.Failed < limit {
+		batchSize := levelTaggingBatchSize
+		if remaining := limit - report.Tagged - report.Failed; limit > 0 && remaining < batchSize {
+			batchSize = remaining
+		}
+
+		words, err := repository.FindUntaggedWords(ctx, dbpool, afterID, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("failed to find words without level: %w", err)
+		}
+		if len(words) == 0 {
+			break
+		}
+
+		for _, word := range words {
+			afterID = word.ID
+			level, requested, err := resolveWordLevel(word.Word, word.Languages)
+			if err == nil {
+				err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+					return repository.SetWordLevel(ctx, tx, word.ID, level)
+				})
+			}
+			if err != nil {
+				report.Failed++
+				report.Errors = append(report.Errors, fmt.Sprintf("word %d: %v", word.ID, err))
+				log.Printf("Failed to tag level of word %d: %v", word.ID, err)
+			} else {
+				report.Tagged++
+			}
+
+			if !requested {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return report, ctx.Err()
+			case <-time.After(pause):
+			}
+		}
+	}
+	return report, nil
+}
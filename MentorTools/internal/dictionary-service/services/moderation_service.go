@@ -0,0 +1,145 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// fieldEdit - новое значение поля; nil - поле не меняется
+type fieldEdit struct {
+	field string
+	value *string
+}
+
+// EditWordContent исправляет транскрипцию, перевод или описание слова.
+// Каждое изменённое поле записывается в историю правок с автором и номером версии.
+func EditWordContent(ctx context.Context, dbpool *pgxpool.Pool, authorID, wordID int, request models.WordContentRequest) error {
+	fields := []fieldEdit{
+		{"transcription", request.Transcription},
+		{"translation", request.Translation},
+		{"definition", request.Description},
+	}
+
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if err := repository.LockWordForEdit(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		for _, edit := range fields {
+			if edit.value == nil {
+				continue
+			}
+			old, err := repository.UpdateWordField(ctx, tx, wordID, edit.field, *edit.value)
+			if err != nil {
+				return err
+			}
+			if err := recordEdit(ctx, tx, wordID, authorID, models.EditTargetWord, 0, edit.field, old, edit.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// EditExample исправляет предложение или перевод примера слова, отклоняет или возвращает его.
+// Если пример не связан со словом, возвращает repository.ErrNotFound.
+func EditExample(ctx context.Context, dbpool *pgxpool.Pool, authorID, wordID, exampleID int, request models.ExampleContentRequest) error {
+	fields := []fieldEdit{
+		{"sentence", request.Sentence},
+		{"translation", request.Translation},
+	}
+
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if err := repository.LockWordForEdit(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		for _, edit := range fields {
+			if edit.value == nil {
+				continue
+			}
+			old, err := repository.UpdateExampleField(ctx, tx, wordID, exampleID, edit.field, *edit.value)
+			if err != nil {
+				return err
+			}
+			if err := recordEdit(ctx, tx, wordID, authorID, models.EditTargetExample, exampleID, edit.field, old, edit.value); err != nil {
+				return err
+			}
+		}
+
+		if request.Hidden != nil {
+			old, err := repository.SetExampleHidden(ctx, tx, wordID, exampleID, *request.Hidden)
+			if err != nil {
+				return err
+			}
+			oldValue, newValue := strconv.FormatBool(old), strconv.FormatBool(*request.Hidden)
+			if err := recordEdit(ctx, tx, wordID, authorID, models.EditTargetExample, exampleID, "hidden", &oldValue, &newValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetSynonymHidden скрывает синоним слова или возвращает его.
+// Если такого синонима у слова нет, возвращает repository.ErrNotFound.
+func SetSynonymHidden(ctx context.Context, dbpool *pgxpool.Pool, authorID, wordID int, synonym string, hidden bool) error {
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if err := repository.LockWordForEdit(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		linkedID, old, err := repository.SetSynonymHidden(ctx, tx, wordID, synonym, hidden)
+		if err != nil {
+			return err
+		}
+		oldValue, newValue := strconv.FormatBool(old), strconv.FormatBool(hidden)
+		return recordEdit(ctx, tx, wordID, authorID, models.EditTargetSynonym, linkedID, "hidden", &oldValue, &newValue)
+	})
+}
+
+// ApproveWord публикует контент слова, ожидающий проверки
+func ApproveWord(ctx context.Context, dbpool *pgxpool.Pool, reviewerID, wordID int) error {
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if err := repository.LockWordForEdit(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		old, err := repository.PublishWord(ctx, tx, wordID, reviewerID)
+		if err != nil {
+			return err
+		}
+		oldValue, newValue := strconv.FormatBool(old), "true"
+		return recordEdit(ctx, tx, wordID, reviewerID, models.EditTargetWord, 0, "published", &oldValue, &newValue)
+	})
+}
+
+// RejectWord отклоняет контент слова целиком: он скрывается, а слово заново ставится в очередь обогащения
+func RejectWord(ctx context.Context, dbpool *pgxpool.Pool, reviewerID, wordID int) error {
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if err := repository.LockWordForEdit(ctx, tx, wordID); err != nil {
+			return err
+		}
+
+		if err := repository.RejectWord(ctx, tx, wordID, reviewerID); err != nil {
+			return err
+		}
+		newValue := "false"
+		return recordEdit(ctx, tx, wordID, reviewerID, models.EditTargetWord, 0, "published", nil, &newValue)
+	})
+}
+
+// recordEdit записывает правку в историю, если значение действительно изменилось
+func recordEdit(ctx context.Context, tx pgx.Tx, wordID, authorID int, target string, targetID int, field string, oldValue, newValue *string) error {
+	if oldValue != nil && newValue != nil && *oldValue == *newValue {
+		return nil
+	}
+	return repository.RecordContentEdit(ctx, tx, wordID, models.ContentEdit{
+		Target: target, TargetID: targetID, Field: field, OldValue: oldValue, NewValue: newValue, AuthorID: authorID,
+	})
+}
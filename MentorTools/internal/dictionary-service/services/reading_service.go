@@ -0,0 +1,136 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/normalizer"
+	"MentorTools/dictionary-service/reading"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// AnalyzeText разбирает текст на предложения и слова и сверяет каждое слово со словарём ученика:
+// выученные слова получают статус "known", слова в процессе изучения - "learning", остальные - "unknown".
+// Слова ищутся по лемме и по формам, в которых ученик их вводил; покрытие считается
+// как доля выученных слов среди всех слов текста (с повторами).
+func AnalyzeText(ctx context.Context, dbpool *pgxpool.Pool, studentID int, text string) (models.ReadingAnalysis, error) {
+	pair, err := repository.GetStudentLanguagePair(ctx, dbpool, studentID)
+	if err != nil {
+		return models.ReadingAnalysis{}, fmt.Errorf("failed to fetch student languages: %w", err)
+	}
+
+	analysis := models.ReadingAnalysis{
+		Languages: pair,
+		Sentences: []string{},
+		Tokens:    []models.ReadingToken{},
+		Unknown:   []models.UnknownWord{},
+	}
+
+	type parsedWord struct {
+		token      models.ReadingToken
+		candidates []string
+	}
+	var words []parsedWord
+	var lookup []string
+	seen := make(map[string]bool)
+
+	for i, sentence := range reading.Split(text) {
+		analysis.Sentences = append(analysis.Sentences, sentence.Text)
+		for _, word := range sentence.Words {
+			normalized := normalizer.NormalizeFor(trimPossessive(word.Text, pair.Source), pair.Source)
+			if normalized.Typed == "" {
+				continue
+			}
+			for _, candidate := range normalized.Candidates {
+				if !seen[candidate] {
+					seen[candidate] = true
+					lookup = append(lookup, candidate)
+				}
+			}
+			words = append(words, parsedWord{
+				token: models.ReadingToken{
+					Text: word.Text, Lemma: normalized.Lemma, Start: word.Start, End: word.End,
+					Sentence: i, Status: models.TokenUnknown,
+				},
+				candidates: normalized.Candidates,
+			})
+		}
+	}
+	if len(words) == 0 {
+		return analysis, nil
+	}
+
+	vocabulary, err := repository.FindStudentVocabulary(ctx, dbpool, studentID, pair, lookup)
+	if err != nil {
+		return analysis, fmt.Errorf("failed to look up student vocabulary: %w", err)
+	}
+
+	unknownIndex := make(map[string]int)
+	for _, word := range words {
+		token := word.token
+		for _, candidate := range word.candidates {
+			match, ok := vocabulary[candidate]
+			if !ok {
+				continue
+			}
+			wordID := match.WordID
+			token.Lemma, token.WordID = candidate, &wordID
+			token.Status = models.TokenLearning
+			if match.Status == models.WordStatusLearned {
+				token.Status = models.TokenKnown
+			}
+			break
+		}
+
+		analysis.TotalWords++
+		switch token.Status {
+		case models.TokenKnown:
+			analysis.KnownWords++
+		case models.TokenLearning:
+			analysis.LearningWords++
+		default:
+			analysis.UnknownWords++
+			if index, ok := unknownIndex[token.Lemma]; ok {
+				analysis.Unknown[index].Occurrences++
+			} else {
+				unknownIndex[token.Lemma] = len(analysis.Unknown)
+				analysis.Unknown = append(analysis.Unknown, models.UnknownWord{
+					Lemma: token.Lemma, Occurrences: 1, Sentence: analysis.Sentences[token.Sentence],
+				})
+			}
+		}
+		analysis.Tokens = append(analysis.Tokens, token)
+	}
+
+	analysis.Coverage = percent(analysis.KnownWords, analysis.TotalWords)
+	analysis.CoverageWithLearning = percent(analysis.KnownWords+analysis.LearningWords, analysis.TotalWords)
+	return analysis, nil
+}
+
+// AddStudentExample сохраняет предложение, из которого ученик добавил слово, как его личный пример
+func AddStudentExample(ctx context.Context, dbpool *pgxpool.Pool, studentID, wordID int, sentence string) error {
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		return repository.SaveStudentExample(ctx, tx, studentID, wordID, sentence)
+	})
+}
+
+// trimPossessive убирает английское притяжательное окончание ("teacher's" -> "teacher")
+func trimPossessive(word, language string) string {
+	if language != "en" || len(word) <= 2 {
+		return word
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "'S")
+}
+
+// percent возвращает долю part от total в процентах с точностью до десятых
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/normalizer"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// minRelationConfidence - связи, в которых GPT уверен меньше, не сохраняются,
+// чтобы случайные слова не попадали в словарь и очередь обогащения
+const minRelationConfidence = 0.5
+
+// MaxRelationGraphNodes - сколько слов может быть в графе связей
+const MaxRelationGraphNodes = 100
+
+// normalizeRelations приводит связанные слова к леммам и отбрасывает связи неизвестных типов,
+// связи с самим словом, повторы и связи с уверенностью ниже minRelationConfidence
+func normalizeRelations(ctx context.Context, dbpool *pgxpool.Pool, word string, relations []gpt.Relation, pair models.LanguagePair) ([]gpt.Relation, error) {
+	var normalized []gpt.Relation
+	seen := make(map[gpt.Relation]bool)
+	for _, relation := range relations {
+		relation.Type = strings.ToLower(strings.TrimSpace(relation.Type))
+		if !models.IsRelationType(relation.Type) || relation.Confidence < minRelationConfidence {
+			continue
+		}
+		if relation.Confidence > 1 {
+			relation.Confidence = 1
+		}
+
+		form := normalizer.NormalizeFor(relation.Word, pair.Source)
+		if form.Typed == "" {
+			continue
+		}
+		lemma, err := resolveLemma(ctx, dbpool, form, pair, false)
+		if err != nil {
+			return nil, err
+		}
+		if lemma == word {
+			continue
+		}
+
+		key := gpt.Relation{Word: lemma, Type: relation.Type}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		relation.Word = lemma
+		normalized = append(normalized, relation)
+	}
+	return normalized, nil
+}
+
+// BuildRelationGraph обходит связи в ширину от слова query.WordID на query.Depth шагов.
+// Обход останавливается, когда в графе набирается MaxRelationGraphNodes слов; тогда Truncated = true.
+// Связи слов, контент которых ещё не проверил репетитор, видят только репетиторы (includeUnpublished).
+// Если слова нет в словаре, возвращает repository.ErrNotFound.
+func BuildRelationGraph(ctx context.Context, dbpool *pgxpool.Pool, query models.RelationGraphQuery, includeUnpublished bool) (models.RelationGraph, error) {
+	graph := models.RelationGraph{Nodes: []models.RelationNode{}, Edges: []models.RelationEdge{}}
+
+	// Симметричная связь может быть сохранена с обеих сторон, поэтому её ключ не зависит от порядка слов
+	type edgeKey struct {
+		from, to     int
+		relationType string
+	}
+	seenEdges := make(map[edgeKey]bool)
+
+	depths := map[int]int{query.WordID: 0}
+	order := []int{query.WordID}
+	frontier := []int{query.WordID}
+
+	for depth := 1; depth <= query.Depth && len(frontier) > 0; depth++ {
+		edges, err := repository.GetRelationEdges(ctx, dbpool, frontier, query.Types, query.MinConfidence, includeUnpublished)
+		if err != nil {
+			return graph, err
+		}
+
+		var next []int
+		for _, edge := range edges {
+			key := edgeKey{edge.From, edge.To, edge.Type}
+			if !edge.Directed && key.from > key.to {
+				key.from, key.to = key.to, key.from
+			}
+			if seenEdges[key] {
+				continue
+			}
+
+			for _, id := range []int{edge.From, edge.To} {
+				if _, ok := depths[id]; ok {
+					continue
+				}
+				if len(depths) >= MaxRelationGraphNodes {
+					graph.Truncated = true
+					continue
+				}
+				depths[id] = depth
+				order = append(order, id)
+				next = append(next, id)
+			}
+
+			_, fromKnown := depths[edge.From]
+			_, toKnown := depths[edge.To]
+			if fromKnown && toKnown {
+				seenEdges[key] = true
+				graph.Edges = append(graph.Edges, edge)
+			}
+		}
+		frontier = next
+	}
+
+	nodes, err := repository.GetRelationNodes(ctx, dbpool, order)
+	if err != nil {
+		return graph, err
+	}
+	if _, ok := nodes[query.WordID]; !ok {
+		return graph, repository.ErrNotFound
+	}
+	for _, id := range order {
+		node := nodes[id]
+		node.Depth = depths[id]
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	return graph, nil
+}
@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"testing"
+)
+
+// Связи, которые GPT сгенерировал для слова, ждущего проверки, видят в графе только репетиторы
+func TestBuildRelationGraphHidesUnpublishedRelations(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	suffix := dbtest.Suffix()
+	happyID := dbtest.CreateWord(t, pool, "happy"+suffix, "счастливый")
+	gladID := dbtest.CreateWord(t, pool, "glad"+suffix, "довольный")
+	joyfulID := dbtest.CreateWord(t, pool, "joyful"+suffix, "радостный")
+	dbtest.Exec(t, pool, "UPDATE words SET published = false WHERE id = $1", joyfulID)
+	dbtest.Exec(t, pool, `
+        INSERT INTO word_links (word_id, linked_word_id, relation_type, direction, confidence)
+        VALUES ($1, $2, 'synonym', 'both', 0.9), ($3, $1, 'synonym', 'both', 0.9)`, happyID, gladID, joyfulID)
+
+	query := models.RelationGraphQuery{WordID: happyID, Depth: 1}
+	for _, tt := range []struct {
+		name               string
+		includeUnpublished bool
+		want               []int
+	}{
+		{"student", false, []int{happyID, gladID}},
+		{"tutor", true, []int{happyID, gladID, joyfulID}},
+	} {
+		graph, err := services.BuildRelationGraph(ctx, pool, query, tt.includeUnpublished)
+		if err != nil {
+			t.Fatalf("%s: BuildRelationGraph: %v", tt.name, err)
+		}
+		var got []int
+		for _, node := range graph.Nodes {
+			got = append(got, node.ID)
+		}
+		if len(got) != len(tt.want) || len(graph.Edges) != len(tt.want)-1 {
+			t.Errorf("%s: nodes %v with %d edges, want %v", tt.name, got, len(graph.Edges), tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: nodes %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrAlreadyReviewed возвращается при повторном отзыве о примере ученика
+var ErrAlreadyReviewed = errors.New("submission is already reviewed")
+
+// SubmitExample проверяет через GPT предложение, которое ученик написал со словом из своего словаря:
+// грамматику и употребление слова. Если ошибок нет, предложение сохраняется примером к слову,
+// который видит автор; репетитор может одобрить его для всех учеников.
+// Возвращает repository.ErrNotFound, если слова нет в словаре ученика.
+func SubmitExample(ctx context.Context, dbpool *pgxpool.Pool, studentID int, request models.ExampleSubmissionRequest) (models.ExampleSubmission, error) {
+	submission := models.ExampleSubmission{StudentID: studentID, WordID: request.WordID, Sentence: request.Sentence}
+
+	word, pair, err := repository.GetStudentWordText(ctx, dbpool, studentID, request.WordID)
+	if err != nil {
+		return submission, err
+	}
+	submission.Word = word
+
+	check, err := gpt.CheckSentenceWithGPT(submission.Word, request.Sentence, pair.Source, pair.Target)
+	if err != nil {
+		return submission, fmt.Errorf("failed to check sentence with GPT: %w", err)
+	}
+
+	submission.GrammarCorrect = check.GrammarCorrect
+	submission.UsageCorrect = check.UsageCorrect
+	submission.Accepted = check.GrammarCorrect && check.UsageCorrect
+	submission.Explanation = check.Explanation
+	submission.Translation = check.Translation
+	if check.Corrected != request.Sentence {
+		submission.Corrected = check.Corrected
+	}
+
+	err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		if submission.Accepted {
+			exampleID, err := repository.CreateStudentExample(ctx, tx, studentID, request.WordID, request.Sentence, check.Translation)
+			if err != nil {
+				return err
+			}
+			submission.ExampleID = &exampleID
+		}
+		return repository.CreateSubmission(ctx, tx, &submission)
+	})
+	return submission, err
+}
+
+// ReviewSubmission сохраняет отзыв репетитора о примере ученика. Одобренный пример становится виден всем
+// ученикам - даже если GPT нашёл в нём ошибки, тогда пример создаётся при одобрении. Отклонённый пример скрывается.
+// Возвращает repository.ErrNotFound, если примера нет, и ErrAlreadyReviewed, если отзыв уже оставлен.
+func ReviewSubmission(ctx context.Context, dbpool *pgxpool.Pool, submissionID int64, reviewerID int, request models.SubmissionReviewRequest) (models.ExampleSubmission, error) {
+	var submission models.ExampleSubmission
+	err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		var err error
+		submission, err = repository.GetSubmissionForUpdate(ctx, tx, submissionID)
+		if err != nil {
+			return err
+		}
+		if submission.ReviewStatus != models.SubmissionPending {
+			return ErrAlreadyReviewed
+		}
+
+		submission.ReviewStatus = models.SubmissionRejected
+		if request.Approved {
+			submission.ReviewStatus = models.SubmissionApproved
+		}
+		submission.ReviewComment = request.Comment
+		submission.ReviewedBy = &reviewerID
+
+		if submission.ExampleID == nil && request.Approved {
+			exampleID, err := repository.CreateStudentExample(ctx, tx, submission.StudentID, submission.WordID, submission.Sentence, "")
+			if err != nil {
+				return err
+			}
+			submission.ExampleID = &exampleID
+		}
+		if submission.ExampleID != nil {
+			if err := repository.SetStudentExampleApproved(ctx, tx, *submission.ExampleID, request.Approved); err != nil {
+				return err
+			}
+		}
+		return repository.SaveSubmissionReview(ctx, tx, &submission)
+	})
+	return submission, err
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/spelling"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Размер тренировки написания
+const (
+	defaultTypingWords = 10
+	maxTypingWords     = 50
+)
+
+// ErrNothingToPractice возвращается, если у ученика нет слов с переводом для тренировки
+var ErrNothingToPractice = errors.New("no words to practice")
+
+// StartTypingSession начинает тренировку написания из слов, которые ученику пора повторить.
+// Возвращает ErrNothingToPractice, если подходящих слов нет.
+func StartTypingSession(ctx context.Context, dbpool *pgxpool.Pool, studentID int, request models.TypingSessionRequest) (models.TypingSession, error) {
+	if request.Mode == "" {
+		request.Mode = models.TypingModeBoth
+	}
+	if request.Limit <= 0 || request.Limit > maxTypingWords {
+		request.Limit = defaultTypingWords
+	}
+
+	wordIDs, err := repository.PickTypingWords(ctx, dbpool, studentID, request.Deck, request.Limit)
+	if err != nil {
+		return models.TypingSession{}, err
+	}
+	if len(wordIDs) == 0 {
+		return models.TypingSession{}, ErrNothingToPractice
+	}
+
+	var sessionID string
+	err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		sessionID, err = repository.CreateTypingSession(ctx, tx, studentID, request.Mode, wordIDs)
+		return err
+	})
+	if err != nil {
+		return models.TypingSession{}, err
+	}
+
+	return GetTypingSession(ctx, dbpool, sessionID, studentID)
+}
+
+// GetTypingSession возвращает тренировку ученика. Задания показывают то, что выбрано режимом
+// тренировки: в режиме "audio" перевод остаётся только у слов, для которых ещё нет аудио.
+// Если тренировки нет, возвращает repository.ErrNotFound.
+func GetTypingSession(ctx context.Context, dbpool *pgxpool.Pool, sessionID string, studentID int) (models.TypingSession, error) {
+	session, err := repository.GetTypingSession(ctx, dbpool, sessionID, studentID)
+	if err != nil {
+		return session, err
+	}
+
+	for i := range session.Prompts {
+		prompt := &session.Prompts[i]
+		switch session.Mode {
+		case models.TypingModeTranslation:
+			prompt.AudioKey = ""
+		case models.TypingModeAudio:
+			if prompt.AudioKey != "" && !prompt.Answered {
+				prompt.Translation = ""
+			}
+		}
+	}
+	return session, nil
+}
+
+// AnswerTyping проверяет набранное слово с допуском на опечатки, сохраняет ответ в тренировке
+// и засчитывает его в повторение слова, как ответ в других упражнениях. Если вместо слова набрано другое слово
+// той же языковой пары (affect вместо effect), ответ не засчитывается и сохраняется как путаница этих слов.
+// Возвращает repository.ErrNotFound, если задания нет, и repository.ErrAlreadyAnswered при повторном ответе.
+func AnswerTyping(ctx context.Context, dbpool *pgxpool.Pool, studentID int, request models.TypingAnswerRequest) (models.TypingAnswerResult, error) {
+	var result models.TypingAnswerResult
+	err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		word, err := repository.GetTypingWordForUpdate(ctx, tx, request.SessionID, studentID, request.WordID)
+		if err != nil {
+			return err
+		}
+
+		// Перед допуском на опечатки проверяется, не набрано ли другое слово словаря
+		var confusedID int
+		var lookupErr error
+		grade := spelling.CheckWord(word, request.Typed, func(typed string) bool {
+			confusedID, lookupErr = repository.FindConfusedWord(ctx, tx, request.WordID, typed)
+			return confusedID != 0
+		})
+		if lookupErr != nil {
+			return lookupErr
+		}
+
+		result = models.TypingAnswerResult{
+			WordID:     request.WordID,
+			Correct:    grade.Correct,
+			Exact:      grade.Exact,
+			Normalized: grade.Normalized,
+			Expected:   word,
+			Typed:      request.Typed,
+			Distance:   grade.Distance,
+			Tolerance:  grade.Tolerance,
+			Mistakes:   make([]models.SpellingMistake, 0, len(grade.Mistakes)),
+		}
+		if grade.KnownWord {
+			result.ConfusedWordID = confusedID
+		}
+		for _, mistake := range grade.Mistakes {
+			result.Mistakes = append(result.Mistakes, models.SpellingMistake{
+				Kind: mistake.Kind, Position: mistake.Position, Expected: mistake.Expected, Typed: mistake.Typed,
+			})
+		}
+
+		answer := models.AnswerRequest{WordID: request.WordID, Correct: grade.Correct, Answer: request.Typed, ChosenWordID: result.ConfusedWordID}
+		if result.Review, err = recordAnswer(ctx, tx, studentID, models.EventTyped, answer); err != nil {
+			return err
+		}
+		return repository.SaveTypingAnswer(ctx, tx, request.SessionID, request.WordID, request.Typed, grade.Correct, grade.Distance)
+	})
+	return result, err
+}
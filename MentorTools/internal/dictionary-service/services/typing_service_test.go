@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+func TestAnswerTypingConfusedWord(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	suffix := dbtest.Suffix()
+	effectID := dbtest.CreateWord(t, pool, "effect"+suffix, "результат")
+	affectID := dbtest.CreateWord(t, pool, "affect"+suffix, "влиять")
+	studentID := dbtest.NewID()
+	dbtest.CleanupStudent(t, pool, studentID)
+	dbtest.AddStudentWord(t, pool, studentID, effectID)
+
+	// Другое слово словаря на расстоянии одной буквы - не опечатка
+	result := answerTyping(t, pool, studentID, effectID, "affect"+suffix)
+	if result.Correct {
+		t.Errorf("typed affect for effect: answer accepted as a typo")
+	}
+	if result.ConfusedWordID != affectID {
+		t.Errorf("ConfusedWordID = %d, want affect %d", result.ConfusedWordID, affectID)
+	}
+
+	pairs, err := repository.GetConfusionPairs(ctx, pool, studentID, 10)
+	if err != nil {
+		t.Fatalf("GetConfusionPairs: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].Mistakes != 1 ||
+		pairs[0].WordID != minID(effectID, affectID) || pairs[0].OtherWordID != maxID(effectID, affectID) {
+		t.Errorf("confusion pairs = %+v, want one effect/affect pair", pairs)
+	}
+
+	// Опечатка, которая не является словом, засчитывается
+	result = answerTyping(t, pool, studentID, effectID, "efect"+suffix)
+	if !result.Correct || result.ConfusedWordID != 0 {
+		t.Errorf("typed efect for effect: correct %v, confused word %d; want a tolerated typo", result.Correct, result.ConfusedWordID)
+	}
+}
+
+// answerTyping начинает тренировку из одного слова и отвечает на него
+func answerTyping(t *testing.T, pool *pgxpool.Pool, studentID, wordID int, typed string) models.TypingAnswerResult {
+	t.Helper()
+	ctx := context.Background()
+
+	var sessionID string
+	err := repository.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		var err error
+		sessionID, err = repository.CreateTypingSession(ctx, tx, studentID, models.TypingModeTranslation, []int{wordID})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("CreateTypingSession: %v", err)
+	}
+
+	result, err := services.AnswerTyping(ctx, pool, studentID, models.TypingAnswerRequest{SessionID: sessionID, WordID: wordID, Typed: typed})
+	if err != nil {
+		t.Fatalf("AnswerTyping(%q): %v", typed, err)
+	}
+	return result
+}
+
+func minID(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxID(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
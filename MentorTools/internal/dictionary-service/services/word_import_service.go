@@ -0,0 +1,110 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/normalizer"
+	"MentorTools/dictionary-service/repository"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ImportWords добавляет ученику слова, прочитанные из CSV или колоды Anki.
+//
+// Каждое слово приводится к лемме и сверяется со словарём: уже заполненные слова
+// просто добавляются ученику, новые добавляются со статусом "pending" и заполняются
+// worker-ом обогащения, поэтому импорт не обращается к GPT и не ждёт его.
+// Перевод из файла сохраняется только у слова ученика: общий словарь он не меняет.
+// Каждая строка сохраняется в своей транзакции: ошибка в одной строке не отменяет остальные.
+// Если задано имя колоды, добавленные слова помещаются в неё. Слова считаются словами языковой пары pair.
+func ImportWords(ctx context.Context, dbpool *pgxpool.Pool, studentID int, pair models.LanguagePair, deck string, entries []models.ImportEntry) models.ImportReport {
+	report := models.ImportReport{Rows: []models.ImportRowResult{}}
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		result := models.ImportRowResult{Row: entry.Row, Word: entry.Word}
+
+		normalized := normalizer.NormalizeFor(entry.Word, pair.Source)
+		if normalized.Typed == "" {
+			result.Status = models.ImportRowInvalid
+			result.Message = "Word is empty"
+			report.Add(result)
+			continue
+		}
+
+		lemma, err := resolveLemma(ctx, dbpool, normalized, pair, true)
+		if err != nil {
+			result.Status = models.ImportRowError
+			result.Message = "Failed to look up word"
+			report.Add(result)
+			continue
+		}
+		result.Lemma = lemma
+
+		if seen[lemma] {
+			result.Status = models.ImportRowDuplicate
+			result.Message = "Word is repeated in the file"
+			report.Add(result)
+			continue
+		}
+		seen[lemma] = true
+
+		err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+			wordID, err := repository.UpsertPendingWord(ctx, tx, lemma, pair)
+			if err != nil {
+				return err
+			}
+			result.WordID = wordID
+
+			_, status, err := repository.GetWordStatusForUpdate(ctx, tx, lemma, pair)
+			if err != nil {
+				return err
+			}
+
+			exists, err := repository.StudentHasWord(ctx, tx, studentID, wordID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				result.Status = models.ImportRowDuplicate
+				result.Message = "Word is already in the vocabulary"
+				return nil
+			}
+
+			if status != "completed" {
+				if err := repository.RequeueFailedWord(ctx, tx, wordID); err != nil {
+					return err
+				}
+			}
+
+			if err := linkStudentWord(ctx, tx, studentID, wordID, normalized.Typed, lemma, pair.Source); err != nil {
+				return err
+			}
+			// Перевод из файла виден только этому ученику и только пока у слова нет перевода из словаря
+			if status != "completed" && entry.Translation != "" {
+				if err := repository.SetStudentTranslationHint(ctx, tx, studentID, wordID, entry.Translation); err != nil {
+					return err
+				}
+			}
+			if deck != "" {
+				if err := repository.SetStudentWordDeck(ctx, tx, studentID, wordID, deck); err != nil {
+					return err
+				}
+			}
+
+			result.Status = models.ImportRowQueued
+			if status == "completed" {
+				result.Status = models.ImportRowLinked
+			}
+			return nil
+		})
+		if err != nil {
+			result.Status = models.ImportRowError
+			result.Message = "Failed to save word"
+		}
+		report.Add(result)
+	}
+
+	return report
+}
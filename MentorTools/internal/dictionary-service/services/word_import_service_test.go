@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"MentorTools/dictionary-service/dbtest"
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"testing"
+)
+
+// Перевод из файла импорта видит только ученик, который импортировал слово; общий словарь не меняется
+func TestImportWordsKeepsTranslationHintPrivate(t *testing.T) {
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+	pair := models.LanguagePair{Source: "en", Target: "ru"}
+
+	word := "quokka" + dbtest.Suffix()
+	importerID, otherID := dbtest.NewID(), dbtest.NewID()
+	// Очистки выполняются в обратном порядке: слово удаляется после слов учеников
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM words WHERE word = $1", word) })
+	dbtest.CleanupStudent(t, pool, importerID)
+	dbtest.CleanupStudent(t, pool, otherID)
+
+	report := services.ImportWords(ctx, pool, importerID, pair, "", []models.ImportEntry{{Row: 1, Word: word, Translation: "квокка"}})
+	if len(report.Rows) != 1 || report.Rows[0].Status != models.ImportRowQueued {
+		t.Fatalf("import report = %+v, want the word queued for enrichment", report.Rows)
+	}
+	wordID := report.Rows[0].WordID
+
+	var shared *string
+	if err := pool.QueryRow(ctx, "SELECT translation FROM words WHERE id = $1", wordID).Scan(&shared); err != nil {
+		t.Fatalf("read word: %v", err)
+	}
+	if shared != nil && *shared != "" {
+		t.Errorf("shared translation = %q, want none", *shared)
+	}
+
+	dbtest.AddStudentWord(t, pool, otherID, wordID)
+	for studentID, want := range map[int]string{importerID: "квокка", otherID: ""} {
+		words, _, err := repository.ListStudentWords(ctx, pool, studentID, models.WordFilter{},
+			models.WordSort{Field: models.WordSortAdded}, nil, 10)
+		if err != nil {
+			t.Fatalf("ListStudentWords: %v", err)
+		}
+		if len(words) != 1 || words[0].Translation != want {
+			t.Errorf("student %d words = %+v, want translation %q", studentID, words, want)
+		}
+	}
+}
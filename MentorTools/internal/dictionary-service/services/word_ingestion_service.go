@@ -0,0 +1,241 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/normalizer"
+	"MentorTools/dictionary-service/repository"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Этапы добавления слова, которые видит пользователь
+const (
+	StageCollectingContext = "collecting context"
+	StageRequestingGPT     = "requesting gpt"
+	StageSaving            = "saving"
+)
+
+// IngestWord добавляет слово ученику: приводит введённую форму к лемме ("ran" -> "run"),
+// при необходимости запрашивает данные у GPT, сохраняет слово, его уровень, синонимы и примеры
+// и связывает слово с учеником, запоминая форму, в которой он его ввёл.
+//
+// Все записи выполняются в одной транзакции, поэтому сбой не оставляет
+// осиротевших примеров и полусвязанных синонимов. Повторный вызов для того же
+// ученика и слова не создаёт дубликатов. Запрос к GPT выполняется вне транзакции,
+// чтобы не держать блокировки на время сетевого вызова.
+// Возвращает ID слова; stage вызывается при переходе к очередному этапу.
+func IngestWord(ctx context.Context, dbpool *pgxpool.Pool, studentID int, typed string, stage func(string)) (int, error) {
+	pair, err := repository.GetStudentLanguagePair(ctx, dbpool, studentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch student languages: %w", err)
+	}
+
+	normalized := normalizer.NormalizeFor(typed, pair.Source)
+	if normalized.Typed == "" {
+		return 0, fmt.Errorf("word is empty")
+	}
+
+	word, err := resolveLemma(ctx, dbpool, normalized, pair, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve lemma: %w", err)
+	}
+
+	wordID, status, err := repository.GetWordStatus(ctx, dbpool, word, pair)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return 0, fmt.Errorf("failed to look up word: %w", err)
+	}
+
+	// Слово уже заполнено данными от GPT, добавляем только связь с учеником
+	if status == "completed" {
+		stage(StageSaving)
+		err := repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+			return linkStudentWord(ctx, tx, studentID, wordID, normalized.Typed, word, pair.Source)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to add word to user: %w", err)
+		}
+		return wordID, nil
+	}
+
+	stage(StageCollectingContext)
+	wordsForExamples, err := repository.GetWordsForExamples(ctx, dbpool, studentID, pair.Source)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch words for examples: %w", err)
+	}
+
+	// Выбор тем взвешен по давности и частоте их использования
+	topics, err := repository.PickTopicsForExamples(ctx, dbpool, studentID, 5)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch topics: %w", err)
+	}
+
+	stage(StageRequestingGPT)
+	details, err := fetchWordDetails(ctx, dbpool, word, pair, wordsForExamples, topics)
+	if err != nil {
+		return 0, err
+	}
+	level := listWordLevel(word, pair)
+
+	stage(StageSaving)
+	err = repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		wordID, err = repository.UpsertPendingWord(ctx, tx, word, pair)
+		if err != nil {
+			return err
+		}
+
+		// Пока мы ждали GPT, слово мог заполнить параллельный запрос или worker обогащения
+		_, status, err := repository.GetWordStatusForUpdate(ctx, tx, word, pair)
+		if err != nil {
+			return err
+		}
+		if status != "completed" {
+			if err := repository.SaveWordDetails(ctx, tx, wordID, details, currentGeneration(nil)); err != nil {
+				return err
+			}
+			if err := saveWordLevel(ctx, tx, wordID, level); err != nil {
+				return err
+			}
+		}
+
+		return linkStudentWord(ctx, tx, studentID, wordID, normalized.Typed, word, pair.Source)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return wordID, nil
+}
+
+// EnrichWord заполняет данными от GPT слово, ожидающее фонового обогащения.
+// Слово не привязывается ни к одному ученику, поэтому примеры генерируются без учёта контекста
+// на языковой паре самого слова.
+func EnrichWord(ctx context.Context, dbpool *pgxpool.Pool, wordID int, word string) error {
+	pair, err := repository.GetWordLanguagePair(ctx, dbpool, wordID)
+	if err != nil {
+		return err
+	}
+
+	details, err := fetchWordDetails(ctx, dbpool, word, pair, nil, nil)
+	if err != nil {
+		return err
+	}
+	level := listWordLevel(word, pair)
+
+	return repository.WithTx(ctx, dbpool, func(tx pgx.Tx) error {
+		_, status, err := repository.GetWordForUpdate(ctx, tx, wordID)
+		if err != nil {
+			return err
+		}
+		if status == "completed" {
+			return nil
+		}
+		if err := repository.SaveWordDetails(ctx, tx, wordID, details, currentGeneration(nil)); err != nil {
+			return err
+		}
+		return saveWordLevel(ctx, tx, wordID, level)
+	})
+}
+
+// fetchWordDetails запрашивает у GPT транскрипцию, перевод, описание, синонимы, связанные слова и примеры слова.
+// Синонимы, связанные слова и ключевые слова примеров приводятся к леммам, чтобы в очередь обогащения
+// не попадали отдельные формы уже известных слов. Инструкция для GPT выбирается по языковой паре.
+func fetchWordDetails(ctx context.Context, dbpool *pgxpool.Pool, word string, pair models.LanguagePair, wordsForExamples []string, topics []string) (gpt.WordDetails, error) {
+	details, err := gpt.GetWordDetailsFromGPT(word, pair.Source, pair.Target, wordsForExamples, topics)
+	if err != nil {
+		return gpt.WordDetails{}, fmt.Errorf("failed to fetch word details from GPT: %w", err)
+	}
+
+	if details.Synonyms, err = lemmatizeAll(ctx, dbpool, details.Synonyms, pair); err != nil {
+		return gpt.WordDetails{}, fmt.Errorf("failed to normalize synonyms: %w", err)
+	}
+	if details.Relations, err = normalizeRelations(ctx, dbpool, word, details.Relations, pair); err != nil {
+		return gpt.WordDetails{}, fmt.Errorf("failed to normalize relations: %w", err)
+	}
+	for i := range details.Examples {
+		if details.Examples[i].Keywords, err = lemmatizeAll(ctx, dbpool, details.Examples[i].Keywords, pair); err != nil {
+			return gpt.WordDetails{}, fmt.Errorf("failed to normalize keywords: %w", err)
+		}
+	}
+
+	return details, nil
+}
+
+// currentGeneration описывает генерацию текущей моделью и версией промпта GPT;
+// createdBy - репетитор, запросивший генерацию, или nil для автоматической
+func currentGeneration(createdBy *int) models.ContentGeneration {
+	return models.ContentGeneration{Model: gpt.Model, PromptVersion: gpt.PromptVersion, CreatedBy: createdBy}
+}
+
+// resolveLemma выбирает лемму для нормализованного слова: сначала ранее сохранённая связь формы
+// с леммой, затем первый кандидат, который уже есть в словаре. Если ни один кандидат не найден,
+// guess решает, взять ли лемму нормализатора (известную по спискам неправильных форм и частотному)
+// или оставить слово в введённой форме.
+// Формы и словарь ищутся только в языковой паре pair.
+func resolveLemma(ctx context.Context, dbpool *pgxpool.Pool, normalized normalizer.Result, pair models.LanguagePair, guess bool) (string, error) {
+	lemma, err := repository.FindLemmaByForm(ctx, dbpool, normalized.Typed, pair.Source)
+	if err == nil {
+		return lemma, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return "", err
+	}
+
+	existing, err := repository.FindExistingWords(ctx, dbpool, normalized.Candidates, pair)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range normalized.Candidates {
+		if existing[candidate] {
+			return candidate, nil
+		}
+	}
+
+	if guess {
+		return normalized.Lemma, nil
+	}
+	return normalized.Typed, nil
+}
+
+// lemmatizeAll приводит слова от GPT к леммам, не доверяя правилам для слов, которых нет в словаре,
+// и убирает повторы
+func lemmatizeAll(ctx context.Context, dbpool *pgxpool.Pool, words []string, pair models.LanguagePair) ([]string, error) {
+	var lemmas []string
+	seen := make(map[string]bool)
+	for _, word := range words {
+		normalized := normalizer.NormalizeFor(word, pair.Source)
+		if normalized.Typed == "" {
+			continue
+		}
+
+		lemma, err := resolveLemma(ctx, dbpool, normalized, pair, false)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[lemma] {
+			seen[lemma] = true
+			lemmas = append(lemmas, lemma)
+		}
+	}
+	return lemmas, nil
+}
+
+// linkStudentWord связывает слово с учеником и запоминает введённую форму как форму леммы языка language.
+// Новое для ученика слово записывается в события обучения.
+func linkStudentWord(ctx context.Context, tx pgx.Tx, studentID, wordID int, typed, lemma, language string) error {
+	if typed != lemma {
+		if err := repository.SaveWordForm(ctx, tx, typed, language, wordID); err != nil {
+			return err
+		}
+	}
+
+	added, err := repository.LinkStudentWord(ctx, tx, studentID, wordID, typed)
+	if err != nil || !added {
+		return err
+	}
+	return repository.RecordLearningEvent(ctx, tx, models.LearningEvent{StudentID: studentID, WordID: wordID, Type: models.EventAdded})
+}
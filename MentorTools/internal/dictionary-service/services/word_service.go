@@ -0,0 +1,96 @@
+package services
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"errors"
+)
+
+// ErrInvalidWordStatus - статус слова ученика не из models.WordStatus*
+var ErrInvalidWordStatus = errors.New("invalid word status")
+
+// WordService - словарь ученика и карточки слов. Работает только через интерфейсы репозиториев,
+// поэтому в тестах обработчиков базу данных заменяет repository/memory.
+type WordService struct {
+	words        repository.WordRepository
+	studentWords repository.StudentWordRepository
+	examples     repository.ExampleRepository
+}
+
+// NewWordService создаёт сервис словаря поверх репозиториев
+func NewWordService(words repository.WordRepository, studentWords repository.StudentWordRepository,
+	examples repository.ExampleRepository) *WordService {
+	return &WordService{words: words, studentWords: studentWords, examples: examples}
+}
+
+// IsWordStatus проверяет, что статус слова ученика допустим
+func IsWordStatus(status string) bool {
+	return status == models.WordStatusNeedToLearn || status == models.WordStatusLearned
+}
+
+// GetWordDetails возвращает карточку слова: перевод, описание, синонимы, связи с другими словами и примеры.
+// Из личных примеров учеников в карточку попадают только примеры ученика studentID.
+// Контент, который ещё не проверил репетитор, видят только репетиторы (includeUnpublished).
+func (s *WordService) GetWordDetails(ctx context.Context, wordID, studentID int, includeUnpublished bool) (models.WordDetails, error) {
+	word, err := s.words.GetWord(ctx, wordID)
+	if err != nil {
+		return word, err
+	}
+	if !word.Published && !includeUnpublished {
+		hideUnpublished(&word)
+		return word, nil
+	}
+
+	wordIDs := []int{wordID}
+	synonyms, err := s.words.GetSynonyms(ctx, wordIDs)
+	if err != nil {
+		return word, err
+	}
+	word.Synonyms = synonyms[wordID]
+
+	if word.Relations, err = s.words.GetRelations(ctx, wordID); err != nil {
+		return word, err
+	}
+
+	examples, err := s.examples.GetExamples(ctx, wordIDs, studentID)
+	if err != nil {
+		return word, err
+	}
+	word.Examples = examples[wordID]
+	return word, nil
+}
+
+// ListWords возвращает страницу словаря ученика и общее количество слов, подходящих под фильтр
+func (s *WordService) ListWords(ctx context.Context, studentID int, filter models.WordFilter, sort models.WordSort,
+	after *models.WordCursor, limit int) ([]models.StudentWord, int, error) {
+	if filter.Status != "" && !IsWordStatus(filter.Status) {
+		return nil, 0, ErrInvalidWordStatus
+	}
+	return s.studentWords.List(ctx, studentID, filter, sort, after, limit)
+}
+
+// UpdateWordStatus меняет статус слова ученика вручную и записывает изменение в события обучения.
+// Если слова нет у ученика, возвращает repository.ErrNotFound.
+func (s *WordService) UpdateWordStatus(ctx context.Context, studentID, wordID int, status string) error {
+	if !IsWordStatus(status) {
+		return ErrInvalidWordStatus
+	}
+	return s.studentWords.UpdateStatus(ctx, studentID, wordID, status)
+}
+
+// CanAccessStudent проверяет доступ пользователя к словарю ученика:
+// ученик имеет доступ к своему словарю, репетитор - к словарям привязанных к нему учеников
+func (s *WordService) CanAccessStudent(ctx context.Context, userID, studentID int) (bool, error) {
+	return s.studentWords.CanAccess(ctx, userID, studentID)
+}
+
+// hideUnpublished убирает из карточки контент, который ещё не проверил репетитор
+func hideUnpublished(word *models.WordDetails) {
+	word.Transcription = ""
+	word.Translation = ""
+	word.Description = ""
+	word.Synonyms = nil
+	word.Relations = nil
+	word.Examples = nil
+}
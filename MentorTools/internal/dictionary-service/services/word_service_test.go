@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/repository/memory"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Ученик и репетитор словаря memory.NewFixtureStore
+const (
+	testStudentID = memory.FixtureStudentID
+	testTutorID   = memory.FixtureTutorID
+)
+
+func newWordService(store *memory.Store) *services.WordService {
+	return services.NewWordService(store, store, store)
+}
+
+func TestWordServiceGetWordDetails(t *testing.T) {
+	words := newWordService(memory.NewFixtureStore())
+	ctx := context.Background()
+
+	word, err := words.GetWordDetails(ctx, 10, testStudentID, false)
+	if err != nil {
+		t.Fatalf("GetWordDetails: %v", err)
+	}
+	var sentences []string
+	for _, example := range word.Examples {
+		sentences = append(sentences, example.Sentence)
+	}
+	wantSentences := []string{"An apple a day.", "I eat an apple.", "My apple."}
+	if !reflect.DeepEqual(sentences, wantSentences) {
+		t.Errorf("examples = %q, want common and own examples %q", sentences, wantSentences)
+	}
+	if !reflect.DeepEqual(word.Synonyms, []string{"pome"}) {
+		t.Errorf("synonyms = %q, want [pome]", word.Synonyms)
+	}
+
+	unpublished, err := words.GetWordDetails(ctx, 11, testStudentID, false)
+	if err != nil {
+		t.Fatalf("GetWordDetails(unpublished): %v", err)
+	}
+	if unpublished.Translation != "" || unpublished.Synonyms != nil {
+		t.Errorf("student sees unpublished content: %+v", unpublished)
+	}
+	reviewed, err := words.GetWordDetails(ctx, 11, testStudentID, true)
+	if err != nil {
+		t.Fatalf("GetWordDetails(includeUnpublished): %v", err)
+	}
+	if reviewed.Translation != "груша" || len(reviewed.Synonyms) != 1 {
+		t.Errorf("tutor does not see unpublished content: %+v", reviewed)
+	}
+
+	if _, err := words.GetWordDetails(ctx, 404, testStudentID, false); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("missing word: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWordServiceListWords(t *testing.T) {
+	words := newWordService(memory.NewFixtureStore())
+	ctx := context.Background()
+	byWord := models.WordSort{Field: models.WordSortWord}
+
+	tests := []struct {
+		name   string
+		filter models.WordFilter
+		want   []string
+	}{
+		{"all", models.WordFilter{}, []string{"apple", "pear"}},
+		{"status", models.WordFilter{Status: models.WordStatusLearned}, []string{"pear"}},
+		{"deck", models.WordFilter{Deck: "vegetables"}, nil},
+		{"level", models.WordFilter{Levels: []string{"A1", "B1"}}, []string{"apple"}},
+		{"topic", models.WordFilter{TopicID: 5}, []string{"apple"}},
+		{"unknown topic", models.WordFilter{TopicID: 6}, nil},
+	}
+	for _, tt := range tests {
+		list, total, err := words.ListWords(ctx, testStudentID, tt.filter, byWord, nil, 10)
+		if err != nil {
+			t.Fatalf("%s: ListWords: %v", tt.name, err)
+		}
+		var got []string
+		for _, word := range list {
+			got = append(got, word.Word)
+		}
+		if !reflect.DeepEqual(got, tt.want) || total != len(tt.want) {
+			t.Errorf("%s: ListWords = %q (total %d), want %q", tt.name, got, total, tt.want)
+		}
+	}
+
+	// Страница содержит limit+1 слово, чтобы обработчик знал, есть ли следующая
+	page, total, err := words.ListWords(ctx, testStudentID, models.WordFilter{}, byWord, nil, 1)
+	if err != nil || len(page) != 2 || total != 2 {
+		t.Fatalf("first page = %d words (total %d, err %v), want 2 words", len(page), total, err)
+	}
+	cursor := models.WordCursor{Sort: byWord.Field, Key: page[0].Word, ID: page[0].ID}
+	next, _, err := words.ListWords(ctx, testStudentID, models.WordFilter{}, byWord, &cursor, 1)
+	if err != nil || len(next) != 1 || next[0].Word != "pear" {
+		t.Errorf("next page = %+v (err %v), want [pear]", next, err)
+	}
+
+	if _, _, err := words.ListWords(ctx, testStudentID, models.WordFilter{Status: "forgotten"}, byWord, nil, 10); !errors.Is(err, services.ErrInvalidWordStatus) {
+		t.Errorf("invalid status: err = %v, want ErrInvalidWordStatus", err)
+	}
+}
+
+func TestWordServiceUpdateWordStatus(t *testing.T) {
+	store := memory.NewFixtureStore()
+	words := newWordService(store)
+	ctx := context.Background()
+
+	if err := words.UpdateWordStatus(ctx, testStudentID, 10, models.WordStatusLearned); err != nil {
+		t.Fatalf("UpdateWordStatus: %v", err)
+	}
+	want := []models.LearningEvent{{
+		StudentID: testStudentID, WordID: 10, Type: models.EventStatusChanged,
+		OldStatus: models.WordStatusNeedToLearn, NewStatus: models.WordStatusLearned,
+	}}
+	if events := store.Events(); !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+
+	if err := words.UpdateWordStatus(ctx, testStudentID, 10, "forgotten"); !errors.Is(err, services.ErrInvalidWordStatus) {
+		t.Errorf("invalid status: err = %v, want ErrInvalidWordStatus", err)
+	}
+	if err := words.UpdateWordStatus(ctx, testStudentID, 404, models.WordStatusLearned); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("missing word: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWordServiceCanAccessStudent(t *testing.T) {
+	words := newWordService(memory.NewFixtureStore())
+	for _, tt := range []struct {
+		userID int
+		want   bool
+	}{{testStudentID, true}, {testTutorID, true}, {3, false}} {
+		got, err := words.CanAccessStudent(context.Background(), tt.userID, testStudentID)
+		if err != nil || got != tt.want {
+			t.Errorf("CanAccessStudent(%d) = %v, %v; want %v", tt.userID, got, err, tt.want)
+		}
+	}
+}
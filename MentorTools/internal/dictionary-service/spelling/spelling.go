@@ -0,0 +1,162 @@
+// Package spelling проверяет слово, набранное учеником, с допуском на опечатки
+// и показывает, в каких буквах ошибка. Другое слово словаря опечаткой не считается:
+// "affect" вместо "effect" - ошибка в выборе слова, а не в букве.
+package spelling
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Виды ошибок в букве
+const (
+	MistakeWrong   = "wrong"   // Вместо нужной буквы набрана другая
+	MistakeMissing = "missing" // Буква пропущена
+	MistakeExtra   = "extra"   // Набрана лишняя буква
+)
+
+// Mistake - ошибка в одной букве
+type Mistake struct {
+	Kind     string // Один из Mistake*
+	Position int    // Позиция в правильном написании (в символах после нормализации); для лишней буквы - позиция, перед которой она набрана
+	Expected string // Нужная буква; пусто для лишней
+	Typed    string // Набранная буква; пусто для пропущенной
+}
+
+// Grade - результат проверки
+type Grade struct {
+	Correct    bool      // Ответ засчитан: ошибок не больше допуска
+	Exact      bool      // Написание совпало после нормализации
+	Normalized bool      // Совпало только после нормализации: отличаются регистр, диакритика, пробелы или апострофы ("Cafe" вместо "café")
+	Distance   int       // Расстояние Левенштейна между нормализованными написаниями
+	Tolerance  int       // Допустимое число ошибок для слова такой длины
+	KnownWord  bool      // Набрано другое слово словаря: допуск на опечатки к нему не применяется
+	Mistakes   []Mistake // Ошибки по буквам
+}
+
+// Dictionary сообщает, есть ли в словаре слово с нормализованным написанием normalized (см. Normalize)
+type Dictionary func(normalized string) bool
+
+// Normalize приводит написание к виду для сравнения: нижний регистр, без диакритики,
+// одинарные пробелы, типографские апострофы и дефисы заменены обычными
+func Normalize(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(strings.ToLower(strings.TrimSpace(text))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case r == '’' || r == '‘' || r == '`':
+			r = '\''
+		case r == '‐' || r == '–' || r == '—':
+			r = '-'
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Tolerance возвращает допустимое число ошибок: в коротких словах ошибки не прощаются,
+// в словах от 5 букв допускается одна опечатка, от 9 букв - две.
+// Допуск относится только к опечаткам: написание, которое само является словом, проверяет CheckWord.
+func Tolerance(length int) int {
+	switch {
+	case length >= 9:
+		return 2
+	case length >= 5:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Check сравнивает набранное написание с правильным, не зная словаря: любое написание в пределах допуска
+// засчитывается как опечатка. Если словарь доступен, нужно использовать CheckWord.
+func Check(expected, typed string) Grade {
+	return CheckWord(expected, typed, nil)
+}
+
+// CheckWord сравнивает набранное написание с правильным. Неточное написание в пределах допуска засчитывается,
+// только если его нет в словаре known: "desert" вместо "dessert" - другое слово, и ответ не засчитывается.
+// known вызывается только для таких написаний; nil - словарь не проверяется.
+func CheckWord(expected, typed string, known Dictionary) Grade {
+	want := []rune(Normalize(expected))
+	got := []rune(Normalize(typed))
+
+	grade := Grade{Tolerance: Tolerance(len(want))}
+	grade.Mistakes = align(want, got)
+	grade.Distance = len(grade.Mistakes)
+	grade.Exact = grade.Distance == 0
+	grade.Correct = len(got) > 0 && grade.Distance <= grade.Tolerance
+	if grade.Correct && !grade.Exact && known != nil && known(string(got)) {
+		grade.KnownWord = true
+		grade.Correct = false
+	}
+	grade.Normalized = grade.Exact && strings.TrimSpace(expected) != strings.TrimSpace(typed)
+	return grade
+}
+
+// align считает расстояние Левенштейна и восстанавливает по таблице ошибки в буквах
+func align(want, got []rune) []Mistake {
+	// dist[i][j] - расстояние между want[:i] и got[:j]
+	dist := make([][]int, len(want)+1)
+	for i := range dist {
+		dist[i] = make([]int, len(got)+1)
+		dist[i][0] = i
+	}
+	for j := range dist[0] {
+		dist[0][j] = j
+	}
+	for i := 1; i <= len(want); i++ {
+		for j := 1; j <= len(got); j++ {
+			cost := 1
+			if want[i-1] == got[j-1] {
+				cost = 0
+			}
+			dist[i][j] = minInt(dist[i-1][j-1]+cost, dist[i-1][j]+1, dist[i][j-1]+1)
+		}
+	}
+
+	// Обратный проход от конца строк; ошибки собираются в обратном порядке
+	var mistakes []Mistake
+	i, j := len(want), len(got)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && want[i-1] == got[j-1] && dist[i][j] == dist[i-1][j-1]:
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			mistakes = append(mistakes, Mistake{Kind: MistakeWrong, Position: i - 1, Expected: string(want[i-1]), Typed: string(got[j-1])})
+			i, j = i-1, j-1
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			mistakes = append(mistakes, Mistake{Kind: MistakeMissing, Position: i - 1, Expected: string(want[i-1])})
+			i--
+		default:
+			mistakes = append(mistakes, Mistake{Kind: MistakeExtra, Position: i, Typed: string(got[j-1])})
+			j--
+		}
+	}
+
+	for left, right := 0, len(mistakes)-1; left < right; left, right = left+1, right-1 {
+		mistakes[left], mistakes[right] = mistakes[right], mistakes[left]
+	}
+	return mistakes
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
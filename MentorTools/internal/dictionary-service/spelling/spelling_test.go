@@ -0,0 +1,94 @@
+package spelling
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"Café", "cafe"},
+		{"  ice   cream ", "ice cream"},
+		{"don’t", "don't"},
+		{"well‐known", "well-known"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.text); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	tests := []struct {
+		length, want int
+	}{
+		{3, 0}, {4, 0}, {5, 1}, {8, 1}, {9, 2}, {15, 2},
+	}
+	for _, tt := range tests {
+		if got := Tolerance(tt.length); got != tt.want {
+			t.Errorf("Tolerance(%d) = %d, want %d", tt.length, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expected, typed string
+		correct, exact  bool
+		distance        int
+	}{
+		{"house", "house", true, true, 0},
+		{"café", "Cafe", true, true, 0},
+		{"house", "hous", true, false, 1},
+		{"cat", "cut", false, false, 1},
+		{"beautiful", "beatiful", true, false, 1},
+		{"beautiful", "beatifull", true, false, 2},
+		{"beautiful", "btfl", false, false, 5},
+		{"house", "", false, false, 5},
+	}
+	for _, tt := range tests {
+		grade := Check(tt.expected, tt.typed)
+		if grade.Correct != tt.correct || grade.Exact != tt.exact || grade.Distance != tt.distance {
+			t.Errorf("Check(%q, %q) = correct %v, exact %v, distance %d; want %v, %v, %d",
+				tt.expected, tt.typed, grade.Correct, grade.Exact, grade.Distance, tt.correct, tt.exact, tt.distance)
+		}
+	}
+}
+
+func TestCheckWordRejectsKnownWords(t *testing.T) {
+	words := map[string]bool{"effect": true, "affect": true, "desert": true, "dessert": true, "house": true}
+	known := func(normalized string) bool { return words[normalized] }
+
+	tests := []struct {
+		expected, typed string
+		correct, known  bool
+	}{
+		{"effect", "affect", false, true},
+		{"affect", "Effect", false, true},
+		{"dessert", "desert", false, true},
+		{"desert", "dessert", false, true},
+		{"effect", "efect", true, false},
+		{"dessert", "desserd", true, false},
+		{"effect", "effect", true, false},
+		{"house", "House", true, false},
+	}
+	for _, tt := range tests {
+		grade := CheckWord(tt.expected, tt.typed, known)
+		if grade.Correct != tt.correct || grade.KnownWord != tt.known {
+			t.Errorf("CheckWord(%q, %q) = correct %v, known word %v; want %v, %v",
+				tt.expected, tt.typed, grade.Correct, grade.KnownWord, tt.correct, tt.known)
+		}
+	}
+
+	if grade := Check("effect", "affect"); !grade.Correct {
+		t.Errorf("Check without a dictionary should accept a one-letter typo")
+	}
+}
+
+func TestCheckMistakes(t *testing.T) {
+	grade := Check("house", "hoese")
+	want := []Mistake{{Kind: MistakeWrong, Position: 2, Expected: "u", Typed: "e"}}
+	if len(grade.Mistakes) != len(want) || grade.Mistakes[0] != want[0] {
+		t.Errorf("Check(house, hoese) mistakes = %+v, want %+v", grade.Mistakes, want)
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// contentExtensions - расширения ключей по типу содержимого, которое сохраняется по хешу
+var contentExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ContentStore - хранилище, в котором ключ объекта выводится из его содержимого: "<раздел>/<sha256><расширение>".
+// Одинаковые файлы хранятся один раз, а объект по ключу никогда не меняется, поэтому его можно кэшировать бессрочно.
+type ContentStore struct {
+	BlobStore
+}
+
+// NewContentStore создаёт хранилище по хешу поверх store
+func NewContentStore(store BlobStore) *ContentStore {
+	return &ContentStore{BlobStore: store}
+}
+
+// PutContent сохраняет данные в разделе namespace, если такого объекта ещё нет, и возвращает его ключ
+func (s *ContentStore) PutContent(ctx context.Context, namespace string, data []byte, contentType string) (string, error) {
+	extension, ok := contentExtensions[contentType]
+	if !ok {
+		return "", fmt.Errorf("unsupported content type %q", contentType)
+	}
+	sum := sha256.Sum256(data)
+	key := namespace + "/" + hex.EncodeToString(sum[:]) + extension
+
+	exists, err := s.Exists(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return key, nil
+	}
+	return key, s.Put(ctx, key, data, contentType)
+}
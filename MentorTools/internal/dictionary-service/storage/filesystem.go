@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// FileStore - хранилище в каталоге локальной файловой системы.
+// Тип содержимого не хранится отдельно и определяется по расширению ключа.
+type FileStore struct {
+	dir       string
+	publicURL string
+}
+
+// NewFileStore создаёт хранилище в каталоге dir; объекты доступны по адресам publicURL + ключ
+func NewFileStore(dir, publicURL string) *FileStore {
+	return &FileStore{dir: dir, publicURL: publicURL}
+}
+
+// Put записывает объект во временный файл и переименовывает его, чтобы читатели не увидели недописанный файл
+func (s *FileStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Get открывает файл объекта
+func (s *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return file, contentTypeOf(key), nil
+}
+
+// Exists проверяет, есть ли файл объекта
+func (s *FileStore) Exists(ctx context.Context, key string) (bool, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete удаляет файл объекта
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL возвращает адрес объекта
+func (s *FileStore) URL(key string) string {
+	return s.publicURL + key
+}
+
+// path возвращает путь к файлу объекта, не выпуская ключ за пределы каталога хранилища
+func (s *FileStore) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// mediaTypes - типы содержимого сгенерированных файлов; в системной таблице mime их может не быть
+var mediaTypes = map[string]string{
+	".mp3": "audio/mpeg",
+	".wav": "audio/wav",
+	".ogg": "audio/ogg",
+}
+
+// contentTypeOf определяет тип содержимого по расширению ключа
+func contentTypeOf(key string) string {
+	if contentType, ok := mediaTypes[path.Ext(key)]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,72 @@
+// Package storage хранит сгенерированные и загруженные файлы (аудио произношения, картинки слов) в файловой системе
+// или в S3-совместимом хранилище.
+package storage
+
+import (
+	"MentorTools/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound возвращается, если объекта с таким ключом нет
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore - хранилище объектов по ключу вида "audio/3f2a....mp3"
+type BlobStore interface {
+	// Put сохраняет объект, перезаписывая существующий
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get открывает объект и возвращает его тип содержимого; если объекта нет, возвращает ErrNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	// Exists проверяет, есть ли объект
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete удаляет объект; удаление отсутствующего объекта не считается ошибкой
+	Delete(ctx context.Context, key string) error
+	// URL возвращает адрес, по которому клиент может загрузить объект
+	URL(key string) string
+}
+
+// DefaultPublicURL - префикс адресов объектов, если он не задан: объекты отдаёт сам сервис по маршруту /media/
+const DefaultPublicURL = "/media/"
+
+// New создаёт хранилище по настройкам storage из файла конфигурации
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	publicURL := cfg.PublicURL
+	if publicURL == "" {
+		publicURL = DefaultPublicURL
+	}
+
+	switch cfg.Type {
+	case "", "filesystem":
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("storage dir is not configured")
+		}
+		return NewFileStore(cfg.Dir, publicURL), nil
+	case "s3":
+		return NewS3Store(cfg.S3, publicURL)
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", cfg.Type)
+	}
+}
+
+// ValidKey проверяет, что ключ - относительный путь из латинских букв, цифр, ".", "-" и "_"
+// без пустых и скрытых сегментов (в том числе ".."). Такой ключ не нужно экранировать ни в пути
+// файловой системы, ни в URL.
+func ValidKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return false
+		}
+		for _, r := range segment {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
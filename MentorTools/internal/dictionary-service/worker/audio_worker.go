@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"MentorTools/dictionary-service/storage"
+	gpt "MentorTools/gpt-service/services"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// AudioConfig - настройки фонового озвучивания слов и примеров
+type AudioConfig struct {
+	RequestsPerMinute int           // Ограничение частоты запросов к провайдеру синтеза речи
+	MaxAttempts       int           // После стольких неудачных попыток слово или пример больше не озвучиваются
+	PollInterval      time.Duration // Пауза между проверками пустой очереди
+}
+
+// DefaultAudioConfig - настройки по умолчанию
+var DefaultAudioConfig = AudioConfig{
+	RequestsPerMinute: 50,
+	MaxAttempts:       3,
+	PollInterval:      30 * time.Second,
+}
+
+// AudioWorker - фоновый обработчик, который озвучивает заполненные слова и их примеры.
+// Аудио генерируется один раз и хранится в BlobStore; повторная обработка того же текста
+// (например, несколькими worker-ами) не создаёт новых файлов.
+type AudioWorker struct {
+	dbpool *pgxpool.Pool
+	store  storage.BlobStore
+	tts    gpt.SpeechSynthesizer
+	cfg    AudioConfig
+}
+
+// NewAudioWorker создаёт worker; запускается вызовом Run в отдельной горутине
+func NewAudioWorker(dbpool *pgxpool.Pool, store storage.BlobStore, tts gpt.SpeechSynthesizer, cfg AudioConfig) *AudioWorker {
+	// Ограничитель частоты делит минуту на RequestsPerMinute: без положительного значения берём значение по умолчанию
+	if cfg.RequestsPerMinute <= 0 {
+		log.Printf("Audio worker: invalid requests per minute %d, using %d", cfg.RequestsPerMinute, DefaultAudioConfig.RequestsPerMinute)
+		cfg.RequestsPerMinute = DefaultAudioConfig.RequestsPerMinute
+	}
+	return &AudioWorker{dbpool: dbpool, store: store, tts: tts, cfg: cfg}
+}
+
+// Run разбирает очередь, пока не будет отменён ctx
+func (w *AudioWorker) Run(ctx context.Context) {
+	// Ограничитель частоты: не больше RequestsPerMinute обращений к провайдеру
+	limiter := time.NewTicker(time.Minute / time.Duration(w.cfg.RequestsPerMinute))
+	defer limiter.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-limiter.C:
+		}
+
+		err := w.processNext(ctx)
+		if errors.Is(err, repository.ErrQueueEmpty) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Audio worker error: %v", err)
+		}
+	}
+}
+
+// processNext озвучивает одно слово или пример из очереди
+func (w *AudioWorker) processNext(ctx context.Context) error {
+	task, err := repository.NextAudioTask(ctx, w.dbpool, w.cfg.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	err = services.GenerateAudio(ctx, w.dbpool, w.store, w.tts, task)
+	if err != nil {
+		if failErr := repository.FailAudioAttempt(ctx, w.dbpool, task, err); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+	return nil
+}
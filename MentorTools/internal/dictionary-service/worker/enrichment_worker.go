@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/models"
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// EnrichmentConfig - настройки фонового обогащения слов
+type EnrichmentConfig struct {
+	RequestsPerMinute int           // Ограничение частоты запросов к GPT
+	MaxAttempts       int           // После стольких неудачных попыток слово получает статус "failed"
+	RetryBackoff      time.Duration // Задержка перед первым повтором, дальше удваивается
+	PollInterval      time.Duration // Пауза между проверками пустой очереди
+	StaleAfter        time.Duration // Через сколько слово в "processing" считается брошенным
+}
+
+// DefaultEnrichmentConfig - настройки по умолчанию
+var DefaultEnrichmentConfig = EnrichmentConfig{
+	RequestsPerMinute: 20,
+	MaxAttempts:       5,
+	RetryBackoff:      time.Minute,
+	PollInterval:      30 * time.Second,
+	StaleAfter:        10 * time.Minute,
+}
+
+// EnrichmentWorker - фоновый обработчик, который заполняет слова в статусе "pending" данными от GPT.
+// Такие слова появляются из синонимов и ключевых слов примеров при добавлении нового слова.
+type EnrichmentWorker struct {
+	dbpool *pgxpool.Pool
+	cfg    EnrichmentConfig
+
+	enriched int64
+	errors   int64
+
+	mu        sync.Mutex
+	startedAt *time.Time
+	lastRunAt *time.Time
+}
+
+// NewEnrichmentWorker создаёт worker; запускается вызовом Run в отдельной горутине
+func NewEnrichmentWorker(dbpool *pgxpool.Pool, cfg EnrichmentConfig) *EnrichmentWorker {
+	// Ограничитель частоты делит минуту на RequestsPerMinute: без положительного значения берём значение по умолчанию
+	if cfg.RequestsPerMinute <= 0 {
+		log.Printf("Enrichment worker: invalid requests per minute %d, using %d", cfg.RequestsPerMinute, DefaultEnrichmentConfig.RequestsPerMinute)
+		cfg.RequestsPerMinute = DefaultEnrichmentConfig.RequestsPerMinute
+	}
+	return &EnrichmentWorker{dbpool: dbpool, cfg: cfg}
+}
+
+// Run разбирает очередь, пока не будет отменён ctx
+func (w *EnrichmentWorker) Run(ctx context.Context) {
+	now := time.Now()
+	w.mu.Lock()
+	w.startedAt = &now
+	w.mu.Unlock()
+
+	// Ограничитель частоты: не больше RequestsPerMinute обращений к GPT
+	limiter := time.NewTicker(time.Minute / time.Duration(w.cfg.RequestsPerMinute))
+	defer limiter.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-limiter.C:
+		}
+
+		err := w.processNext(ctx)
+		if errors.Is(err, repository.ErrQueueEmpty) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Enrichment worker error: %v", err)
+		}
+	}
+}
+
+// processNext забирает одно слово из очереди и обогащает его
+func (w *EnrichmentWorker) processNext(ctx context.Context) error {
+	wordID, word, err := repository.ClaimPendingWord(ctx, w.dbpool, w.cfg.StaleAfter)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w.mu.Lock()
+	w.lastRunAt = &now
+	w.mu.Unlock()
+
+	err = services.EnrichWord(ctx, w.dbpool, wordID, word)
+	if err != nil {
+		atomic.AddInt64(&w.errors, 1)
+		if failErr := repository.FailWordAttempt(ctx, w.dbpool, wordID, err, w.cfg.MaxAttempts, w.cfg.RetryBackoff); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+
+	atomic.AddInt64(&w.enriched, 1)
+	return nil
+}
+
+// Stats возвращает глубину очереди и прогресс worker-а
+func (w *EnrichmentWorker) Stats(ctx context.Context) (models.EnrichmentStats, error) {
+	stats, err := repository.GetEnrichmentQueueStats(ctx, w.dbpool)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.Enriched = atomic.LoadInt64(&w.enriched)
+	stats.Errors = atomic.LoadInt64(&w.errors)
+
+	w.mu.Lock()
+	stats.StartedAt = w.startedAt
+	stats.LastRunAt = w.lastRunAt
+	w.mu.Unlock()
+
+	return stats, nil
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// LessonSchedulerConfig - настройки планировщика ежедневных уроков
+type LessonSchedulerConfig struct {
+	PollInterval time.Duration // Как часто искать учеников, у которых наступил час урока
+	BatchSize    int           // Сколько уроков составляется за одну проверку
+}
+
+// DefaultLessonSchedulerConfig - настройки по умолчанию
+var DefaultLessonSchedulerConfig = LessonSchedulerConfig{
+	PollInterval: 5 * time.Minute,
+	BatchSize:    100,
+}
+
+// LessonScheduler - фоновый планировщик, который утром по местному времени ученика составляет ему урок на день.
+// Урок составляется один раз за день: повторный запуск или несколько планировщиков не создают дублей.
+type LessonScheduler struct {
+	dbpool *pgxpool.Pool
+	cfg    LessonSchedulerConfig
+}
+
+// NewLessonScheduler создаёт планировщик; запускается вызовом Run в отдельной горутине
+func NewLessonScheduler(dbpool *pgxpool.Pool, cfg LessonSchedulerConfig) *LessonScheduler {
+	return &LessonScheduler{dbpool: dbpool, cfg: cfg}
+}
+
+// Run составляет уроки, пока не будет отменён ctx
+func (s *LessonScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		generated, err := s.processBatch(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Lesson scheduler error: %v", err)
+		}
+		if generated > 0 {
+			log.Printf("Generated %d daily lessons", generated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processBatch составляет уроки ученикам, у которых в момент now уже наступил час урока, пока такие ученики
+// не закончатся, и возвращает число новых уроков. Урок, который уже составил другой планировщик, не считается.
+// Ошибка урока одного ученика не останавливает остальных; такой ученик будет обработан при следующей проверке.
+func (s *LessonScheduler) processBatch(ctx context.Context, now time.Time) (int, error) {
+	generated := 0
+	// Ученики, которых этот проход уже обработал: с ошибкой или с уроком, составленным другим планировщиком
+	done := make(map[int]bool)
+	for ctx.Err() == nil {
+		students, err := repository.StudentsWithoutLesson(ctx, s.dbpool, now, s.cfg.BatchSize+len(done))
+		if err != nil {
+			return generated, err
+		}
+
+		pending := 0
+		for _, studentID := range students {
+			if done[studentID] {
+				continue
+			}
+			pending++
+			created, err := services.GenerateDailyLesson(ctx, s.dbpool, studentID, now)
+			if err != nil {
+				log.Printf("Failed to generate daily lesson for student %d: %v", studentID, err)
+				done[studentID] = true
+				continue
+			}
+			if created {
+				generated++
+			} else {
+				done[studentID] = true
+			}
+		}
+		if pending == 0 {
+			return generated, nil
+		}
+	}
+	return generated, ctx.Err()
+}
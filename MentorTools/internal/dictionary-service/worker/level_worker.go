@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// LevelConfig - настройки фонового определения уровня CEFR слов
+type LevelConfig struct {
+	RequestsPerMinute int           // Ограничение частоты запросов к GPT
+	MaxAttempts       int           // После стольких неудачных попыток слово остаётся без уровня
+	PollInterval      time.Duration // Пауза между проверками пустой очереди
+}
+
+// DefaultLevelConfig - настройки по умолчанию
+var DefaultLevelConfig = LevelConfig{
+	RequestsPerMinute: 10,
+	MaxAttempts:       3,
+	PollInterval:      time.Minute,
+}
+
+// LevelWorker - фоновый обработчик, который определяет уровень заполненных слов без уровня.
+// При заполнении слова уровень берётся только из частотного списка; для остальных слов уровень
+// оценивает GPT здесь, с ограничением частоты запросов, а не в запросе на добавление слова.
+type LevelWorker struct {
+	dbpool *pgxpool.Pool
+	cfg    LevelConfig
+}
+
+// NewLevelWorker создаёт worker; запускается вызовом Run в отдельной горутине
+func NewLevelWorker(dbpool *pgxpool.Pool, cfg LevelConfig) *LevelWorker {
+	// Ограничитель частоты делит минуту на RequestsPerMinute: без положительного значения берём значение по умолчанию
+	if cfg.RequestsPerMinute <= 0 {
+		log.Printf("Level worker: invalid requests per minute %d, using %d", cfg.RequestsPerMinute, DefaultLevelConfig.RequestsPerMinute)
+		cfg.RequestsPerMinute = DefaultLevelConfig.RequestsPerMinute
+	}
+	return &LevelWorker{dbpool: dbpool, cfg: cfg}
+}
+
+// Run разбирает очередь, пока не будет отменён ctx
+func (w *LevelWorker) Run(ctx context.Context) {
+	// Ограничитель частоты: не больше RequestsPerMinute обращений к GPT
+	limiter := time.NewTicker(time.Minute / time.Duration(w.cfg.RequestsPerMinute))
+	defer limiter.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-limiter.C:
+		}
+
+		err := w.processNext(ctx)
+		if errors.Is(err, repository.ErrQueueEmpty) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Level worker error: %v", err)
+		}
+	}
+}
+
+// processNext определяет уровень одного слова из очереди
+func (w *LevelWorker) processNext(ctx context.Context) error {
+	word, err := repository.NextUntaggedWord(ctx, w.dbpool, w.cfg.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	err = services.TagWordLevel(ctx, w.dbpool, word)
+	if err != nil {
+		if failErr := repository.FailLevelAttempt(ctx, w.dbpool, word.ID, err); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+	return nil
+}
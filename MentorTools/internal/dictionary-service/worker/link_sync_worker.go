@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// LinkSyncConfig - настройки синхронизации привязок учеников к репетиторам
+type LinkSyncConfig struct {
+	Interval time.Duration // Как часто перечитывать привязки из user-service
+}
+
+// DefaultLinkSyncConfig - настройки по умолчанию
+var DefaultLinkSyncConfig = LinkSyncConfig{
+	Interval: time.Minute,
+}
+
+// LinkSyncWorker - фоновая синхронизация привязок учеников к репетиторам из базы auth_db user-service
+// в таблицу tutor_students базы словаря. Новая привязка становится видна словарю не позже чем через Interval.
+type LinkSyncWorker struct {
+	dbpool *pgxpool.Pool
+	authdb *pgxpool.Pool
+	cfg    LinkSyncConfig
+}
+
+// NewLinkSyncWorker создаёт worker; запускается вызовом Run в отдельной горутине
+func NewLinkSyncWorker(dbpool, authdb *pgxpool.Pool, cfg LinkSyncConfig) *LinkSyncWorker {
+	return &LinkSyncWorker{dbpool: dbpool, authdb: authdb, cfg: cfg}
+}
+
+// Run синхронизирует привязки, пока не будет отменён ctx
+func (w *LinkSyncWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Sync(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Link sync error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync один раз копирует привязки из user-service в базу словаря
+func (w *LinkSyncWorker) Sync(ctx context.Context) error {
+	links, err := repository.ListUserLinks(ctx, w.authdb)
+	if err != nil {
+		return err
+	}
+	return repository.WithTx(ctx, w.dbpool, func(tx pgx.Tx) error {
+		return repository.ReplaceTutorStudents(ctx, tx, links)
+	})
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"MentorTools/dictionary-service/repository"
+	"MentorTools/dictionary-service/services"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// WordJobConfig - настройки обработки задач на добавление слов
+type WordJobConfig struct {
+	Concurrency  int           // Количество одновременно обрабатываемых задач
+	PollInterval time.Duration // Как часто проверять очередь, если не пришло уведомление
+	StaleAfter   time.Duration // Через сколько задача в "running" считается брошенной
+}
+
+// DefaultWordJobConfig - настройки по умолчанию
+var DefaultWordJobConfig = WordJobConfig{
+	Concurrency:  4,
+	PollInterval: 5 * time.Second,
+	StaleAfter:   10 * time.Minute,
+}
+
+// WordJobWorker - обрабатывает задачи на добавление слов, созданные AddWordHandler.
+// Очередь хранится в таблице word_jobs, поэтому задачи переживают перезапуск сервиса.
+type WordJobWorker struct {
+	dbpool *pgxpool.Pool
+	cfg    WordJobConfig
+	notify chan struct{}
+}
+
+// NewWordJobWorker создаёт worker; запускается вызовом Run в отдельной горутине
+func NewWordJobWorker(dbpool *pgxpool.Pool, cfg WordJobConfig) *WordJobWorker {
+	return &WordJobWorker{
+		dbpool: dbpool,
+		cfg:    cfg,
+		notify: make(chan struct{}, cfg.Concurrency),
+	}
+}
+
+// Notify будит worker после постановки новой задачи, чтобы не ждать PollInterval
+func (w *WordJobWorker) Notify() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run запускает Concurrency обработчиков и ждёт их завершения после отмены ctx
+func (w *WordJobWorker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop обрабатывает задачи, пока очередь не опустеет, затем ждёт уведомления или таймера
+func (w *WordJobWorker) loop(ctx context.Context) {
+	for {
+		err := w.processNext(ctx)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, repository.ErrQueueEmpty) {
+			log.Printf("Word job worker error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.notify:
+		case <-time.After(w.cfg.PollInterval):
+		}
+	}
+}
+
+// processNext забирает одну задачу из очереди и выполняет её
+func (w *WordJobWorker) processNext(ctx context.Context) error {
+	job, err := repository.ClaimWordJob(ctx, w.dbpool, w.cfg.StaleAfter)
+	if err != nil {
+		return err
+	}
+
+	wordID, err := services.IngestWord(ctx, w.dbpool, job.StudentID, job.Word, func(stage string) {
+		if err := repository.UpdateWordJobStage(ctx, w.dbpool, job.ID, stage); err != nil {
+			log.Printf("Failed to update stage of job %s: %v", job.ID, err)
+		}
+	})
+	if err != nil {
+		log.Printf("Word job %s failed: %v", job.ID, err)
+		return repository.FailWordJob(ctx, w.dbpool, job.ID, err)
+	}
+
+	// Слово уже добавлено, поэтому ошибка сохранения примера не проваливает задачу
+	if job.SourceSentence != "" {
+		if err := services.AddStudentExample(ctx, w.dbpool, job.StudentID, wordID, job.SourceSentence); err != nil {
+			log.Printf("Failed to save source sentence of job %s: %v", job.ID, err)
+		}
+	}
+
+	return repository.CompleteWordJob(ctx, w.dbpool, job.ID, wordID)
+}
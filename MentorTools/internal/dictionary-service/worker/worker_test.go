@@ -0,0 +1,22 @@
+package worker
+
+import "testing"
+
+// Нулевая или отрицательная частота запросов заменяется значением по умолчанию: иначе Run делит минуту на ноль
+func TestWorkersDefaultRequestsPerMinute(t *testing.T) {
+	for _, rpm := range []int{0, -1} {
+		if got := NewEnrichmentWorker(nil, EnrichmentConfig{RequestsPerMinute: rpm}).cfg.RequestsPerMinute; got != DefaultEnrichmentConfig.RequestsPerMinute {
+			t.Errorf("enrichment worker with rpm %d: %d, want %d", rpm, got, DefaultEnrichmentConfig.RequestsPerMinute)
+		}
+		if got := NewAudioWorker(nil, nil, nil, AudioConfig{RequestsPerMinute: rpm}).cfg.RequestsPerMinute; got != DefaultAudioConfig.RequestsPerMinute {
+			t.Errorf("audio worker with rpm %d: %d, want %d", rpm, got, DefaultAudioConfig.RequestsPerMinute)
+		}
+		if got := NewLevelWorker(nil, LevelConfig{RequestsPerMinute: rpm}).cfg.RequestsPerMinute; got != DefaultLevelConfig.RequestsPerMinute {
+			t.Errorf("level worker with rpm %d: %d, want %d", rpm, got, DefaultLevelConfig.RequestsPerMinute)
+		}
+	}
+
+	if got := NewLevelWorker(nil, LevelConfig{RequestsPerMinute: 3}).cfg.RequestsPerMinute; got != 3 {
+		t.Errorf("level worker with rpm 3: %d, want 3", got)
+	}
+}
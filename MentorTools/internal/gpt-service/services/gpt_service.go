@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 )
 
 // Определение структуры запроса к OpenAI
@@ -37,8 +38,24 @@ type WordDetails struct {
 	Description   string     `json:"Description"`
 	Synonyms      []string   `json:"Synonyms"`
 	Examples      []Examples `json:"Examples"`
+	Relations     []Relation `json:"Relations,omitempty"`
 }
 
+// Relation представляет связанное слово: антоним, сочетание, однокоренное слово или более общее понятие
+type Relation struct {
+	Word       string  `json:"word"`
+	Type       string  `json:"type"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Типы связей, которые возвращает GPT; синонимы приходят отдельным полем Synonyms
+const (
+	RelationAntonym     = "antonym"
+	RelationCollocation = "collocation"
+	RelationDerivative  = "derivative"
+	RelationHypernym    = "hypernym"
+)
+
 // Examples представляет пример использования слова
 type Examples struct {
 	Text        string   `json:"sentence"`
@@ -47,13 +64,21 @@ type Examples struct {
 	Context     string   `json:"area"`
 }
 
-// Единственное сообщение-инструкция, которое будет храниться в контексте
-var systemMessage = Message{
-	Role:    "system",
-	Content: "Я отправлю тебе слово на английском языке (изучаемое слово), а также слова-маркеры и контекст для генерации примеров.Ты должен сгенерировать следующую информацию строго в формате JSON без форматирования или дополнительных символов, таких как тройные кавычки:1. Transcription — string: транскрипция изучаемого слова на British English;2. Translation — string: перевод изучаемого слова на русский язык;3. Description — string: объяснение значения изучаемого слова на русском языке;4. Synonyms — []string: список синонимов для изучаемого слова на английском языке;5. Examples — []Examples: список из 5 или более примеров. Структура Examples:1. sentence — string: пример, должен быть максимально простым и коротким, но обязательно содержать изучаемое слово, слова-маркеры, а также должен быть на тему (контекст, топик) из запроса;2. keywords — []string: перечень неизвестных, новых, других слов, которые используются в примере (больше-лучше);3. translation — string: перевод примера на русский;4. area — string: область или контекст примера одним словом или выражением."}
+// Model - модель OpenAI, которая генерирует данные о слове
+const Model = "gpt-service-4-turbo"
+
+// PromptVersion - версия системных инструкций из prompts.go. Увеличивается при каждом изменении промптов,
+// чтобы контент, созданный по старой версии, можно было найти и перегенерировать.
+const PromptVersion = 2
+
+// GetWordDetailsFromGPT - Функция для получения данных о слове от ChatGPT.
+// sourceLang - язык изучаемого слова, targetLang - язык перевода и объяснений (коды ISO 639-1).
+func GetWordDetailsFromGPT(word, sourceLang, targetLang string, wordsForExamples []string, topics []string) (WordDetails, error) {
+	systemMessage, err := systemMessageFor(sourceLang, targetLang)
+	if err != nil {
+		return WordDetails{}, err
+	}
 
-// GetWordDetailsFromGPT - Функция для получения данных о слове от ChatGPT
-func GetWordDetailsFromGPT(word string, wordsForExamples []string, topics []string) (string, string, string, []string, []Examples, error) {
 	// Формируем новое сообщение с текущим словом
 	userMessage := Message{
 		Role: "user",
@@ -64,16 +89,138 @@ func GetWordDetailsFromGPT(word string, wordsForExamples []string, topics []stri
 		}`, word, wordsForExamples, topics),
 	}
 
-	// Формируем запрос, включая только инструкцию и текущее сообщение
+	content, err := requestChatCompletion([]Message{systemMessage, userMessage}) // Инструкция + текущее сообщение
+	if err != nil {
+		return WordDetails{}, err
+	}
+
+	// Парсим содержимое ответа
+	wordDetails, err := parseGPTResponse(content)
+	if err != nil {
+		return WordDetails{}, fmt.Errorf("error parsing GPT response: %v", err)
+	}
+
+	return wordDetails, nil
+}
+
+// GetWordLevelFromGPT - оценивает уровень CEFR (A1-C2) слова языка sourceLang.
+// Используется для слов, которых нет во встроенном частотном списке.
+func GetWordLevelFromGPT(word, sourceLang string) (string, error) {
+	language, ok := languageNames[sourceLang]
+	if !ok {
+		return "", fmt.Errorf("unsupported language %s", sourceLang)
+	}
+
+	content, err := requestChatCompletion([]Message{
+		{Role: "system", Content: fmt.Sprintf(levelPromptTemplate, language)},
+		{Role: "user", Content: word},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Level string `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return "", fmt.Errorf("error parsing GPT response: %v", err)
+	}
+	return strings.ToUpper(strings.TrimSpace(response.Level)), nil
+}
+
+// SentenceCheck - проверка предложения, которое ученик написал с изучаемым словом
+type SentenceCheck struct {
+	GrammarCorrect bool   `json:"grammarCorrect"`
+	UsageCorrect   bool   `json:"usageCorrect"` // Слово употреблено в правильном значении и форме
+	Corrected      string `json:"corrected"`    // Исправленное предложение; совпадает с исходным, если ошибок нет
+	Explanation    string `json:"explanation"`  // Объяснение ошибок на языке объяснений
+	Translation    string `json:"translation"`  // Перевод исправленного предложения
+}
+
+// CheckSentenceWithGPT - проверяет грамматику предложения ученика на языке sourceLang и употребление в нём слова word.
+// Исправления объясняются, а предложение переводится на язык targetLang.
+func CheckSentenceWithGPT(word, sentence, sourceLang, targetLang string) (SentenceCheck, error) {
+	if !SupportsLanguagePair(sourceLang, targetLang) {
+		return SentenceCheck{}, fmt.Errorf("unsupported language pair %s-%s", sourceLang, targetLang)
+	}
+
+	request, err := json.Marshal(map[string]string{"word": word, "sentence": sentence})
+	if err != nil {
+		return SentenceCheck{}, err
+	}
+
+	content, err := requestChatCompletion([]Message{
+		{Role: "system", Content: fmt.Sprintf(sentenceCheckPromptTemplate, languageNames[sourceLang], languageNames[targetLang])},
+		{Role: "user", Content: string(request)},
+	})
+	if err != nil {
+		return SentenceCheck{}, err
+	}
+
+	var check SentenceCheck
+	if err := json.Unmarshal([]byte(content), &check); err != nil {
+		return SentenceCheck{}, fmt.Errorf("error parsing GPT response: %v", err)
+	}
+	check.Corrected = strings.TrimSpace(check.Corrected)
+	return check, nil
+}
+
+// ContrastivePairs - сколько пар предложений просить в упражнении на различение слов
+const ContrastivePairs = 3
+
+// ContrastiveItem - предложение с пропуском для одного из двух слов
+type ContrastiveItem struct {
+	Sentence    string `json:"sentence"` // Предложение с пропуском "___"
+	Answer      string `json:"answer"`   // Слово, которое нужно вставить
+	Translation string `json:"translation"`
+}
+
+// ContrastiveExercise - упражнение на различение двух слов из пар предложений, отличающихся минимально
+type ContrastiveExercise struct {
+	Explanation string            `json:"explanation"` // Чем слова различаются, на языке объяснений
+	Items       []ContrastiveItem `json:"items"`
+}
+
+// GenerateContrastiveExerciseWithGPT - пишет упражнение на различение слов word и otherWord на языке sourceLang:
+// пары предложений с пропуском, в каждую из которых подходит только одно из слов.
+// Объяснение и переводы предложений - на языке targetLang.
+func GenerateContrastiveExerciseWithGPT(word, otherWord, sourceLang, targetLang string) (ContrastiveExercise, error) {
+	if !SupportsLanguagePair(sourceLang, targetLang) {
+		return ContrastiveExercise{}, fmt.Errorf("unsupported language pair %s-%s", sourceLang, targetLang)
+	}
+
+	request, err := json.Marshal(map[string]string{"firstWord": word, "secondWord": otherWord})
+	if err != nil {
+		return ContrastiveExercise{}, err
+	}
+
+	content, err := requestChatCompletion([]Message{
+		{Role: "system", Content: fmt.Sprintf(contrastivePromptTemplate, languageNames[sourceLang], languageNames[targetLang], ContrastivePairs)},
+		{Role: "user", Content: string(request)},
+	})
+	if err != nil {
+		return ContrastiveExercise{}, err
+	}
+
+	var exercise ContrastiveExercise
+	if err := json.Unmarshal([]byte(content), &exercise); err != nil {
+		return ContrastiveExercise{}, fmt.Errorf("error parsing GPT response: %v", err)
+	}
+	return exercise, nil
+}
+
+// requestChatCompletion - отправляет сообщения в OpenAI и возвращает текст первого ответа
+func requestChatCompletion(messages []Message) (string, error) {
+	// Формируем запрос
 	requestBody := OpenAIRequest{
-		Model:    "gpt-service-4-turbo",
-		Messages: []Message{systemMessage, userMessage}, // Инструкция + текущее сообщение
+		Model:    Model,
+		Messages: messages,
 	}
 
 	// Конвертируем запрос в JSON
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", "", "", nil, nil, fmt.Errorf("error marshalling request: %v", err)
+		return "", fmt.Errorf("error marshalling request: %v", err)
 	}
 
 	fmt.Printf("Request body to GPT: %s\n", jsonData) // Логируем тело запроса
@@ -81,7 +228,7 @@ func GetWordDetailsFromGPT(word string, wordsForExamples []string, topics []stri
 	// Создаем HTTP-запрос к OpenAI API
 	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", "", "", nil, nil, fmt.Errorf("error creating request: %v", err)
+		return "", fmt.Errorf("error creating request: %v", err)
 	}
 
 	// Логируем URL и заголовки запроса
@@ -96,7 +243,7 @@ func GetWordDetailsFromGPT(word string, wordsForExamples []string, topics []stri
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", "", "", nil, nil, fmt.Errorf("error making request: %v", err)
+		return "", fmt.Errorf("error making request: %v", err)
 	}
 	defer resp.Body.Close()
 
@@ -106,25 +253,18 @@ func GetWordDetailsFromGPT(word string, wordsForExamples []string, topics []stri
 	// Обрабатываем ответ
 	var openAIResponse OpenAIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&openAIResponse); err != nil {
-		return "", "", "", nil, nil, fmt.Errorf("error decoding response: %v", err)
+		return "", fmt.Errorf("error decoding response: %v", err)
 	}
 
 	// Проверяем, что ответ содержит хотя бы один выбор
 	if len(openAIResponse.Choices) == 0 {
-		return "", "", "", nil, nil, fmt.Errorf("no choices returned from GPT")
+		return "", fmt.Errorf("no choices returned from GPT")
 	}
 
 	// Логируем полученный ответ от GPT
 	content := openAIResponse.Choices[0].Message.Content
 	fmt.Println("GPT Response:", content)
-
-	// Парсим содержимое ответа
-	wordDetails, err := parseGPTResponse(content)
-	if err != nil {
-		return "", "", "", nil, nil, fmt.Errorf("error parsing GPT response: %v", err)
-	}
-
-	return wordDetails.Transcription, wordDetails.Translation, wordDetails.Description, wordDetails.Synonyms, wordDetails.Examples, nil
+	return content, nil
 }
 
 // Функция для парсинга ответа в нужную структуру
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// GeneratedImage - сгенерированная картинка
+type GeneratedImage struct {
+	Data        []byte
+	ContentType string
+}
+
+// ImageGenerator - провайдер генерации картинок по текстовому описанию
+type ImageGenerator interface {
+	// ID однозначно описывает провайдера и модель
+	ID() string
+	// Generate рисует картинку по описанию prompt
+	Generate(ctx context.Context, prompt string) (GeneratedImage, error)
+}
+
+// ImagePrompt - описание картинки для карточки слова: простая иллюстрация значения без текста,
+// чтобы картинка не подсказывала написание слова
+func ImagePrompt(word, translation, language string) string {
+	prompt := fmt.Sprintf("A simple, clear flat illustration for a vocabulary flashcard showing the meaning of the %s word %q", languageNames[language], word)
+	if translation != "" {
+		prompt += fmt.Sprintf(" (%s)", translation)
+	}
+	return prompt + ". Plain light background, no text, no letters, no captions."
+}
+
+// OpenAIImages - генерация картинок через OpenAI-совместимый эндпоинт /images/generations
+type OpenAIImages struct {
+	BaseURL string // Например, "https://api.openai.com/v1"
+	APIKey  string
+	Model   string
+	Size    string
+	Client  *http.Client
+}
+
+// NewOpenAIImages создаёт провайдера с настройками из окружения: OPENAI_API_KEY, а также
+// необязательные OPENAI_BASE_URL, IMAGE_MODEL и IMAGE_SIZE для совместимых сервисов
+func NewOpenAIImages() *OpenAIImages {
+	return &OpenAIImages{
+		BaseURL: envOrDefault("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		APIKey:  os.Getenv("OPENAI_API_KEY"),
+		Model:   envOrDefault("IMAGE_MODEL", "dall-e-3"),
+		Size:    envOrDefault("IMAGE_SIZE", "1024x1024"),
+		Client:  &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// ID возвращает модель
+func (g *OpenAIImages) ID() string {
+	return "openai/" + g.Model
+}
+
+// Generate рисует картинку; OpenAI возвращает PNG в base64
+func (g *OpenAIImages) Generate(ctx context.Context, prompt string) (GeneratedImage, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":           g.Model,
+		"prompt":          prompt,
+		"n":               1,
+		"size":            g.Size,
+		"response_format": "b64_json",
+	})
+	if err != nil {
+		return GeneratedImage{}, fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(g.BaseURL, "/")+"/images/generations", bytes.NewReader(body))
+	if err != nil {
+		return GeneratedImage{}, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.APIKey)
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return GeneratedImage{}, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return GeneratedImage{}, fmt.Errorf("image request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var result struct {
+		Data []struct {
+			B64JSON string `json:"b64_json"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return GeneratedImage{}, fmt.Errorf("error parsing response: %v", err)
+	}
+	if len(result.Data) == 0 {
+		return GeneratedImage{}, fmt.Errorf("image response has no images")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(result.Data[0].B64JSON)
+	if err != nil {
+		return GeneratedImage{}, fmt.Errorf("error decoding image: %v", err)
+	}
+	return GeneratedImage{Data: data, ContentType: "image/png"}, nil
+}
+
+// StubImages - провайдер для локальной разработки и тестов без обращения к сети:
+// возвращает PNG 256x256 с градиентом, цвет которого зависит от описания
+type StubImages struct{}
+
+// ID возвращает идентификатор заглушки
+func (StubImages) ID() string {
+	return "stub"
+}
+
+// Generate рисует градиент; одно и то же описание даёт одну и ту же картинку
+func (StubImages) Generate(ctx context.Context, prompt string) (GeneratedImage, error) {
+	const size = 256
+	sum := sha256.Sum256([]byte(prompt))
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, color.RGBA{
+				R: uint8(int(sum[0]) * x / size),
+				G: uint8(int(sum[1]) * y / size),
+				B: sum[2],
+				A: 255,
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return GeneratedImage{}, err
+	}
+	return GeneratedImage{Data: buf.Bytes(), ContentType: "image/png"}, nil
+}
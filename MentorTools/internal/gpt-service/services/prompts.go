@@ -0,0 +1,105 @@
+package services
+
+import "fmt"
+
+// SourceLanguages - языки изучаемых слов и вариант произношения, в котором даётся транскрипция
+var SourceLanguages = map[string]string{
+	"en": "British English",
+	"de": "Standard German",
+	"es": "Castilian Spanish",
+}
+
+// TargetLanguages - языки перевода и объяснений
+var TargetLanguages = map[string]string{
+	"ru": "Russian",
+	"en": "English",
+}
+
+// languageNames - названия языков для шаблона промпта
+var languageNames = map[string]string{
+	"en": "English",
+	"de": "German",
+	"es": "Spanish",
+	"ru": "Russian",
+}
+
+// promptTemplates - инструкции, написанные под конкретную языковую пару ("en-ru");
+// для остальных пар инструкция собирается из genericPromptTemplate
+var promptTemplates = map[string]string{
+	"en-ru": "Я отправлю тебе слово на английском языке (изучаемое слово), а также слова-маркеры и контекст для генерации примеров.Ты должен сгенерировать следующую информацию строго в формате JSON без форматирования или дополнительных символов, таких как тройные кавычки:1. Transcription — string: транскрипция изучаемого слова на British English;2. Translation — string: перевод изучаемого слова на русский язык;3. Description — string: объяснение значения изучаемого слова на русском языке;4. Synonyms — []string: список синонимов для изучаемого слова на английском языке;5. Examples — []Examples: список из 5 или более примеров. Структура Examples:1. sentence — string: пример, должен быть максимально простым и коротким, но обязательно содержать изучаемое слово, слова-маркеры, а также должен быть на тему (контекст, топик) из запроса;2. keywords — []string: перечень неизвестных, новых, других слов, которые используются в примере (больше-лучше);3. translation — string: перевод примера на русский;4. area — string: область или контекст примера одним словом или выражением.6. Relations — []Relation: связанные с изучаемым словом слова и выражения на английском языке, кроме синонимов. Структура Relation:1. word — string: связанное слово или выражение;2. type — string: antonym (антоним), collocation (устойчивое сочетание или фразовый глагол с изучаемым словом), derivative (однокоренное слово другой части речи) или hypernym (более общее понятие);3. confidence — number от 0 до 1: насколько ты уверен в этой связи.",
+}
+
+// genericPromptTemplate - инструкция для языковых пар без собственного шаблона.
+// %[1]s - язык изучаемого слова, %[2]s - вариант произношения, %[3]s - язык перевода и объяснений,
+// %[4]s - что вернуть в поле Translation.
+const genericPromptTemplate = "I will send you a word in %[1]s (the studied word), as well as marker words and topics for generating examples. " +
+	"You must generate the following information strictly as JSON without formatting or extra characters such as triple quotes: " +
+	"1. Transcription — string: IPA transcription of the studied word in %[2]s; " +
+	"2. Translation — string: %[4]s; " +
+	"3. Description — string: explanation of the meaning of the studied word in %[3]s; " +
+	"4. Synonyms — []string: list of synonyms of the studied word in %[1]s; " +
+	"5. Examples — []Examples: list of 5 or more examples. Examples structure: " +
+	"1. sentence — string: an example in %[1]s, as simple and short as possible, but it must contain the studied word, the marker words and be on a topic from the request; " +
+	"2. keywords — []string: list of unknown, new, other words used in the example (the more the better); " +
+	"3. translation — string: translation of the example into %[3]s; " +
+	"4. area — string: area or context of the example in one word or expression; " +
+	"6. Relations — []Relation: words and expressions in %[1]s related to the studied word, except synonyms. Relation structure: " +
+	"1. word — string: the related word or expression; " +
+	"2. type — string: antonym, collocation (a typical word combination or phrasal verb with the studied word), " +
+	"derivative (a word with the same root and a different part of speech) or hypernym (a broader concept); " +
+	"3. confidence — number from 0 to 1: how sure you are about the relation."
+
+// levelPromptTemplate - инструкция для оценки уровня CEFR слова, %s - язык слова
+const levelPromptTemplate = "I will send you a word or expression in %s. " +
+	"Estimate the CEFR level (A1, A2, B1, B2, C1 or C2) at which learners usually learn it. " +
+	"Answer strictly as JSON without formatting or extra characters such as triple quotes: {\"level\": \"B1\"}."
+
+// sentenceCheckPromptTemplate - инструкция для проверки предложения ученика;
+// %[1]s - язык предложения, %[2]s - язык объяснений и перевода
+const sentenceCheckPromptTemplate = "I will send you JSON with a word in %[1]s (the studied word) and a sentence in %[1]s written by a student to practice it. " +
+	"Check the grammar of the sentence and whether the studied word is used with the correct meaning and in a correct form. " +
+	"Answer strictly as JSON without formatting or extra characters such as triple quotes, with the fields: " +
+	"1. grammarCorrect — boolean: the sentence has no grammar or spelling mistakes; " +
+	"2. usageCorrect — boolean: the studied word is used correctly; " +
+	"3. corrected — string: the corrected sentence, the same sentence if there are no mistakes; keep the student's wording as much as possible; " +
+	"4. explanation — string: a short and friendly explanation of the mistakes in %[2]s, empty if there are no mistakes; " +
+	"5. translation — string: translation of the corrected sentence into %[2]s."
+
+// contrastivePromptTemplate - инструкция для упражнения на различение двух слов, которые ученик путает;
+// %[1]s - язык слов, %[2]s - язык объяснений и перевода, %[3]d - количество пар предложений
+const contrastivePromptTemplate = "I will send you JSON with two words in %[1]s that a student keeps confusing. " +
+	"Write %[3]d minimal pairs of short natural sentences in %[1]s: the two sentences of a pair differ as little as possible, " +
+	"and one of them needs the first word while the other needs the second word. Replace the word in each sentence with \"___\"; " +
+	"only one of the two words must fit the gap, in exactly the form given. " +
+	"Answer strictly as JSON without formatting or extra characters such as triple quotes, with the fields: " +
+	"1. explanation — string: a short and friendly explanation in %[2]s of the difference between the words; " +
+	"2. items — array of objects with the fields sentence (the sentence with the gap), answer (the word that fills the gap) " +
+	"and translation (translation of the full sentence into %[2]s); the sentences of a pair go one after another."
+
+// SupportsLanguagePair проверяет, умеет ли сервис генерировать контент для языковой пары
+func SupportsLanguagePair(sourceLang, targetLang string) bool {
+	_, source := SourceLanguages[sourceLang]
+	_, target := TargetLanguages[targetLang]
+	return source && target
+}
+
+// systemMessageFor выбирает инструкцию для языковой пары: собственный шаблон пары или общий
+func systemMessageFor(sourceLang, targetLang string) (Message, error) {
+	if !SupportsLanguagePair(sourceLang, targetLang) {
+		return Message{}, fmt.Errorf("unsupported language pair %s-%s", sourceLang, targetLang)
+	}
+
+	if content, ok := promptTemplates[sourceLang+"-"+targetLang]; ok {
+		return Message{Role: "system", Content: content}, nil
+	}
+
+	target := languageNames[targetLang]
+	translation := "translation of the studied word into " + target
+	// Объяснения на том же языке: вместо перевода - короткий простой пересказ значения
+	if sourceLang == targetLang {
+		translation = "a short and simple paraphrase of the studied word in " + target
+	}
+
+	content := fmt.Sprintf(genericPromptTemplate, languageNames[sourceLang], SourceLanguages[sourceLang], target, translation)
+	return Message{Role: "system", Content: content}, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Speech - синтезированная речь
+type Speech struct {
+	Audio       []byte
+	ContentType string
+}
+
+// SpeechSynthesizer - провайдер синтеза речи (TTS)
+type SpeechSynthesizer interface {
+	// ID однозначно описывает провайдера, модель и голос: при смене голоса меняются и ключи сохранённого аудио
+	ID() string
+	// Extension - расширение файлов, которые возвращает Synthesize (".mp3")
+	Extension() string
+	// Synthesize озвучивает текст на языке language (код ISO 639-1)
+	Synthesize(ctx context.Context, text, language string) (Speech, error)
+}
+
+// OpenAISpeech - синтез речи через OpenAI-совместимый эндпоинт /audio/speech
+type OpenAISpeech struct {
+	BaseURL string // Например, "https://api.openai.com/v1"
+	APIKey  string
+	Model   string
+	Voice   string
+	Client  *http.Client
+}
+
+// NewOpenAISpeech создаёт провайдера с настройками из окружения: OPENAI_API_KEY, а также
+// необязательные OPENAI_BASE_URL, TTS_MODEL и TTS_VOICE для совместимых сервисов
+func NewOpenAISpeech() *OpenAISpeech {
+	return &OpenAISpeech{
+		BaseURL: envOrDefault("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		APIKey:  os.Getenv("OPENAI_API_KEY"),
+		Model:   envOrDefault("TTS_MODEL", "tts-1"),
+		Voice:   envOrDefault("TTS_VOICE", "alloy"),
+		Client:  &http.Client{Timeout: time.Minute},
+	}
+}
+
+// ID возвращает модель и голос
+func (s *OpenAISpeech) ID() string {
+	return "openai/" + s.Model + "/" + s.Voice
+}
+
+// Extension - OpenAI возвращает MP3
+func (s *OpenAISpeech) Extension() string {
+	return ".mp3"
+}
+
+// Synthesize озвучивает текст; язык модель определяет сама по тексту
+func (s *OpenAISpeech) Synthesize(ctx context.Context, text, language string) (Speech, error) {
+	body, err := json.Marshal(map[string]string{
+		"model":           s.Model,
+		"voice":           s.Voice,
+		"input":           text,
+		"response_format": "mp3",
+	})
+	if err != nil {
+		return Speech{}, fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(s.BaseURL, "/")+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return Speech{}, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.APIKey)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return Speech{}, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return Speech{}, fmt.Errorf("speech request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Speech{}, fmt.Errorf("error reading response: %v", err)
+	}
+	return Speech{Audio: audio, ContentType: "audio/mpeg"}, nil
+}
+
+// StubSpeech - провайдер для локальной разработки и тестов без обращения к сети:
+// возвращает WAV с тишиной, длина которой зависит от длины текста
+type StubSpeech struct{}
+
+// ID возвращает идентификатор заглушки
+func (StubSpeech) ID() string {
+	return "stub"
+}
+
+// Extension - заглушка возвращает WAV
+func (StubSpeech) Extension() string {
+	return ".wav"
+}
+
+// Synthesize возвращает тишину: 50 мс на символ, но не меньше 0,5 с
+func (StubSpeech) Synthesize(ctx context.Context, text, language string) (Speech, error) {
+	const sampleRate = 8000
+	duration := 50 * time.Millisecond * time.Duration(len([]rune(text)))
+	if duration < 500*time.Millisecond {
+		duration = 500 * time.Millisecond
+	}
+	samples := int(duration.Seconds() * sampleRate)
+
+	// Заголовок WAV: PCM, моно, 8 бит
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+samples))
+	buf.WriteString("WAVEfmt ")
+	for _, field := range []interface{}{
+		uint32(16), uint16(1), uint16(1), uint32(sampleRate), uint32(sampleRate), uint16(1), uint16(8),
+	} {
+		binary.Write(&buf, binary.LittleEndian, field)
+	}
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(samples))
+	// Тишина в 8-битном PCM - середина диапазона
+	buf.Write(bytes.Repeat([]byte{128}, samples))
+
+	return Speech{Audio: buf.Bytes(), ContentType: "audio/wav"}, nil
+}
+
+// envOrDefault возвращает переменную окружения или значение по умолчанию, если она не задана
+func envOrDefault(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+func TestStubSpeechSynthesize(t *testing.T) {
+	var tts SpeechSynthesizer = StubSpeech{}
+	ctx := context.Background()
+
+	tests := []struct {
+		text    string
+		samples int // 8000 отсчётов в секунду
+	}{
+		{"cat", 4000}, // Короткий текст - не меньше 0,5 с
+		{"An apple a day keeps the doctor away.", 14800}, // 37 символов по 50 мс
+		{"яблоко", 4000},                                 // Длина считается в символах, а не в байтах
+	}
+	for _, tt := range tests {
+		speech, err := tts.Synthesize(ctx, tt.text, "en")
+		if err != nil {
+			t.Fatalf("Synthesize(%q): %v", tt.text, err)
+		}
+		if speech.ContentType != "audio/wav" {
+			t.Errorf("%q: content type %q, want audio/wav", tt.text, speech.ContentType)
+		}
+
+		audio := speech.Audio
+		if len(audio) != 44+tt.samples {
+			t.Fatalf("%q: %d bytes, want 44-byte header and %d samples", tt.text, len(audio), tt.samples)
+		}
+		if string(audio[0:4]) != "RIFF" || string(audio[8:16]) != "WAVEfmt " || string(audio[36:40]) != "data" {
+			t.Errorf("%q: not a WAV header: %q", tt.text, audio[:44])
+		}
+		if size := binary.LittleEndian.Uint32(audio[4:8]); int(size) != len(audio)-8 {
+			t.Errorf("%q: RIFF size %d, want %d", tt.text, size, len(audio)-8)
+		}
+		if rate := binary.LittleEndian.Uint32(audio[24:28]); rate != 8000 {
+			t.Errorf("%q: sample rate %d, want 8000", tt.text, rate)
+		}
+		if size := binary.LittleEndian.Uint32(audio[40:44]); int(size) != tt.samples {
+			t.Errorf("%q: data size %d, want %d", tt.text, size, tt.samples)
+		}
+		if !bytes.Equal(audio[44:], bytes.Repeat([]byte{128}, tt.samples)) {
+			t.Errorf("%q: samples are not silence", tt.text)
+		}
+	}
+
+	if tts.ID() != "stub" || tts.Extension() != ".wav" {
+		t.Errorf("ID, Extension = %q, %q; want stub, .wav", tts.ID(), tts.Extension())
+	}
+}
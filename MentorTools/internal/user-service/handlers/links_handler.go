@@ -10,7 +10,8 @@ import (
 	"net/http"
 )
 
-// GetStudentsHandler returns an http.HandlerFunc to get a list of students connected to the teacher.
+// GetStudentsHandler returns an http.HandlerFunc to get a page of students connected to the teacher.
+// The page is selected with the "limit" and "cursor" query parameters.
 func GetStudentsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Extract user claims from the request context
@@ -24,8 +25,19 @@ func GetStudentsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 		// Convert the user ID from the token claims to an integer
 		teacherID := int(claims["id"].(float64)) // Assuming the teacher ID is stored in the token claims
 
-		// Call the service layer to fetch students associated with the teacher
-		students, appErr := services.GetStudents(context.Background(), dbPool, teacherID)
+		// Parse the "limit" and "cursor" query parameters
+		pageRequest, err := common.ParsePageRequest(r)
+		if err != nil {
+			common.JSONResponse(w, http.StatusBadRequest, common.NewErrorResponse("REQ400", err.Error()))
+			return
+		}
+
+		// Call the service layer to fetch a page of students associated with the teacher
+		students, appErr := services.GetStudents(context.Background(), dbPool, teacherID, pageRequest)
+		if appErr != nil && appErr.Code == "REQ400" {
+			common.JSONResponse(w, http.StatusBadRequest, appErr)
+			return
+		}
 		if appErr != nil {
 			// If there is an application error (e.g., no students found), return a "Not Found" status with a JSON error response
 			common.JSONResponse(w, http.StatusNotFound, common.NewErrorResponse("USER404", appErr.Message))
@@ -7,32 +7,59 @@ import (
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
-// GetStudents retrieves the list of students linked to a specific teacher.
-func GetStudents(ctx context.Context, dbPool *pgxpool.Pool, teacherID int) ([]models.Student, *common.Response) {
-	var students []models.Student
+// studentCursor is the position of the last student on a page; students are ordered by ID.
+type studentCursor struct {
+	ID int `json:"id"`
+}
+
+// GetStudents retrieves a page of students linked to a specific teacher, ordered by student ID.
+func GetStudents(ctx context.Context, dbPool *pgxpool.Pool, teacherID int, pageRequest common.PageRequest) (common.Page[models.Student], *common.Response) {
+	var page common.Page[models.Student]
+
+	// Decode the position of the last student from the previous page
+	var after studentCursor
+	if pageRequest.Cursor != "" {
+		if err := common.DecodeCursor(pageRequest.Cursor, &after); err != nil {
+			return page, common.NewErrorResponse("REQ400", "Invalid cursor")
+		}
+	}
 
-	// Call the database procedure to get students linked to the teacher
-	rows, err := dbPool.Query(ctx, "SELECT * FROM fn_get_students_by_teacher($1)", teacherID)
+	// Count all linked students for the page total
+	var total int
+	if err := dbPool.QueryRow(ctx, "SELECT count(*) FROM fn_get_students_by_teacher($1)", teacherID).Scan(&total); err != nil {
+		return page, common.NewErrorResponse("DB500", "Database error occurred while counting students")
+	}
+
+	// Call the database procedure to get students linked to the teacher, fetching one extra row to detect the next page
+	rows, err := dbPool.Query(ctx, "SELECT * FROM fn_get_students_by_teacher($1) WHERE id > $2 ORDER BY id LIMIT $3",
+		teacherID, after.ID, pageRequest.Limit+1)
 	if err != nil {
-		return nil, common.NewErrorResponse("DB500", "Database error occurred while retrieving students")
+		return page, common.NewErrorResponse("DB500", "Database error occurred while retrieving students")
 	}
 	defer rows.Close()
 
 	// Scan each row into the Student model
+	var students []models.Student
 	for rows.Next() {
 		var student models.Student
 		if err := rows.Scan(&student.ID, &student.Name, &student.Email); err != nil {
-			return nil, common.NewErrorResponse("DB500", "Failed to scan student data")
+			return page, common.NewErrorResponse("DB500", "Failed to scan student data")
 		}
 		students = append(students, student)
 	}
 
 	// Check for errors after row iteration
 	if rows.Err() != nil {
-		return nil, common.NewErrorResponse("DB500", "Error while reading students data")
+		return page, common.NewErrorResponse("DB500", "Error while reading students data")
 	}
 
-	return students, nil
+	page, err = common.NewPage(students, pageRequest.Limit, total, func(student models.Student) interface{} {
+		return studentCursor{ID: student.ID}
+	})
+	if err != nil {
+		return page, common.NewErrorResponse("DB500", "Failed to build students page")
+	}
+	return page, nil
 }
 
 // CreateLink establishes a link between a teacher and a student based on the student's email.
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// Default and maximum page sizes for cursor-paginated lists.
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded or does not match the request.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest holds the cursor pagination parameters of a list request.
+type PageRequest struct {
+	Limit  int    // Number of items per page
+	Cursor string // Opaque cursor from the previous page; empty for the first page
+}
+
+// Page is a single page of a cursor-paginated list.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"` // Pass as "cursor" to fetch the next page
+	HasMore    bool   `json:"hasMore"`
+	Total      int    `json:"total"` // Number of items matching the filters across all pages
+}
+
+// ParsePageRequest reads the "limit" and "cursor" query parameters.
+// A missing limit falls back to DefaultPageLimit; limits above MaxPageLimit are rejected.
+func ParsePageRequest(r *http.Request) (PageRequest, error) {
+	request := PageRequest{Limit: DefaultPageLimit, Cursor: r.URL.Query().Get("cursor")}
+
+	if param := r.URL.Query().Get("limit"); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 1 || limit > MaxPageLimit {
+			return request, errors.New("limit must be between 1 and " + strconv.Itoa(MaxPageLimit))
+		}
+		request.Limit = limit
+	}
+	return request, nil
+}
+
+// EncodeCursor serializes the position of the last item on a page into an opaque string.
+func EncodeCursor(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor restores a position encoded by EncodeCursor.
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// NewPage builds a page from items fetched with a limit of limit+1:
+// the extra item only signals that there is a next page and is dropped.
+// position returns the cursor position of an item, used for the last item on the page.
+func NewPage[T any](items []T, limit, total int, position func(T) interface{}) (Page[T], error) {
+	page := Page[T]{Items: items, Total: total}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+
+		cursor, err := EncodeCursor(position(page.Items[limit-1]))
+		if err != nil {
+			return page, err
+		}
+		page.NextCursor = cursor
+	}
+	return page, nil
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPosition struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := testPosition{CreatedAt: time.Date(2024, 3, 1, 12, 30, 0, 123000000, time.UTC), ID: 42}
+
+	cursor, err := EncodeCursor(want)
+	if err != nil {
+		t.Fatalf("EncodeCursor: %v", err)
+	}
+	var got testPosition
+	if err := DecodeCursor(cursor, &got); err != nil {
+		t.Fatalf("DecodeCursor(%q): %v", cursor, err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("DecodeCursor = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	wrongType, _ := EncodeCursor("plain")
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", "eyJpZCI6MX0="},
+		{"not JSON", "bm90IGpzb24"},
+		{"wrong type", wrongType},
+	}
+	for _, tt := range tests {
+		var position testPosition
+		if err := DecodeCursor(tt.cursor, &position); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: DecodeCursor(%q) err = %v, want ErrInvalidCursor", tt.name, tt.cursor, err)
+		}
+	}
+}
+
+func TestParsePageRequest(t *testing.T) {
+	tests := []struct {
+		query string
+		limit int
+		ok    bool
+	}{
+		{"", DefaultPageLimit, true},
+		{"limit=1", 1, true},
+		{"limit=200", MaxPageLimit, true},
+		{"limit=0", 0, false},
+		{"limit=-5", 0, false},
+		{"limit=201", 0, false},
+		{"limit=ten", 0, false},
+	}
+	for _, tt := range tests {
+		request, err := ParsePageRequest(httptest.NewRequest("GET", "/words?"+tt.query, nil))
+		if (err == nil) != tt.ok {
+			t.Errorf("ParsePageRequest(%q) err = %v, want ok = %v", tt.query, err, tt.ok)
+			continue
+		}
+		if tt.ok && request.Limit != tt.limit {
+			t.Errorf("ParsePageRequest(%q).Limit = %d, want %d", tt.query, request.Limit, tt.limit)
+		}
+	}
+
+	request, err := ParsePageRequest(httptest.NewRequest("GET", "/words?cursor=abc&limit=10", nil))
+	if err != nil || request.Cursor != "abc" || request.Limit != 10 {
+		t.Errorf("ParsePageRequest = %+v, %v; want cursor abc and limit 10", request, err)
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	position := func(id int) interface{} { return testPosition{ID: id} }
+
+	tests := []struct {
+		name    string
+		items   []int
+		want    []int
+		hasMore bool
+		lastID  int
+	}{
+		{"nil items", nil, []int{}, false, 0},
+		{"fewer than limit", []int{1, 2}, []int{1, 2}, false, 0},
+		{"exactly limit", []int{1, 2, 3}, []int{1, 2, 3}, false, 0},
+		{"limit plus one", []int{1, 2, 3, 4}, []int{1, 2, 3}, true, 3},
+	}
+	for _, tt := range tests {
+		page, err := NewPage(tt.items, 3, 10, position)
+		if err != nil {
+			t.Fatalf("%s: NewPage: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(page.Items, tt.want) || page.HasMore != tt.hasMore || page.Total != 10 {
+			t.Errorf("%s: NewPage = %+v, want items %v, hasMore %v, total 10", tt.name, page, tt.want, tt.hasMore)
+		}
+		if !tt.hasMore {
+			if page.NextCursor != "" {
+				t.Errorf("%s: NextCursor = %q on the last page", tt.name, page.NextCursor)
+			}
+			continue
+		}
+
+		var next testPosition
+		if err := DecodeCursor(page.NextCursor, &next); err != nil || next.ID != tt.lastID {
+			t.Errorf("%s: next cursor = %+v, %v; want position of item %d", tt.name, next, err, tt.lastID)
+		}
+	}
+}
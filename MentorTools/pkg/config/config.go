@@ -13,10 +13,32 @@ type DBConfig struct {
 	Password string `yaml:"password"`
 	DBName   string `yaml:"dbname"`
 	SSLMode  string `yaml:"sslmode"`
+	// AutoMigrate applies pending schema migrations when the service connects to the database.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+// StorageConfig describes the blob store for generated media such as pronunciation audio.
+type StorageConfig struct {
+	Type      string   `yaml:"type"`       // "filesystem" or "s3"
+	Dir       string   `yaml:"dir"`        // Root directory of the filesystem store
+	PublicURL string   `yaml:"public_url"` // URL prefix the stored objects are served from
+	S3        S3Config `yaml:"s3"`
+}
+
+// S3Config describes an S3-compatible bucket (AWS S3, MinIO).
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"` // Scheme and host, e.g. "http://minio:9000"
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
 }
 
 type Config struct {
 	Databases map[string]DBConfig `yaml:"databases"`
+	Storage   StorageConfig       `yaml:"storage"`
+	// FrequencyLists maps a language code to a word frequency list file that replaces the built-in list.
+	FrequencyLists map[string]string `yaml:"frequency_lists"`
 }
 
 // LoadConfig loads the configuration from a YAML file.